@@ -2,16 +2,49 @@
 package config
 
 import (
+	"time"
+
 	"github.com/caarlos0/env/v6"
 )
 
 // Config configuration
 type Config struct {
 	PostgresURL string `env:"POSTGRES_URL"`
-	MongoURL    string `env:"MONGO_URL"`
-	ServerPort  string `env:"SERVER_ADDRESS"`
-	DBType      string `env:"DB_TYPE"`
-	RedisURL    string `env:"REDIS_URL"`
+	// MongoURL is the mongo replica set or sharded cluster, the standalone server can't run transactions
+	MongoURL   string `env:"MONGO_URL"`
+	ServerPort string `env:"SERVER_ADDRESS"`
+	DBType     string `env:"DB_TYPE"`
+	// RedisURL is the redis shared by the replicas, without it or with memory db
+	// the cache and the job locks are kept in the process
+	RedisURL string `env:"REDIS_URL"`
+	// MongoDatabase is the database of the mongo db
+	MongoDatabase string `env:"MONGO_DATABASE" envDefault:"cats"`
+	// MigrateOnStart applies the pending Postgres migrations before the server starts
+	MigrateOnStart bool `env:"MIGRATE_ON_START"`
+	// MemorySnapshot is the JSON file the memory db is loaded from on start and saved to on shutdown,
+	// empty path keeps the data only while the server runs
+	MemorySnapshot string `env:"MEMORY_SNAPSHOT"`
+	// SQLitePath is the database file of the sqlite db, it's created with the schema when missing
+	SQLitePath string `env:"SQLITE_PATH" envDefault:"cats.db"`
+	// AdminToken protects admin endpoints, they are disabled when it's empty
+	AdminToken string `env:"ADMIN_TOKEN"`
+	// PurgeRetention is how long deleted cats are kept before purge
+	PurgeRetention time.Duration `env:"PURGE_RETENTION" envDefault:"720h"`
+	// BlobStore is the storage of photo images, only local is supported
+	BlobStore string `env:"BLOB_STORE" envDefault:"local"`
+	// BlobDir is the directory of the local blob store
+	BlobDir string `env:"BLOB_DIR" envDefault:"./blobs"`
+	// PurgeSchedule is the cron schedule of the purge of deleted cats, empty schedule disables the job
+	PurgeSchedule string `env:"PURGE_SCHEDULE" envDefault:"0 3 * * *"`
+	// VaccinationReminderSchedule is the cron schedule of the vaccination reminders, empty schedule disables the job
+	VaccinationReminderSchedule string `env:"VACCINATION_REMINDER_SCHEDULE" envDefault:"0 8 * * *"`
+	// VaccinationReminderWindow is how long before the expiry the vaccination is reminded of
+	VaccinationReminderWindow time.Duration `env:"VACCINATION_REMINDER_WINDOW" envDefault:"336h"`
+	// VaccinationSyncSchedule is the cron schedule of the vaccinated flag sync with the expired vaccinations,
+	// empty schedule disables the job
+	VaccinationSyncSchedule string `env:"VACCINATION_SYNC_SCHEDULE" envDefault:"5 0 * * *"`
+	// OutboxPollInterval is how often the relay looks for the cache events to publish
+	OutboxPollInterval time.Duration `env:"OUTBOX_POLL_INTERVAL" envDefault:"1s"`
 }
 
 // New configuration
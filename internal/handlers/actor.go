@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"strings"
+
+	"github.com/catService/internal/service"
+
+	"github.com/labstack/echo/v4"
+)
+
+// headerActor introduces who makes the request, the name gets into the cat history.
+// The client only claims the name, nothing verifies it
+const headerActor = "X-Actor"
+
+// Actor puts the actor of the request into the request context. Requests with the admin token
+// are made by service.AdminActor, the others by the actor claimed in the X-Actor header
+func Actor(adminToken string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			actor := strings.TrimSpace(req.Header.Get(headerActor))
+			if key := strings.TrimPrefix(req.Header.Get(echo.HeaderAuthorization), "Bearer "); validAdminToken(adminToken, key) {
+				actor = service.AdminActor
+			}
+			if actor != "" {
+				c.SetRequest(req.WithContext(service.WithActor(req.Context(), actor)))
+			}
+			return next(c)
+		}
+	}
+}
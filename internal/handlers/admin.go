@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"time"
+
+	"github.com/catService/internal/service"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"github.com/sirupsen/logrus"
+)
+
+// AdminHandler contains links to services and maintenance settings
+type AdminHandler struct {
+	service        service.SheltersCatService
+	jobs           service.JobsService
+	purgeRetention time.Duration
+}
+
+// NewAdmin return AdminHandler
+func NewAdmin(s service.SheltersCatService, jobs service.JobsService, purgeRetention time.Duration) *AdminHandler {
+	return &AdminHandler{
+		service:        s,
+		jobs:           jobs,
+		purgeRetention: purgeRetention,
+	}
+}
+
+type purgeResponse struct {
+	Purged int64 `json:"purged"`
+}
+
+type jobRunResponse struct {
+	ID          uuid.UUID `json:"id"`
+	Owner       string    `json:"owner"`
+	ScheduledAt time.Time `json:"scheduled_at"`
+	StartedAt   time.Time `json:"started_at"`
+	FinishedAt  time.Time `json:"finished_at"`
+	Status      string    `json:"status"`
+	Result      string    `json:"result,omitempty"`
+	Error       string    `json:"error,omitempty"`
+}
+
+type jobResponse struct {
+	Name     string            `json:"name"`
+	Schedule string            `json:"schedule"`
+	NextRun  *time.Time        `json:"next_run,omitempty"`
+	Running  bool              `json:"running"`
+	Runs     []*jobRunResponse `json:"runs"`
+}
+
+type jobListResponse struct {
+	Jobs []*jobResponse `json:"jobs"`
+}
+
+// AdminOnly allows requests with "Authorization: Bearer <token>" header only.
+// Empty token denies all requests
+func AdminOnly(token string) echo.MiddlewareFunc {
+	return middleware.KeyAuth(func(key string, c echo.Context) (bool, error) {
+		return validAdminToken(token, key), nil
+	})
+}
+
+// validAdminToken reports whether the key is the admin token, empty token matches no key
+func validAdminToken(token, key string) bool {
+	return token != "" && subtle.ConstantTimeCompare([]byte(key), []byte(token)) == 1
+}
+
+// Purge permanently removes cats deleted longer than retention ago
+// @Summary      Purge deleted cats
+// @Tags         admin
+// @Description  permanently remove cats deleted longer than retention ago
+// @ID           purge-cats
+// @Produce      json
+// @Security     AdminToken
+// @Success      200  {object}  purgeResponse
+// @Failure      401  {string}  unauthorized
+// @Failure      500  {string}  internal error
+// @Router       /admin/cat/purge [post]
+func (hlr *AdminHandler) Purge(c echo.Context) error {
+	purged, err := hlr.service.Purge(c.Request().Context(), hlr.purgeRetention)
+	if err != nil {
+		logrus.Errorf("purge error %s", err)
+		return newHTTPError(err, "could not purge cats")
+	}
+
+	return c.JSON(http.StatusOK, purgeResponse{Purged: purged})
+}
+
+// Jobs returns the scheduled jobs with their run history
+// @Summary      List scheduled jobs
+// @Tags         admin
+// @Description  scheduled jobs with the next run on this replica and the latest runs on all replicas, the last run goes first.
+// @Description  Running shows the job runs on this replica now
+// @ID           list-jobs
+// @Produce      json
+// @Security     AdminToken
+// @Success      200  {object}  jobListResponse
+// @Failure      401  {string}  unauthorized
+// @Failure      500  {string}  internal error
+// @Router       /admin/jobs [get]
+func (hlr *AdminHandler) Jobs(c echo.Context) error {
+	jobs, err := hlr.jobs.Jobs(c.Request().Context())
+	if err != nil {
+		logrus.Errorf("jobs error %s", err)
+		return newHTTPError(err, "could not list jobs")
+	}
+
+	response := jobListResponse{Jobs: make([]*jobResponse, 0, len(jobs))}
+	for _, job := range jobs {
+		item := &jobResponse{
+			Name:     job.Name,
+			Schedule: job.Schedule,
+			Running:  job.Running,
+			Runs:     make([]*jobRunResponse, 0, len(job.Runs)),
+		}
+		if !job.NextRun.IsZero() {
+			item.NextRun = &job.NextRun
+		}
+		for _, run := range job.Runs {
+			item.Runs = append(item.Runs, &jobRunResponse{
+				ID:          run.ID,
+				Owner:       run.Owner,
+				ScheduledAt: run.ScheduledAt,
+				StartedAt:   run.StartedAt,
+				FinishedAt:  run.FinishedAt,
+				Status:      run.Status,
+				Result:      run.Result,
+				Error:       run.Error,
+			})
+		}
+		response.Jobs = append(response.Jobs, item)
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	servicemock "github.com/catService/internal/service/service_mock"
+
+	"github.com/catService/internal/model"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminHandler_Purge(t *testing.T) {
+	service := &servicemock.SheltersCatService{}
+	adminHandler := NewAdmin(service, &servicemock.JobsService{}, time.Hour)
+	service.On("Purge", context.Background(), time.Hour).Return(int64(3), nil)
+
+	e := echo.New()
+	e.POST("/v1/admin/cat/purge", adminHandler.Purge, AdminOnly("secret"))
+
+	tests := []struct {
+		name   string
+		header string
+		status int
+	}{
+		{name: "valid token", header: "Bearer secret", status: http.StatusOK},
+		{name: "wrong token", header: "Bearer guess", status: http.StatusUnauthorized},
+		{name: "no token", header: "", status: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/v1/admin/cat/purge", nil)
+			if tt.header != "" {
+				req.Header.Set(echo.HeaderAuthorization, tt.header)
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+			require.Equal(t, tt.status, rec.Code)
+		})
+	}
+}
+
+func TestAdminOnly_EmptyToken(t *testing.T) {
+	e := echo.New()
+	e.POST("/v1/admin/cat/purge", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	}, AdminOnly(""))
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/admin/cat/purge", nil)
+	req.Header.Set(echo.HeaderAuthorization, "Bearer ")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	require.NotEqual(t, http.StatusOK, rec.Code)
+}
+
+func TestAdminHandler_Jobs(t *testing.T) {
+	run := &model.JobRun{ID: uuid.New(), Job: "purge-deleted-cats", Owner: "replica-1", Status: model.JobFailed, Error: "db is down"}
+	jobs := &servicemock.JobsService{}
+	jobs.On("Jobs", context.Background()).Return([]*model.JobStatus{
+		{Name: "purge-deleted-cats", Schedule: "0 3 * * *", NextRun: time.Now().Add(time.Hour), Runs: []*model.JobRun{run}},
+	}, nil)
+
+	e := echo.New()
+	e.GET("/v1/admin/jobs", NewAdmin(&servicemock.SheltersCatService{}, jobs, time.Hour).Jobs, AdminOnly("secret"))
+	req := httptest.NewRequest(http.MethodGet, "/v1/admin/jobs", nil)
+	req.Header.Set(echo.HeaderAuthorization, "Bearer secret")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var response jobListResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	require.Len(t, response.Jobs, 1)
+	require.NotNil(t, response.Jobs[0].NextRun)
+	require.Equal(t, run.ID, response.Jobs[0].Runs[0].ID)
+	require.Equal(t, "db is down", response.Jobs[0].Runs[0].Error)
+}
@@ -0,0 +1,289 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/catService/internal/model"
+	"github.com/catService/internal/service"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+// AdopterHandler contain link to service
+type AdopterHandler struct {
+	service service.AdoptersService
+}
+
+// NewAdopter return AdopterHandler
+func NewAdopter(s service.AdoptersService) *AdopterHandler {
+	return &AdopterHandler{
+		service: s,
+	}
+}
+
+type adopterRequest struct {
+	Name        string                   `json:"name" validate:"required"`
+	Email       string                   `json:"email" validate:"omitempty,email"`
+	Phone       string                   `json:"phone"`
+	Address     string                   `json:"address"`
+	Household   householdRequest         `json:"household"`
+	Preferences adopterPreferenceRequest `json:"preferences"`
+}
+
+type householdRequest struct {
+	Adults    int    `json:"adults" validate:"min=0"`
+	Children  int    `json:"children" validate:"min=0"`
+	OtherPets bool   `json:"other_pets"`
+	HomeType  string `json:"home_type"`
+}
+
+// adopterPreferenceRequest contains the wishes of the adopter, omitted ones don't matter
+type adopterPreferenceRequest struct {
+	MinAge         *int   `json:"min_age" validate:"omitempty,min=0"`
+	MaxAge         *int   `json:"max_age" validate:"omitempty,min=0"`
+	Sex            string `json:"sex"`
+	VaccinatedOnly bool   `json:"vaccinated_only"`
+	GoodWithKids   bool   `json:"good_with_kids"`
+}
+
+type adopterListResponse struct {
+	Adopters   []*model.Adopter `json:"adopters"`
+	NextCursor string           `json:"next_cursor,omitempty"`
+}
+
+type catMatchResponse struct {
+	Cat     *model.Cat `json:"cat"`
+	Score   int        `json:"score"`
+	Reasons []string   `json:"reasons"`
+}
+
+type matchListResponse struct {
+	Matches []*catMatchResponse `json:"matches"`
+}
+
+// Create adopter
+// @Summary      Create adopter
+// @Tags         adopter
+// @Description  create adopter with household details and preferences, email or phone is required
+// @ID           create-adopter
+// @Accept       json
+// @Produce      json
+// @Param        input  body       adopterRequest  true  "Adopter info"
+// @Success      201  {object}  model.Adopter
+// @Failure      400  {string}  bad request
+// @Failure      422  {string}  unprocessable entity
+// @Failure      500  {string}  internal error
+// @Router       /adopter/ [post]
+func (hlr *AdopterHandler) Create(c echo.Context) error {
+	adopter, err := bindAdopter(c)
+	if err != nil {
+		return err
+	}
+
+	err = hlr.service.Create(c.Request().Context(), adopter)
+	if err != nil {
+		logrus.Errorf("create adopter error: %s", err)
+		return newHTTPError(err, "could not create adopter")
+	}
+
+	return c.JSON(http.StatusCreated, adopter)
+}
+
+// Get returns adopter by ID
+// @Summary      Get adopter by ID
+// @Tags         adopter
+// @Description  get adopter
+// @ID           get-adopter
+// @Produce      json
+// @Param        id  path       string  true  "Adopter ID"
+// @Success      200  {object}  model.Adopter
+// @Failure      400  {string}  bad request
+// @Failure      404  {string}  not found
+// @Failure      500  {string}  internal error
+// @Router       /adopter/{id} [get]
+func (hlr *AdopterHandler) Get(c echo.Context) error {
+	adopterID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err)
+	}
+
+	adopter, err := hlr.service.Get(c.Request().Context(), adopterID)
+	if err != nil {
+		logrus.Errorf("get adopter error %s", err)
+		return newHTTPError(err, "could not get adopter")
+	}
+
+	return c.JSON(http.StatusOK, adopter)
+}
+
+// Update adopter by ID
+// @Summary      Update adopter by ID
+// @Tags         adopter
+// @Description  update adopter
+// @ID           update-adopter
+// @Accept       json
+// @Produce      json
+// @Param        id     path       string          true  "Adopter ID"
+// @Param        input  body       adopterRequest  true  "Adopter info"
+// @Success      200  {object}  model.Adopter
+// @Failure      400  {string}  bad request
+// @Failure      404  {string}  not found
+// @Failure      422  {string}  unprocessable entity
+// @Failure      500  {string}  internal error
+// @Router       /adopter/{id} [put]
+func (hlr *AdopterHandler) Update(c echo.Context) error {
+	adopterID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err)
+	}
+	adopter, err := bindAdopter(c)
+	if err != nil {
+		return err
+	}
+	adopter.ID = adopterID
+
+	err = hlr.service.Update(c.Request().Context(), adopter)
+	if err != nil {
+		logrus.Errorf("adopter update error %s", err)
+		return newHTTPError(err, "could not update adopter")
+	}
+
+	return c.JSON(http.StatusOK, adopter)
+}
+
+// Delete adopter by ID
+// @Summary      Delete adopter by ID
+// @Tags         adopter
+// @Description  delete adopter
+// @ID           delete-adopter
+// @Param        id  path       string  true  "Adopter ID"
+// @Success      204  {string}  no content
+// @Failure      400  {string}  bad request
+// @Failure      404  {string}  not found
+// @Failure      500  {string}  internal error
+// @Router       /adopter/{id} [delete]
+func (hlr *AdopterHandler) Delete(c echo.Context) error {
+	adopterID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err)
+	}
+
+	err = hlr.service.Delete(c.Request().Context(), adopterID)
+	if err != nil {
+		logrus.Errorf("adopter delete error %s", err)
+		return newHTTPError(err, "could not delete adopter")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// List returns adopters page by page
+// @Summary      List adopters
+// @Tags         adopter
+// @Description  list adopters with cursor pagination
+// @ID           list-adopters
+// @Produce      json
+// @Param        limit   query      int     false  "Page size, 20 by default"
+// @Param        cursor  query      string  false  "Cursor of the next page"
+// @Success      200  {object}  adopterListResponse
+// @Failure      400  {string}  bad request
+// @Failure      422  {string}  unprocessable entity
+// @Failure      500  {string}  internal error
+// @Router       /adopter/ [get]
+func (hlr *AdopterHandler) List(c echo.Context) error {
+	limit, err := queryInt(c, "limit")
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err)
+	}
+	if limit == nil {
+		limit = new(int)
+	}
+
+	page, err := hlr.service.List(c.Request().Context(), *limit, c.QueryParam("cursor"))
+	if err != nil {
+		logrus.Errorf("adopter list error %s", err)
+		return newHTTPError(err, "could not list adopters")
+	}
+
+	return c.JSON(http.StatusOK, adopterListResponse{
+		Adopters:   page.Adopters,
+		NextCursor: page.NextCursor,
+	})
+}
+
+// Matches returns cats ranked against the adopter preferences
+// @Summary      Match cats for adopter
+// @Tags         adopter
+// @Description  available and returned cats ranked by the age range, good with kids, sex and vaccination.
+// @Description  Vaccinated-only adopters get vaccinated cats only, adopters with children don't get cats known to be bad with kids
+// @ID           match-adopter-cats
+// @Produce      json
+// @Param        id     path       string  true   "Adopter ID"
+// @Param        limit  query      int     false  "Number of matches, 20 by default"
+// @Success      200  {object}  matchListResponse
+// @Failure      400  {string}  bad request
+// @Failure      404  {string}  not found
+// @Failure      422  {string}  unprocessable entity
+// @Failure      500  {string}  internal error
+// @Router       /adopter/{id}/matches [get]
+func (hlr *AdopterHandler) Matches(c echo.Context) error {
+	adopterID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err)
+	}
+	limit, err := queryInt(c, "limit")
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err)
+	}
+	if limit == nil {
+		limit = new(int)
+	}
+
+	matches, err := hlr.service.Matches(c.Request().Context(), adopterID, *limit)
+	if err != nil {
+		logrus.Errorf("adopter matches error %s", err)
+		return newHTTPError(err, "could not match cats")
+	}
+
+	response := matchListResponse{Matches: make([]*catMatchResponse, 0, len(matches))}
+	for _, match := range matches {
+		response.Matches = append(response.Matches, &catMatchResponse{Cat: match.Cat, Score: match.Score, Reasons: match.Reasons})
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// bindAdopter reads and validates adopter from the request body
+func bindAdopter(c echo.Context) (*model.Adopter, error) {
+	var request adopterRequest
+	if err := c.Bind(&request); err != nil {
+		logrus.Errorf("bind failed: %s", err)
+		return nil, echo.NewHTTPError(http.StatusBadRequest)
+	}
+	if err := c.Validate(&request); err != nil {
+		logrus.Errorf("validate failed: %s", err)
+		return nil, echo.NewHTTPError(http.StatusUnprocessableEntity, err)
+	}
+
+	return &model.Adopter{
+		Name:    request.Name,
+		Email:   request.Email,
+		Phone:   request.Phone,
+		Address: request.Address,
+		Household: model.Household{
+			Adults:    request.Household.Adults,
+			Children:  request.Household.Children,
+			OtherPets: request.Household.OtherPets,
+			HomeType:  request.Household.HomeType,
+		},
+		Preferences: model.AdopterPreferences{
+			MinAge:         request.Preferences.MinAge,
+			MaxAge:         request.Preferences.MaxAge,
+			Sex:            request.Preferences.Sex,
+			VaccinatedOnly: request.Preferences.VaccinatedOnly,
+			GoodWithKids:   request.Preferences.GoodWithKids,
+		},
+	}, nil
+}
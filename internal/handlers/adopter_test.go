@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	servicemock "github.com/catService/internal/service/service_mock"
+
+	"github.com/catService/internal/model"
+	"github.com/catService/internal/validator"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdopterHandler_Create(t *testing.T) {
+	service := &servicemock.AdoptersService{}
+	service.On("Create", context.Background(), mock.MatchedBy(func(adopter *model.Adopter) bool {
+		return adopter.Name == "Kate" && adopter.Household.Children == 2 &&
+			*adopter.Preferences.MaxAge == 3 && adopter.Preferences.MinAge == nil && adopter.Preferences.VaccinatedOnly
+	})).Return(nil)
+
+	e := echo.New()
+	e.Validator = validator.NewValidator()
+	body := `{"name":"Kate","email":"kate@example.com","household":{"adults":2,"children":2},` +
+		`"preferences":{"max_age":3,"vaccinated_only":true}}`
+	req := httptest.NewRequest(http.MethodPost, "/v1/adopter/", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+
+	require.NoError(t, NewAdopter(service).Create(e.NewContext(req, rec)))
+	require.Equal(t, http.StatusCreated, rec.Code)
+	service.AssertExpectations(t)
+
+	req = httptest.NewRequest(http.MethodPost, "/v1/adopter/", strings.NewReader(`{"name":"Kate","email":"kate"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	err := NewAdopter(service).Create(e.NewContext(req, httptest.NewRecorder()))
+	require.Equal(t, http.StatusUnprocessableEntity, err.(*echo.HTTPError).Code)
+}
+
+func TestAdopterHandler_Matches(t *testing.T) {
+	id := uuid.New()
+	cat := &model.Cat{ID: uuid.New(), Name: "Cat 1", Age: 2}
+
+	service := &servicemock.AdoptersService{}
+	service.On("Matches", context.Background(), id, 5).
+		Return([]*model.CatMatch{{Cat: cat, Score: 3, Reasons: []string{model.MatchAgeInRange}}}, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/v1/adopter/?limit=5", nil)
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	ctx.SetParamNames("id")
+	ctx.SetParamValues(id.String())
+
+	require.NoError(t, NewAdopter(service).Matches(ctx))
+	require.Equal(t, http.StatusOK, rec.Code)
+	var response matchListResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	require.Len(t, response.Matches, 1)
+	require.Equal(t, cat.ID, response.Matches[0].Cat.ID)
+	require.Equal(t, 3, response.Matches[0].Score)
+	require.Equal(t, []string{model.MatchAgeInRange}, response.Matches[0].Reasons)
+}
+
+func TestAdopterHandler_MatchesNotFound(t *testing.T) {
+	id := uuid.New()
+	service := &servicemock.AdoptersService{}
+	service.On("Matches", context.Background(), id, 0).Return(nil, model.ErrAdopterNotFound)
+
+	e := echo.New()
+	ctx := e.NewContext(httptest.NewRequest(http.MethodGet, "/v1/adopter/", nil), httptest.NewRecorder())
+	ctx.SetParamNames("id")
+	ctx.SetParamValues(id.String())
+
+	err := NewAdopter(service).Matches(ctx)
+	require.Equal(t, http.StatusNotFound, err.(*echo.HTTPError).Code)
+}
@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/catService/internal/model"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+// Reserve cat by ID
+// @Summary      Reserve cat
+// @Tags         adoption
+// @Description  reserve available or returned cat for adoption
+// @ID           reserve-cat
+// @Produce      json
+// @Param        id        path       string  true   "Cat ID"
+// @Param        If-Match  header     string  false  "Expected ETag of the cat"
+// @Success      200  {object}  model.Cat
+// @Header       200  {string}  ETag  "New cat version"
+// @Failure      400  {string}  bad request
+// @Failure      404  {string}  not found
+// @Failure      409  {string}  conflict
+// @Failure      412  {string}  precondition failed
+// @Failure      500  {string}  internal error
+// @Router       /cat/{id}/reserve [post]
+func (hlr *CatHandler) Reserve(c echo.Context) error {
+	return hlr.transition(c, model.CatReserve)
+}
+
+// CancelReservation of cat by ID
+// @Summary      Cancel cat reservation
+// @Tags         adoption
+// @Description  make reserved cat available again
+// @ID           cancel-cat-reservation
+// @Produce      json
+// @Param        id        path       string  true   "Cat ID"
+// @Param        If-Match  header     string  false  "Expected ETag of the cat"
+// @Success      200  {object}  model.Cat
+// @Header       200  {string}  ETag  "New cat version"
+// @Failure      400  {string}  bad request
+// @Failure      404  {string}  not found
+// @Failure      409  {string}  conflict
+// @Failure      412  {string}  precondition failed
+// @Failure      500  {string}  internal error
+// @Router       /cat/{id}/cancel-reservation [post]
+func (hlr *CatHandler) CancelReservation(c echo.Context) error {
+	return hlr.transition(c, model.CatCancelReservation)
+}
+
+// Adopt cat by ID
+// @Summary      Adopt cat
+// @Tags         adoption
+// @Description  mark cat as adopted
+// @ID           adopt-cat
+// @Produce      json
+// @Param        id        path       string  true   "Cat ID"
+// @Param        If-Match  header     string  false  "Expected ETag of the cat"
+// @Success      200  {object}  model.Cat
+// @Header       200  {string}  ETag  "New cat version"
+// @Failure      400  {string}  bad request
+// @Failure      404  {string}  not found
+// @Failure      409  {string}  conflict
+// @Failure      412  {string}  precondition failed
+// @Failure      500  {string}  internal error
+// @Router       /cat/{id}/adopt [post]
+func (hlr *CatHandler) Adopt(c echo.Context) error {
+	return hlr.transition(c, model.CatAdopt)
+}
+
+// Return adopted cat by ID
+// @Summary      Return cat
+// @Tags         adoption
+// @Description  return adopted cat to the shelter
+// @ID           return-cat
+// @Produce      json
+// @Param        id        path       string  true   "Cat ID"
+// @Param        If-Match  header     string  false  "Expected ETag of the cat"
+// @Success      200  {object}  model.Cat
+// @Header       200  {string}  ETag  "New cat version"
+// @Failure      400  {string}  bad request
+// @Failure      404  {string}  not found
+// @Failure      409  {string}  conflict
+// @Failure      412  {string}  precondition failed
+// @Failure      500  {string}  internal error
+// @Router       /cat/{id}/return [post]
+func (hlr *CatHandler) Return(c echo.Context) error {
+	return hlr.transition(c, model.CatReturn)
+}
+
+// transition applies the adoption transition to the cat from the path
+func (hlr *CatHandler) transition(c echo.Context, transition string) error {
+	catID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err)
+	}
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		return err
+	}
+
+	cat, err := hlr.service.Transition(c.Request().Context(), catID, version, transition)
+	if err != nil {
+		logrus.Errorf("cat %s error %s", transition, err)
+		return newHTTPError(err, "could not "+transition+" cat")
+	}
+
+	c.Response().Header().Set(headerETag, etag(cat.Version))
+	return c.JSON(http.StatusOK, cat)
+}
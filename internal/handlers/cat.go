@@ -3,7 +3,10 @@ package handlers
 
 import (
 	"errors"
+	"io"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/catService/internal/model"
 	"github.com/catService/internal/service"
@@ -25,17 +28,80 @@ func NewCat(s service.SheltersCatService) *CatHandler {
 	}
 }
 
+// catProfileRequest contains the cat profile, age isn't required when birth date is known
+type catProfileRequest struct {
+	BirthDate          *time.Time `json:"birth_date"`
+	BirthDateEstimated bool       `json:"birth_date_estimated"`
+	Sex                string     `json:"sex"`
+	Breed              string     `json:"breed"`
+	CoatColor          string     `json:"coat_color"`
+	Neutered           *bool      `json:"neutered"`
+	IntakeDate         *time.Time `json:"intake_date"`
+	Microchip          *string    `json:"microchip"`
+	GoodWithKids       *bool      `json:"good_with_kids"`
+}
+
 type catCreateRequest struct {
-	Name       string `json:"name" bson:"name" validate:"required"`
-	Age        int    `json:"age"  bson:"age" validate:"required"`
-	Vaccinated bool   `json:"vaccinated" bson:"vaccinated"`
+	Name       string     `json:"name" bson:"name" validate:"required"`
+	Age        int        `json:"age"  bson:"age" validate:"required_without=BirthDate"`
+	Vaccinated bool       `json:"vaccinated" bson:"vaccinated"`
+	ShelterID  *uuid.UUID `json:"shelter_id" bson:"shelter_id"`
+	catProfileRequest
 }
 
 type catUpdateRequest struct {
-	ID         uuid.UUID `param:"id"`
-	Name       string    `json:"name" bson:"name" validate:"required"`
-	Age        int       `json:"age" bson:"age" validate:"required"`
-	Vaccinated bool      `json:"vaccinated" bson:"vaccinated"`
+	ID         uuid.UUID  `param:"id"`
+	Name       string     `json:"name" bson:"name" validate:"required"`
+	Age        int        `json:"age" bson:"age" validate:"required_without=BirthDate"`
+	Vaccinated bool       `json:"vaccinated" bson:"vaccinated"`
+	ShelterID  *uuid.UUID `json:"shelter_id" bson:"shelter_id"`
+	catProfileRequest
+}
+
+// profile returns the model profile of the request
+func (r *catProfileRequest) profile() model.CatProfile {
+	return model.CatProfile{
+		BirthDate:          r.BirthDate,
+		BirthDateEstimated: r.BirthDateEstimated,
+		Sex:                r.Sex,
+		Breed:              r.Breed,
+		CoatColor:          r.CoatColor,
+		Neutered:           r.Neutered,
+		IntakeDate:         r.IntakeDate,
+		Microchip:          r.Microchip,
+		GoodWithKids:       r.GoodWithKids,
+	}
+}
+
+// newCatProfileRequest returns the request with the model profile
+func newCatProfileRequest(profile *model.CatProfile) catProfileRequest {
+	return catProfileRequest{
+		BirthDate:          profile.BirthDate,
+		BirthDateEstimated: profile.BirthDateEstimated,
+		Sex:                profile.Sex,
+		Breed:              profile.Breed,
+		CoatColor:          profile.CoatColor,
+		Neutered:           profile.Neutered,
+		IntakeDate:         profile.IntakeDate,
+		Microchip:          profile.Microchip,
+		GoodWithKids:       profile.GoodWithKids,
+	}
+}
+
+// Patch content types
+const (
+	mimeMergePatch = "application/merge-patch+json"
+	mimeJSONPatch  = "application/json-patch+json"
+)
+
+type catListResponse struct {
+	Cats       []*model.Cat `json:"cats"`
+	NextCursor string       `json:"next_cursor,omitempty"`
+}
+
+type catHistoryResponse struct {
+	Events     []*model.CatEvent `json:"events"`
+	NextCursor string            `json:"next_cursor,omitempty"`
 }
 
 // Create cat
@@ -47,6 +113,8 @@ type catUpdateRequest struct {
 // @Param        input  body       catCreateRequest  true  "Cat info"
 // @Success      201  {integer}  integer  1
 // @Failure      400  {string}   bad request
+// @Failure      409  {string}   conflict
+// @Failure      422  {string}   unprocessable entity
 // @Failure      500  {string}   internal error
 // @Router       /cat/ [post]
 func (hlr *CatHandler) Create(c echo.Context) error {
@@ -67,11 +135,13 @@ func (hlr *CatHandler) Create(c echo.Context) error {
 	cat.Age = catRq.Age
 	cat.Name = catRq.Name
 	cat.Vaccinated = catRq.Vaccinated
+	cat.ShelterID = catRq.ShelterID
+	cat.CatProfile = catRq.profile()
 
 	err = hlr.service.Create(c.Request().Context(), &cat)
 	if err != nil {
 		logrus.Errorf("create error: %s", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, errors.New("could not create cat"))
+		return newHTTPError(err, "could not create cat")
 	}
 
 	return c.JSON(http.StatusCreated, &cat)
@@ -84,9 +154,13 @@ func (hlr *CatHandler) Create(c echo.Context) error {
 // @ID           get-cat
 // @Accept       json
 // @Produce      json
-// @Param        id   path      string  true  "Cat ID"
+// @Param        id             path      string  true   "Cat ID"
+// @Param        If-None-Match  header    string  false  "ETag of the cached cat"
 // @Success      200  {object}  model.Cat
-// @Failure      404	{string} bad request
+// @Success      304  {string}  not modified
+// @Header       200  {string}  ETag  "Cat version, photo changes increase it too"
+// @Failure      400  {string}   bad request
+// @Failure      404  {string}   not found
 // @Failure      500  {string}   internal error
 // @Router       /cat/{id} [get]
 func (hlr *CatHandler) Get(c echo.Context) error {
@@ -97,8 +171,49 @@ func (hlr *CatHandler) Get(c echo.Context) error {
 	cat, err := hlr.service.Get(c.Request().Context(), catID)
 	if err != nil {
 		logrus.Errorf("get cat error %s", err)
-		return echo.NewHTTPError(http.StatusNotFound, errors.New("could not get cat"))
+		return newHTTPError(err, "could not get cat")
+	}
+
+	tag := etag(cat.Version)
+	c.Response().Header().Set(headerETag, tag)
+	if c.Request().Header.Get(headerIfNoneMatch) == tag {
+		return c.NoContent(http.StatusNotModified)
+	}
+	cat, err = hlr.service.WithPhotos(c.Request().Context(), cat)
+	if err != nil {
+		logrus.Errorf("get cat photos error %s", err)
+		return newHTTPError(err, "could not get cat")
+	}
+
+	return c.JSON(http.StatusOK, cat)
+}
+
+// GetByMicrochip returns cat by microchip number
+// @Summary      Get cat by microchip
+// @Tags         cat
+// @Description  get cat by the scanned microchip number. 9-digit, 10-character hexadecimal and 15-digit ISO 11784 numbers
+// @Description  are accepted, spaces and dashes are ignored
+// @ID           get-cat-by-microchip
+// @Produce      json
+// @Param        chip  path      string  true  "Microchip number"
+// @Success      200  {object}  model.Cat
+// @Header       200  {string}  ETag  "Cat version, photo changes increase it too"
+// @Failure      404  {string}  not found
+// @Failure      422  {string}  unprocessable entity
+// @Failure      500  {string}  internal error
+// @Router       /cat/by-microchip/{chip} [get]
+func (hlr *CatHandler) GetByMicrochip(c echo.Context) error {
+	cat, err := hlr.service.GetByMicrochip(c.Request().Context(), c.Param("chip"))
+	if err != nil {
+		logrus.Errorf("get cat by microchip error %s", err)
+		return newHTTPError(err, "could not get cat")
+	}
+	cat, err = hlr.service.WithPhotos(c.Request().Context(), cat)
+	if err != nil {
+		logrus.Errorf("get cat photos error %s", err)
+		return newHTTPError(err, "could not get cat")
 	}
+	c.Response().Header().Set(headerETag, etag(cat.Version))
 
 	return c.JSON(http.StatusOK, cat)
 }
@@ -109,9 +224,12 @@ func (hlr *CatHandler) Get(c echo.Context) error {
 // @Description  delete cat
 // @ID           delete-cat
 // @Accept       json
-// @Param        id   path       string   true  "Cat ID"
+// @Param        id        path       string   true   "Cat ID"
+// @Param        If-Match  header     string   false  "Expected ETag of the cat"
 // @Success      200  {integer}  integer  1
-// @Failure      404 {string} bad request
+// @Failure      400  {string}   bad request
+// @Failure      404  {string}   not found
+// @Failure      412  {string}   precondition failed
 // @Failure      500  {string}   internal error
 // @Router       /cat/{id} [delete]
 func (hlr *CatHandler) Delete(c echo.Context) error {
@@ -123,10 +241,14 @@ func (hlr *CatHandler) Delete(c echo.Context) error {
 	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, err)
 	}
-	err = hlr.service.Delete(c.Request().Context(), catID)
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		return err
+	}
+	err = hlr.service.Delete(c.Request().Context(), catID, version)
 	if err != nil {
 		logrus.Errorf("cat delete error %s", err)
-		return echo.NewHTTPError(http.StatusNotFound, errors.New("could not delete cat"))
+		return newHTTPError(err, "could not delete cat")
 	}
 	return c.JSON(http.StatusOK, ok)
 }
@@ -137,12 +259,17 @@ func (hlr *CatHandler) Delete(c echo.Context) error {
 // @Description  update cat
 // @ID           update-cat
 // @Accept       json
-// @Param        id     path       string            true  "Cat ID"
-// @Param        input  body       catUpdateRequest  true  "Cat info"
+// @Param        id        path       string            true   "Cat ID"
+// @Param        If-Match  header     string            false  "Expected ETag of the cat"
+// @Param        input     body       catUpdateRequest  true   "Cat info"
 // @Success      201    {integer}  integer           1
-// @Failure      404 {string} bad request
-// @Failure      500    {integer}  integer           1
-// @Failure      500  {string}   internal error
+// @Header       201    {string}   ETag  "New cat version"
+// @Failure      400    {string}   bad request
+// @Failure      404    {string}   not found
+// @Failure      409    {string}   conflict
+// @Failure      412    {string}   precondition failed
+// @Failure      422    {string}   unprocessable entity
+// @Failure      500    {string}   internal error
 // @Router       /cat/{id} [put]
 func (hlr *CatHandler) Update(c echo.Context) error {
 	var cat model.Cat
@@ -163,12 +290,244 @@ func (hlr *CatHandler) Update(c echo.Context) error {
 	cat.Age = catRq.Age
 	cat.Name = catRq.Name
 	cat.Vaccinated = catRq.Vaccinated
+	cat.ShelterID = catRq.ShelterID
+	cat.CatProfile = catRq.profile()
+	cat.Version, err = ifMatchVersion(c)
+	if err != nil {
+		return err
+	}
 
 	err = hlr.service.Update(c.Request().Context(), &cat)
 	if err != nil {
 		logrus.Errorf("cat update error %s", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, errors.New("could not update cat"))
+		return newHTTPError(err, "could not update cat")
 	}
 
+	c.Response().Header().Set(headerETag, etag(cat.Version))
 	return c.JSON(http.StatusCreated, cat)
 }
+
+// List returns cats page by page
+// @Summary      List cats
+// @Tags         cat
+// @Description  list cats with filtering, sorting and cursor pagination
+// @ID           list-cats
+// @Produce      json
+// @Param        vaccinated  query      bool    false  "Vaccination status"
+// @Param        min_age     query      int     false  "Minimal age"
+// @Param        max_age     query      int     false  "Maximal age"
+// @Param        name        query      string  false  "Name prefix"
+// @Param        deleted     query      string  false  "Deleted cats: include or only, hidden by default"
+// @Param        shelter_id  query      string  false  "Shelter ID"
+// @Param        status      query      string  false  "Adoption status: available, reserved, adopted or returned"
+// @Param        in_foster   query      bool    false  "Cats which are with a foster now or which are not"
+// @Param        sort        query      string  false  "Sort field: id, name, age or vaccinated. Prefix - means descending order"
+// @Param        limit       query      int     false  "Page size, 20 by default"
+// @Param        cursor      query      string  false  "Cursor of the next page"
+// @Success      200  {object}  catListResponse
+// @Failure      400  {string}   bad request
+// @Failure      422  {string}   unprocessable entity
+// @Failure      500  {string}   internal error
+// @Router       /cat/ [get]
+func (hlr *CatHandler) List(c echo.Context) error {
+	query, err := bindCatQuery(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err)
+	}
+
+	page, err := hlr.service.List(c.Request().Context(), query)
+	if err != nil {
+		logrus.Errorf("cat list error %s", err)
+		return newHTTPError(err, "could not list cats")
+	}
+
+	return c.JSON(http.StatusOK, catListResponse{
+		Cats:       page.Cats,
+		NextCursor: page.NextCursor,
+	})
+}
+
+// bindCatQuery reads the list query from the query parameters
+func bindCatQuery(c echo.Context) (*model.CatQuery, error) {
+	filter, err := bindCatFilter(c)
+	if err != nil {
+		return nil, err
+	}
+	query := &model.CatQuery{
+		CatFilter: *filter,
+		SortBy:    c.QueryParam("sort"),
+		Cursor:    c.QueryParam("cursor"),
+	}
+	if strings.HasPrefix(query.SortBy, "-") {
+		query.SortBy = strings.TrimPrefix(query.SortBy, "-")
+		query.Desc = true
+	}
+
+	limit, err := queryInt(c, "limit")
+	if err != nil {
+		return nil, err
+	}
+	if limit != nil {
+		query.Limit = *limit
+	}
+
+	return query, nil
+}
+
+// bindCatFilter reads the cat filter from the query parameters
+func bindCatFilter(c echo.Context) (*model.CatFilter, error) {
+	filter := &model.CatFilter{
+		NamePrefix: c.QueryParam("name"),
+		Status:     c.QueryParam("status"),
+		Deleted:    c.QueryParam("deleted"),
+	}
+
+	var err error
+	if filter.ShelterID, err = queryUUID(c, "shelter_id"); err != nil {
+		return nil, err
+	}
+	if filter.Vaccinated, err = queryBool(c, "vaccinated"); err != nil {
+		return nil, err
+	}
+	if filter.MinAge, err = queryInt(c, "min_age"); err != nil {
+		return nil, err
+	}
+	if filter.MaxAge, err = queryInt(c, "max_age"); err != nil {
+		return nil, err
+	}
+	if filter.InFoster, err = queryBool(c, "in_foster"); err != nil {
+		return nil, err
+	}
+
+	return filter, nil
+}
+
+// Patch cat by ID
+// @Summary      Patch cat by ID
+// @Tags         cat
+// @Description  partial update of cat with JSON Merge Patch (RFC 7386) or JSON Patch (RFC 6902)
+// @ID           patch-cat
+// @Accept       application/merge-patch+json,application/json-patch+json
+// @Produce      json
+// @Param        id        path       string  true   "Cat ID"
+// @Param        If-Match  header     string  false  "Expected ETag of the cat"
+// @Param        input     body       object  true   "Merge patch or JSON patch document"
+// @Success      200    {object}   model.Cat
+// @Header       200    {string}   ETag  "New cat version"
+// @Failure      400    {string}   bad request
+// @Failure      404    {string}   not found
+// @Failure      409    {string}   conflict
+// @Failure      412    {string}   precondition failed
+// @Failure      415    {string}   unsupported media type
+// @Failure      422    {string}   unprocessable entity
+// @Failure      500    {string}   internal error
+// @Router       /cat/{id} [patch]
+func (hlr *CatHandler) Patch(c echo.Context) error {
+	catID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err)
+	}
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		return err
+	}
+	var format string
+	switch strings.TrimSpace(strings.Split(c.Request().Header.Get(echo.HeaderContentType), ";")[0]) {
+	case mimeMergePatch:
+		format = service.MergePatch
+	case mimeJSONPatch:
+		format = service.JSONPatch
+	default:
+		return echo.NewHTTPError(http.StatusUnsupportedMediaType, errors.New("patch must be merge-patch+json or json-patch+json"))
+	}
+	document, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		logrus.Errorf("read patch failed: %s", err)
+		return echo.NewHTTPError(http.StatusBadRequest)
+	}
+
+	cat, err := hlr.service.ApplyPatch(c.Request().Context(), catID, version, format, document)
+	if err != nil {
+		logrus.Errorf("cat patch error %s", err)
+		return newHTTPError(err, "could not patch cat")
+	}
+
+	c.Response().Header().Set(headerETag, etag(cat.Version))
+	return c.JSON(http.StatusOK, cat)
+}
+
+// Restore deleted cat by ID
+// @Summary      Restore deleted cat by ID
+// @Tags         cat
+// @Description  restore deleted cat
+// @ID           restore-cat
+// @Produce      json
+// @Param        id        path       string  true   "Cat ID"
+// @Param        If-Match  header     string  false  "Expected ETag of the cat"
+// @Success      200    {object}   model.Cat
+// @Header       200    {string}   ETag  "New cat version"
+// @Failure      400    {string}   bad request
+// @Failure      404    {string}   not found
+// @Failure      409    {string}   conflict
+// @Failure      412    {string}   precondition failed
+// @Failure      500    {string}   internal error
+// @Router       /cat/{id}/restore [post]
+func (hlr *CatHandler) Restore(c echo.Context) error {
+	catID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err)
+	}
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		return err
+	}
+
+	cat, err := hlr.service.Restore(c.Request().Context(), catID, version)
+	if err != nil {
+		logrus.Errorf("cat restore error %s", err)
+		return newHTTPError(err, "could not restore cat")
+	}
+
+	c.Response().Header().Set(headerETag, etag(cat.Version))
+	return c.JSON(http.StatusOK, cat)
+}
+
+// History of cat changes by ID
+// @Summary      History of cat changes
+// @Tags         cat
+// @Description  changes of cat in chronological order with cursor pagination.
+// @Description  Actor of the change is admin for requests with the admin token, otherwise the name claimed in X-Actor header, it isn't verified
+// @ID           cat-history
+// @Produce      json
+// @Param        id      path       string  true   "Cat ID"
+// @Param        limit   query      int     false  "Page size, 20 by default"
+// @Param        cursor  query      string  false  "Cursor of the next page"
+// @Success      200  {object}  catHistoryResponse
+// @Failure      400  {string}  bad request
+// @Failure      422  {string}  unprocessable entity
+// @Failure      500  {string}  internal error
+// @Router       /cat/{id}/history [get]
+func (hlr *CatHandler) History(c echo.Context) error {
+	catID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err)
+	}
+	limit, err := queryInt(c, "limit")
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err)
+	}
+	if limit == nil {
+		limit = new(int)
+	}
+
+	page, err := hlr.service.History(c.Request().Context(), catID, *limit, c.QueryParam("cursor"))
+	if err != nil {
+		logrus.Errorf("cat history error %s", err)
+		return newHTTPError(err, "could not get cat history")
+	}
+
+	return c.JSON(http.StatusOK, catHistoryResponse{
+		Events:     page.Events,
+		NextCursor: page.NextCursor,
+	})
+}
@@ -2,18 +2,23 @@ package handlers
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	servicemock "github.com/catService/internal/service/service_mock"
 
 	"github.com/catService/internal/model"
+	"github.com/catService/internal/service"
 	"github.com/catService/internal/validator"
 
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -29,6 +34,7 @@ func TestCatHandler_Get(t *testing.T) {
 	catHandler := NewCat(service)
 
 	service.On("Get", context.Background(), input.ID).Return(input, nil)
+	service.On("WithPhotos", context.Background(), input).Return(input, nil)
 	e := echo.New()
 	e.Validator = validator.NewValidator()
 	req := httptest.NewRequest(http.MethodGet, "/v1/", nil)
@@ -70,6 +76,58 @@ func TestCatHandler_Create(t *testing.T) {
 	require.Nil(t, err)
 }
 
+func TestCatHandler_CreateProfile(t *testing.T) {
+	service := &servicemock.SheltersCatService{}
+	catHandler := NewCat(service)
+	service.On("Create", context.Background(), mock.MatchedBy(func(cat *model.Cat) bool {
+		return cat.Age == 0 && cat.BirthDate != nil && cat.BirthDate.Year() == 2020 && cat.Sex == model.CatMale &&
+			cat.Microchip != nil && *cat.Microchip == "985112000123456"
+	})).Return(nil)
+
+	e := echo.New()
+	e.Validator = validator.NewValidator()
+	catJSON := `{"name":"Cat 21","birth_date":"2020-05-03T00:00:00Z","sex":"male","microchip":"985112000123456"}`
+	req := httptest.NewRequest(http.MethodPost, "/v1/cat/", strings.NewReader(catJSON))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	require.NoError(t, catHandler.Create(e.NewContext(req, rec)))
+	require.Equal(t, http.StatusCreated, rec.Code)
+	service.AssertExpectations(t)
+
+	req = httptest.NewRequest(http.MethodPost, "/v1/cat/", strings.NewReader(`{"name":"Cat 22","sex":"male"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	err := catHandler.Create(e.NewContext(req, httptest.NewRecorder()))
+	require.Equal(t, http.StatusUnprocessableEntity, err.(*echo.HTTPError).Code)
+}
+
+func TestCatHandler_GetByMicrochip(t *testing.T) {
+	cat := &model.Cat{ID: uuid.New(), Name: "Cat 1", Version: 3}
+
+	service := &servicemock.SheltersCatService{}
+	service.On("GetByMicrochip", context.Background(), "985112000123456").Return(cat, nil)
+	service.On("WithPhotos", context.Background(), cat).Return(cat, nil)
+	service.On("GetByMicrochip", context.Background(), "985112000654321").Return(nil, model.ErrCatNotFound)
+	service.On("GetByMicrochip", context.Background(), "123").Return(nil, model.ErrInvalid)
+	catHandler := NewCat(service)
+
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(httptest.NewRequest(http.MethodGet, "/v1/cat/by-microchip/985112000123456", nil), rec)
+	ctx.SetParamNames("chip")
+	ctx.SetParamValues("985112000123456")
+	require.NoError(t, catHandler.GetByMicrochip(ctx))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, `"3"`, rec.Header().Get(headerETag))
+
+	for chip, code := range map[string]int{"985112000654321": http.StatusNotFound, "123": http.StatusUnprocessableEntity} {
+		ctx = e.NewContext(httptest.NewRequest(http.MethodGet, "/v1/cat/by-microchip/"+chip, nil), httptest.NewRecorder())
+		ctx.SetParamNames("chip")
+		ctx.SetParamValues(chip)
+		err := catHandler.GetByMicrochip(ctx)
+		require.Equal(t, code, err.(*echo.HTTPError).Code)
+	}
+}
+
 func TestCatHandler_Delete(t *testing.T) {
 	input := &model.Cat{
 		ID:         uuid.MustParse("a0664c54-4ad3-4445-bb25-fb34f2ff67fc"),
@@ -81,7 +139,7 @@ func TestCatHandler_Delete(t *testing.T) {
 	service := &servicemock.SheltersCatService{}
 	catHandler := NewCat(service)
 
-	service.On("Delete", context.Background(), input.ID).Return(nil)
+	service.On("Delete", context.Background(), input.ID, model.AnyVersion).Return(nil)
 	e := echo.New()
 	e.Validator = validator.NewValidator()
 	req := httptest.NewRequest(http.MethodDelete, "/v1/", nil)
@@ -123,3 +181,441 @@ func TestCatHandler_Update(t *testing.T) {
 	require.Equal(t, http.StatusCreated, rec.Code)
 	require.Nil(t, err)
 }
+
+func TestCatHandler_GetNotFound(t *testing.T) {
+	id := uuid.MustParse("a0664c54-4ad3-4445-bb25-fb34f2ff67fc")
+
+	service := &servicemock.SheltersCatService{}
+	catHandler := NewCat(service)
+
+	service.On("Get", context.Background(), id).Return(nil, model.ErrCatNotFound)
+	e := echo.New()
+	e.Validator = validator.NewValidator()
+	req := httptest.NewRequest(http.MethodGet, "/v1/", nil)
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	ctx.SetPath("/cat/:id")
+	ctx.SetParamNames("id")
+	ctx.SetParamValues(id.String())
+	err := catHandler.Get(ctx)
+	require.Error(t, err)
+	require.Equal(t, http.StatusNotFound, err.(*echo.HTTPError).Code)
+}
+
+func TestCatHandler_UpdateErrors(t *testing.T) {
+	input := &model.Cat{
+		ID:   uuid.MustParse("a0664c54-4ad3-4445-bb25-fb34f2ff67fc"),
+		Name: "Cat 211",
+		Age:  4,
+	}
+	catJSON := `{"name":"Cat 211","age":4,"vaccinated":false}`
+
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{name: "not found", err: model.ErrCatNotFound, status: http.StatusNotFound},
+		{name: "invalid", err: model.ErrInvalid, status: http.StatusUnprocessableEntity},
+		{name: "conflict", err: model.ErrConflict, status: http.StatusConflict},
+		{name: "internal", err: errors.New("connection refused"), status: http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &servicemock.SheltersCatService{}
+			catHandler := NewCat(service)
+
+			service.On("Update", context.Background(), input).Return(fmt.Errorf("update cat: %w", tt.err))
+			e := echo.New()
+			e.Validator = validator.NewValidator()
+			req := httptest.NewRequest(http.MethodPut, "/v1/", strings.NewReader(catJSON))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+			ctx.SetPath("/cat/:id")
+			ctx.SetParamNames("id")
+			ctx.SetParamValues(input.ID.String())
+			err := catHandler.Update(ctx)
+			require.Error(t, err)
+			require.Equal(t, tt.status, err.(*echo.HTTPError).Code)
+		})
+	}
+}
+
+func TestCatHandler_List(t *testing.T) {
+	vaccinated, minAge := true, 1
+	query := &model.CatQuery{
+		CatFilter: model.CatFilter{
+			Vaccinated: &vaccinated,
+			MinAge:     &minAge,
+			NamePrefix: "Ca",
+		},
+		SortBy: "name",
+		Desc:   true,
+		Limit:  10,
+	}
+	page := &model.CatPage{
+		Cats:       []*model.Cat{{ID: uuid.New(), Name: "Cat 1", Age: 2, Vaccinated: true}},
+		NextCursor: "next",
+	}
+
+	service := &servicemock.SheltersCatService{}
+	catHandler := NewCat(service)
+
+	service.On("List", context.Background(), query).Return(page, nil)
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/v1/cat/?vaccinated=true&min_age=1&name=Ca&sort=-name&limit=10", nil)
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	err := catHandler.List(ctx)
+	require.Nil(t, err)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), `"next_cursor":"next"`)
+}
+
+func TestCatHandler_ListBadQuery(t *testing.T) {
+	catHandler := NewCat(&servicemock.SheltersCatService{})
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/v1/cat/?min_age=two", nil)
+	rec := httptest.NewRecorder()
+	err := catHandler.List(e.NewContext(req, rec))
+	require.Error(t, err)
+	require.Equal(t, http.StatusBadRequest, err.(*echo.HTTPError).Code)
+}
+
+func TestCatHandler_Patch(t *testing.T) {
+	id := uuid.MustParse("a0664c54-4ad3-4445-bb25-fb34f2ff67fc")
+	patched := &model.Cat{ID: id, Name: "Cat 1", Age: 2, Vaccinated: true, Version: 3}
+
+	tests := []struct {
+		name        string
+		contentType string
+		format      string
+		body        string
+	}{
+		{name: "merge patch", contentType: "application/merge-patch+json", format: service.MergePatch, body: `{"vaccinated":true}`},
+		{name: "json patch", contentType: "application/json-patch+json", format: service.JSONPatch, body: `[{"op":"replace","path":"/vaccinated","value":true}]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			catService := &servicemock.SheltersCatService{}
+			catHandler := NewCat(catService)
+			catService.On("ApplyPatch", context.Background(), id, model.AnyVersion, tt.format, []byte(tt.body)).Return(patched, nil)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPatch, "/v1/", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, tt.contentType)
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+			ctx.SetPath("/cat/:id")
+			ctx.SetParamNames("id")
+			ctx.SetParamValues(id.String())
+			err := catHandler.Patch(ctx)
+			require.Nil(t, err)
+			require.Equal(t, http.StatusOK, rec.Code)
+			require.Equal(t, `"3"`, rec.Header().Get("ETag"))
+			catService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCatHandler_PatchErrors(t *testing.T) {
+	id := uuid.MustParse("a0664c54-4ad3-4445-bb25-fb34f2ff67fc")
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		err         error
+		status      int
+	}{
+		{name: "unsupported content type", contentType: echo.MIMEApplicationJSON, body: `{"age":3}`, status: http.StatusUnsupportedMediaType},
+		{name: "invalid patch", contentType: "application/merge-patch+json", body: `{"color":"black"}`,
+			err: fmt.Errorf("%w: json: unknown field \"color\"", model.ErrInvalid), status: http.StatusUnprocessableEntity},
+		{name: "changed cat", contentType: "application/json-patch+json", body: `[{"op":"replace","path":"/age","value":5}]`,
+			err: model.ErrVersionMismatch, status: http.StatusPreconditionFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			catService := &servicemock.SheltersCatService{}
+			catHandler := NewCat(catService)
+			catService.On("ApplyPatch", context.Background(), id, model.AnyVersion, mock.Anything, mock.Anything).Return(nil, tt.err)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPatch, "/v1/", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, tt.contentType)
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+			ctx.SetPath("/cat/:id")
+			ctx.SetParamNames("id")
+			ctx.SetParamValues(id.String())
+			err := catHandler.Patch(ctx)
+			require.Error(t, err)
+			require.Equal(t, tt.status, err.(*echo.HTTPError).Code)
+		})
+	}
+}
+
+func TestCatHandler_GetETag(t *testing.T) {
+	input := &model.Cat{
+		ID:      uuid.MustParse("a0664c54-4ad3-4445-bb25-fb34f2ff67fc"),
+		Name:    "Cat 1",
+		Age:     2,
+		Version: 7,
+	}
+
+	service := &servicemock.SheltersCatService{}
+	catHandler := NewCat(service)
+	service.On("Get", context.Background(), input.ID).Return(input, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/v1/", nil)
+	req.Header.Set("If-None-Match", `"7"`)
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	ctx.SetPath("/cat/:id")
+	ctx.SetParamNames("id")
+	ctx.SetParamValues(input.ID.String())
+	err := catHandler.Get(ctx)
+	require.Nil(t, err)
+	require.Equal(t, http.StatusNotModified, rec.Code)
+	require.Equal(t, `"7"`, rec.Header().Get("ETag"))
+}
+
+func TestCatHandler_UpdateIfMatch(t *testing.T) {
+	id := uuid.MustParse("a0664c54-4ad3-4445-bb25-fb34f2ff67fc")
+	catJSON := `{"name":"Cat 211","age":4,"vaccinated":false}`
+
+	tests := []struct {
+		name    string
+		ifMatch string
+		err     error
+		status  int
+	}{
+		{name: "matching version", ifMatch: `"3"`, status: http.StatusCreated},
+		{name: "stale version", ifMatch: `"3"`, err: model.ErrVersionMismatch, status: http.StatusPreconditionFailed},
+		{name: "weak tag", ifMatch: `W/"3"`, status: http.StatusPreconditionFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &servicemock.SheltersCatService{}
+			catHandler := NewCat(service)
+			input := &model.Cat{ID: id, Name: "Cat 211", Age: 4, Version: 3}
+			service.On("Update", context.Background(), input).Return(tt.err).Run(func(args mock.Arguments) {
+				args.Get(1).(*model.Cat).Version = 4
+			})
+
+			e := echo.New()
+			e.Validator = validator.NewValidator()
+			req := httptest.NewRequest(http.MethodPut, "/v1/", strings.NewReader(catJSON))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req.Header.Set("If-Match", tt.ifMatch)
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+			ctx.SetPath("/cat/:id")
+			ctx.SetParamNames("id")
+			ctx.SetParamValues(id.String())
+			err := catHandler.Update(ctx)
+			if tt.status == http.StatusCreated {
+				require.Nil(t, err)
+				require.Equal(t, `"4"`, rec.Header().Get("ETag"))
+				return
+			}
+			require.Error(t, err)
+			require.Equal(t, tt.status, err.(*echo.HTTPError).Code)
+		})
+	}
+}
+
+func TestCatHandler_Restore(t *testing.T) {
+	id := uuid.MustParse("a0664c54-4ad3-4445-bb25-fb34f2ff67fc")
+
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{name: "restored", status: http.StatusOK},
+		{name: "not deleted", err: model.ErrConflict, status: http.StatusConflict},
+		{name: "not found", err: model.ErrCatNotFound, status: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &servicemock.SheltersCatService{}
+			catHandler := NewCat(service)
+			var restored *model.Cat
+			if tt.err == nil {
+				restored = &model.Cat{ID: id, Name: "Cat 1", Age: 2, Version: 5}
+			}
+			service.On("Restore", context.Background(), id, model.AnyVersion).Return(restored, tt.err)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/v1/", nil)
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+			ctx.SetPath("/cat/:id/restore")
+			ctx.SetParamNames("id")
+			ctx.SetParamValues(id.String())
+			err := catHandler.Restore(ctx)
+			if tt.err == nil {
+				require.Nil(t, err)
+				require.Equal(t, tt.status, rec.Code)
+				require.Equal(t, `"5"`, rec.Header().Get("ETag"))
+				return
+			}
+			require.Error(t, err)
+			require.Equal(t, tt.status, err.(*echo.HTTPError).Code)
+		})
+	}
+}
+
+func TestCatHandler_History(t *testing.T) {
+	id := uuid.MustParse("a0664c54-4ad3-4445-bb25-fb34f2ff67fc")
+	page := &model.CatEventPage{
+		Events:     []*model.CatEvent{{ID: uuid.New(), CatID: id, Action: model.CatEventCreate}},
+		NextCursor: "next",
+	}
+
+	service := &servicemock.SheltersCatService{}
+	catHandler := NewCat(service)
+	service.On("History", context.Background(), id, 5, "abc").Return(page, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/v1/cat/"+id.String()+"/history?limit=5&cursor=abc", nil)
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	ctx.SetPath("/cat/:id/history")
+	ctx.SetParamNames("id")
+	ctx.SetParamValues(id.String())
+	err := catHandler.History(ctx)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), `"next_cursor":"next"`)
+}
+
+func TestActor(t *testing.T) {
+	var actor string
+	handler := Actor("secret")(func(c echo.Context) error {
+		actor = service.ActorFromContext(c.Request().Context())
+		return nil
+	})
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodDelete, "/v1/cat/", nil)
+	req.Header.Set(headerActor, "volunteer")
+	require.NoError(t, handler(e.NewContext(req, httptest.NewRecorder())))
+	require.Equal(t, "volunteer", actor)
+
+	req = httptest.NewRequest(http.MethodDelete, "/v1/cat/", nil)
+	require.NoError(t, handler(e.NewContext(req, httptest.NewRecorder())))
+	require.Equal(t, service.AnonymousActor, actor)
+
+	// the admin token identifies the actor, the claimed name is ignored then
+	req = httptest.NewRequest(http.MethodDelete, "/v1/cat/", nil)
+	req.Header.Set(headerActor, "volunteer")
+	req.Header.Set(echo.HeaderAuthorization, "Bearer secret")
+	require.NoError(t, handler(e.NewContext(req, httptest.NewRecorder())))
+	require.Equal(t, service.AdminActor, actor)
+
+	req = httptest.NewRequest(http.MethodDelete, "/v1/cat/", nil)
+	req.Header.Set(headerActor, "volunteer")
+	req.Header.Set(echo.HeaderAuthorization, "Bearer guess")
+	require.NoError(t, handler(e.NewContext(req, httptest.NewRecorder())))
+	require.Equal(t, "volunteer", actor)
+
+	req = httptest.NewRequest(http.MethodDelete, "/v1/cat/", nil)
+	req.Header.Set(echo.HeaderAuthorization, "Bearer ")
+	require.NoError(t, Actor("")(func(c echo.Context) error {
+		actor = service.ActorFromContext(c.Request().Context())
+		return nil
+	})(e.NewContext(req, httptest.NewRecorder())))
+	require.Equal(t, service.AnonymousActor, actor)
+}
+
+func TestCatHandler_Transitions(t *testing.T) {
+	id := uuid.MustParse("a0664c54-4ad3-4445-bb25-fb34f2ff67fc")
+	tests := []struct {
+		transition string
+		handler    func(*CatHandler) echo.HandlerFunc
+		err        error
+		status     int
+	}{
+		{transition: model.CatReserve, handler: func(h *CatHandler) echo.HandlerFunc { return h.Reserve }, status: http.StatusOK},
+		{transition: model.CatCancelReservation, handler: func(h *CatHandler) echo.HandlerFunc { return h.CancelReservation }, status: http.StatusOK},
+		{transition: model.CatAdopt, handler: func(h *CatHandler) echo.HandlerFunc { return h.Adopt }, status: http.StatusOK},
+		{transition: model.CatReturn, handler: func(h *CatHandler) echo.HandlerFunc { return h.Return }, err: model.ErrConflict, status: http.StatusConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.transition, func(t *testing.T) {
+			service := &servicemock.SheltersCatService{}
+			if tt.err != nil {
+				service.On("Transition", context.Background(), id, int64(4), tt.transition).Return(nil, tt.err)
+			} else {
+				service.On("Transition", context.Background(), id, int64(4), tt.transition).Return(&model.Cat{ID: id, Version: 5}, nil)
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/v1/cat/", nil)
+			req.Header.Set(headerIfMatch, `"4"`)
+			rec := httptest.NewRecorder()
+			ctx := e.NewContext(req, rec)
+			ctx.SetParamNames("id")
+			ctx.SetParamValues(id.String())
+			err := tt.handler(NewCat(service))(ctx)
+			if tt.err != nil {
+				require.Equal(t, tt.status, err.(*echo.HTTPError).Code)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.status, rec.Code)
+			require.Equal(t, `"5"`, rec.Header().Get(headerETag))
+		})
+	}
+}
+
+func TestCatHandler_AddVaccination(t *testing.T) {
+	id := uuid.MustParse("a0664c54-4ad3-4445-bb25-fb34f2ff67fc")
+	expires := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	input := &model.Vaccination{
+		Vaccine:        "rabies",
+		AdministeredAt: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		Lot:            "R-17",
+		ExpiresAt:      &expires,
+	}
+
+	service := &servicemock.SheltersCatService{}
+	service.On("AddVaccination", context.Background(), id, input).Return(nil)
+
+	e := echo.New()
+	e.Validator = validator.NewValidator()
+	body := `{"vaccine":"rabies","administered_at":"2024-03-01T00:00:00Z","lot":"R-17","expires_at":"2025-03-01T00:00:00Z"}`
+	req := httptest.NewRequest(http.MethodPost, "/v1/cat/", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	ctx.SetParamNames("id")
+	ctx.SetParamValues(id.String())
+
+	require.NoError(t, NewCat(service).AddVaccination(ctx))
+	require.Equal(t, http.StatusCreated, rec.Code)
+	service.AssertExpectations(t)
+}
+
+func TestCatHandler_DeleteVaccinationNotFound(t *testing.T) {
+	id := uuid.MustParse("a0664c54-4ad3-4445-bb25-fb34f2ff67fc")
+	vaccinationID := uuid.MustParse("2f4b7e1e-43f5-4a49-9b53-5b8f3a2a9c1d")
+
+	service := &servicemock.SheltersCatService{}
+	service.On("DeleteVaccination", context.Background(), id, vaccinationID).Return(model.ErrVaccinationNotFound)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodDelete, "/v1/cat/", nil)
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	ctx.SetParamNames("id", "vaccination_id")
+	ctx.SetParamValues(id.String(), vaccinationID.String())
+
+	err := NewCat(service).DeleteVaccination(ctx)
+	require.Equal(t, http.StatusNotFound, err.(*echo.HTTPError).Code)
+}
@@ -0,0 +1,29 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/catService/internal/model"
+
+	"github.com/labstack/echo/v4"
+)
+
+// newHTTPError converts service error into HTTP error with a matching status code.
+// Details are sent to the client only for errors caused by the request itself
+func newHTTPError(err error, message string) *echo.HTTPError {
+	switch {
+	case errors.Is(err, model.ErrCatNotFound), errors.Is(err, model.ErrShelterNotFound),
+		errors.Is(err, model.ErrVaccinationNotFound), errors.Is(err, model.ErrPhotoNotFound),
+		errors.Is(err, model.ErrFosterNotFound), errors.Is(err, model.ErrAdopterNotFound):
+		return echo.NewHTTPError(http.StatusNotFound, errors.New(message))
+	case errors.Is(err, model.ErrInvalid):
+		return echo.NewHTTPError(http.StatusUnprocessableEntity, err)
+	case errors.Is(err, model.ErrConflict):
+		return echo.NewHTTPError(http.StatusConflict, err)
+	case errors.Is(err, model.ErrVersionMismatch):
+		return echo.NewHTTPError(http.StatusPreconditionFailed, errors.New("cat was changed, reload it and try again"))
+	default:
+		return echo.NewHTTPError(http.StatusInternalServerError, errors.New(message))
+	}
+}
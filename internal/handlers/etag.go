@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/catService/internal/model"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Conditional request headers
+const (
+	headerETag        = "ETag"
+	headerIfMatch     = "If-Match"
+	headerIfNoneMatch = "If-None-Match"
+)
+
+// etag returns the entity tag of the cat version
+func etag(version int64) string {
+	return strconv.Quote(strconv.FormatInt(version, 10))
+}
+
+// ifMatchVersion returns the cat version required by If-Match header,
+// model.AnyVersion when the header is absent or matches any version
+func ifMatchVersion(c echo.Context) (int64, error) {
+	header := strings.TrimSpace(c.Request().Header.Get(headerIfMatch))
+	if header == "" || header == "*" {
+		return model.AnyVersion, nil
+	}
+
+	// If-Match uses the strong comparison, so weak tags never match
+	tag, err := strconv.Unquote(header)
+	if err != nil {
+		return 0, echo.NewHTTPError(http.StatusPreconditionFailed, errors.New("If-Match must contain a single strong entity tag"))
+	}
+	version, err := strconv.ParseInt(tag, 10, 64)
+	if err != nil || version <= 0 {
+		return 0, echo.NewHTTPError(http.StatusPreconditionFailed, errors.New("unknown entity tag"))
+	}
+
+	return version, nil
+}
@@ -0,0 +1,237 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/catService/internal/model"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+// Export formats
+const (
+	exportCSV    = "csv"
+	exportNDJSON = "ndjson"
+	exportJSON   = "json"
+)
+
+// exportFlushRows is the number of rows sent to the client at once
+const exportFlushRows = 100
+
+// catExport writes cats to the response in the export format.
+// The response starts with the first cat, so errors before it can still change the status
+type catExport struct {
+	format   string
+	response *echo.Response
+	csv      *csv.Writer
+	json     *json.Encoder
+	rows     int
+}
+
+// exportContentType returns the content type of the format, empty for unknown format
+func exportContentType(format string) string {
+	switch format {
+	case exportCSV:
+		return mimeCSV
+	case exportNDJSON:
+		return mimeNDJSON
+	case exportJSON:
+		return echo.MIMEApplicationJSON
+	default:
+		return ""
+	}
+}
+
+// Export all cats matching the filter
+// @Summary      Export cats
+// @Tags         cat
+// @Description  stream all cats matching the filter as a file in id order
+// @ID           export-cats
+// @Produce      text/csv,application/x-ndjson,json
+// @Param        format      query      string  false  "File format: csv, ndjson or json, json by default"
+// @Param        vaccinated  query      bool    false  "Vaccination status"
+// @Param        min_age     query      int     false  "Minimal age"
+// @Param        max_age     query      int     false  "Maximal age"
+// @Param        name        query      string  false  "Name prefix"
+// @Param        deleted     query      string  false  "Deleted cats: include or only, hidden by default"
+// @Param        shelter_id  query      string  false  "Shelter ID"
+// @Param        status      query      string  false  "Adoption status: available, reserved, adopted or returned"
+// @Param        in_foster   query      bool    false  "Cats which are with a foster now or which are not"
+// @Success      200  {array}   model.Cat
+// @Failure      400  {string}  bad request
+// @Failure      422  {string}  unprocessable entity
+// @Failure      500  {string}  internal error
+// @Router       /cat/export [get]
+func (hlr *CatHandler) Export(c echo.Context) error {
+	format := c.QueryParam("format")
+	if format == "" {
+		format = exportJSON
+	}
+	if exportContentType(format) == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Errorf("format must be csv, ndjson or json"))
+	}
+	filter, err := bindCatFilter(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err)
+	}
+
+	export := &catExport{format: format, response: c.Response()}
+	err = hlr.service.Export(c.Request().Context(), filter, export.write)
+	if err != nil && !export.started() {
+		logrus.Errorf("cat export error %s", err)
+		return newHTTPError(err, "could not export cats")
+	}
+	if err != nil {
+		// the status is already sent, the client sees the broken file
+		logrus.Errorf("cat export broken after %d cats: %s", export.rows, err)
+		return err
+	}
+
+	return export.finish()
+}
+
+func (e *catExport) started() bool {
+	return e.response.Committed
+}
+
+// start sends the headers and the beginning of the file
+func (e *catExport) start() error {
+	header := e.response.Header()
+	header.Set(echo.HeaderContentType, exportContentType(e.format))
+	header.Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="cats-%s.%s"`,
+		time.Now().UTC().Format("20060102T150405Z"), e.format))
+	e.response.WriteHeader(http.StatusOK)
+
+	switch e.format {
+	case exportCSV:
+		e.csv = csv.NewWriter(e.response)
+		return e.csv.Write([]string{"id", "name", "age", "vaccinated", "shelter_id", "status",
+			"birth_date", "birth_date_estimated", "sex", "breed", "coat_color", "neutered", "intake_date", "microchip",
+			"good_with_kids", "version", "deleted_at"})
+	case exportJSON:
+		e.json = json.NewEncoder(e.response)
+		_, err := e.response.Write([]byte("["))
+		return err
+	default:
+		e.json = json.NewEncoder(e.response)
+		return nil
+	}
+}
+
+// write appends the cat to the file
+func (e *catExport) write(cat *model.Cat) error {
+	if !e.started() {
+		if err := e.start(); err != nil {
+			return err
+		}
+	}
+
+	var err error
+	switch e.format {
+	case exportCSV:
+		err = e.csv.Write(catCSVRecord(cat))
+	case exportJSON:
+		if e.rows > 0 {
+			if _, err = e.response.Write([]byte(",")); err != nil {
+				return err
+			}
+		}
+		err = e.json.Encode(cat)
+	default:
+		err = e.json.Encode(cat)
+	}
+	if err != nil {
+		return err
+	}
+
+	e.rows++
+	if e.rows%exportFlushRows == 0 {
+		return e.flush()
+	}
+
+	return nil
+}
+
+// finish sends the end of the file
+func (e *catExport) finish() error {
+	if !e.started() {
+		if err := e.start(); err != nil {
+			return err
+		}
+	}
+	if e.format == exportJSON {
+		if _, err := e.response.Write([]byte("]")); err != nil {
+			return err
+		}
+	}
+
+	return e.flush()
+}
+
+// flush sends the buffered rows to the client
+func (e *catExport) flush() error {
+	if e.csv != nil {
+		e.csv.Flush()
+		if err := e.csv.Error(); err != nil {
+			return err
+		}
+	}
+	e.response.Flush()
+
+	return nil
+}
+
+// catCSVRecord returns the cat fields in the order of the CSV header
+func catCSVRecord(cat *model.Cat) []string {
+	shelterID, neutered, microchip, goodWithKids, deletedAt := "", "", "", "", ""
+	if cat.ShelterID != nil {
+		shelterID = cat.ShelterID.String()
+	}
+	if cat.Neutered != nil {
+		neutered = strconv.FormatBool(*cat.Neutered)
+	}
+	if cat.Microchip != nil {
+		microchip = *cat.Microchip
+	}
+	if cat.GoodWithKids != nil {
+		goodWithKids = strconv.FormatBool(*cat.GoodWithKids)
+	}
+	if cat.DeletedAt != nil {
+		deletedAt = cat.DeletedAt.UTC().Format(time.RFC3339)
+	}
+
+	return []string{
+		cat.ID.String(),
+		cat.Name,
+		strconv.Itoa(cat.Age),
+		strconv.FormatBool(cat.Vaccinated),
+		shelterID,
+		model.StatusOrDefault(cat.Status),
+		csvDate(cat.BirthDate),
+		strconv.FormatBool(cat.BirthDateEstimated),
+		cat.Sex,
+		cat.Breed,
+		cat.CoatColor,
+		neutered,
+		csvDate(cat.IntakeDate),
+		microchip,
+		goodWithKids,
+		strconv.FormatInt(cat.Version, 10),
+		deletedAt,
+	}
+}
+
+// csvDate formats the date without time, empty for unknown date
+func csvDate(date *time.Time) string {
+	if date == nil {
+		return ""
+	}
+
+	return date.UTC().Format(dateLayout)
+}
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	servicemock "github.com/catService/internal/service/service_mock"
+
+	"github.com/catService/internal/model"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func exportService(cats ...*model.Cat) *servicemock.SheltersCatService {
+	service := &servicemock.SheltersCatService{}
+	service.On("Export", context.Background(), mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		fn := args.Get(2).(func(*model.Cat) error)
+		for _, cat := range cats {
+			if err := fn(cat); err != nil {
+				return
+			}
+		}
+	})
+
+	return service
+}
+
+func exportRequest(t *testing.T, service *servicemock.SheltersCatService, target string) *httptest.ResponseRecorder {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	rec := httptest.NewRecorder()
+	require.NoError(t, NewCat(service).Export(e.NewContext(req, rec)))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	return rec
+}
+
+func TestCatHandler_ExportCSV(t *testing.T) {
+	birthDate := time.Date(2020, time.May, 3, 0, 0, 0, 0, time.UTC)
+	neutered := true
+	cat := &model.Cat{ID: uuid.MustParse("a0664c54-4ad3-4445-bb25-fb34f2ff67fc"), Name: "Cat, the first", Age: 2, Vaccinated: true, Version: 1,
+		CatProfile: model.CatProfile{BirthDate: &birthDate, Sex: model.CatFemale, Breed: "siamese", Neutered: &neutered}}
+
+	rec := exportRequest(t, exportService(cat), "/v1/cat/export?format=csv&vaccinated=true")
+	require.Equal(t, mimeCSV, rec.Header().Get(echo.HeaderContentType))
+	require.Contains(t, rec.Header().Get(echo.HeaderContentDisposition), `attachment; filename="cats-`)
+	require.Equal(t, "id,name,age,vaccinated,shelter_id,status,birth_date,birth_date_estimated,sex,breed,coat_color,neutered,intake_date,microchip,good_with_kids,version,deleted_at\n"+
+		`a0664c54-4ad3-4445-bb25-fb34f2ff67fc,"Cat, the first",2,true,,available,2020-05-03,false,female,siamese,,true,,,,1,`+"\n", rec.Body.String())
+}
+
+func TestCatHandler_ExportJSON(t *testing.T) {
+	cats := []*model.Cat{{ID: uuid.New(), Name: "Cat 1", Age: 1}, {ID: uuid.New(), Name: "Cat 2", Age: 2}}
+
+	rec := exportRequest(t, exportService(cats...), "/v1/cat/export")
+	var exported []*model.Cat
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &exported))
+	require.Equal(t, cats, exported)
+
+	rec = exportRequest(t, exportService(), "/v1/cat/export?format=json")
+	require.JSONEq(t, "[]", rec.Body.String())
+
+	rec = exportRequest(t, exportService(cats...), "/v1/cat/export?format=ndjson")
+	require.Equal(t, mimeNDJSON, rec.Header().Get(echo.HeaderContentType))
+	require.Len(t, strings.Split(strings.TrimSpace(rec.Body.String()), "\n"), 2)
+}
+
+func TestCatHandler_ExportErrors(t *testing.T) {
+	e := echo.New()
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/cat/export?format=xml", nil)
+	err := NewCat(&servicemock.SheltersCatService{}).Export(e.NewContext(req, httptest.NewRecorder()))
+	require.Equal(t, http.StatusBadRequest, err.(*echo.HTTPError).Code)
+
+	service := &servicemock.SheltersCatService{}
+	service.On("Export", context.Background(), mock.Anything, mock.Anything).Return(errors.New("connection lost"))
+	req = httptest.NewRequest(http.MethodGet, "/v1/cat/export", nil)
+	err = NewCat(service).Export(e.NewContext(req, httptest.NewRecorder()))
+	require.Equal(t, http.StatusInternalServerError, err.(*echo.HTTPError).Code)
+}
@@ -0,0 +1,334 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/catService/internal/model"
+	"github.com/catService/internal/service"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+// FosterHandler contain link to service
+type FosterHandler struct {
+	service service.FostersService
+}
+
+// NewFoster return FosterHandler
+func NewFoster(s service.FostersService) *FosterHandler {
+	return &FosterHandler{
+		service: s,
+	}
+}
+
+type fosterRequest struct {
+	Name    string `json:"name" validate:"required"`
+	Address string `json:"address"`
+	Contact string `json:"contact" validate:"required"`
+	Notes   string `json:"notes"`
+}
+
+type fosterListResponse struct {
+	Fosters    []*model.Foster `json:"fosters"`
+	NextCursor string          `json:"next_cursor,omitempty"`
+}
+
+// placementRequest places cat with the foster, the placement starts now if started_at is omitted
+type placementRequest struct {
+	FosterID  uuid.UUID `json:"foster_id" validate:"required"`
+	StartedAt time.Time `json:"started_at"`
+	Notes     string    `json:"notes"`
+}
+
+// placementEndRequest brings cat back, the placement ends now if ended_at is omitted
+type placementEndRequest struct {
+	EndedAt time.Time `json:"ended_at"`
+	Notes   string    `json:"notes"`
+}
+
+type placementListResponse struct {
+	Placements []*model.Placement `json:"placements"`
+}
+
+// Create foster
+// @Summary      Create foster
+// @Tags         foster
+// @Description  create foster parent
+// @ID           create-foster
+// @Accept       json
+// @Produce      json
+// @Param        input  body       fosterRequest  true  "Foster info"
+// @Success      201  {object}  model.Foster
+// @Failure      400  {string}  bad request
+// @Failure      422  {string}  unprocessable entity
+// @Failure      500  {string}  internal error
+// @Router       /foster/ [post]
+func (hlr *FosterHandler) Create(c echo.Context) error {
+	foster, err := bindFoster(c)
+	if err != nil {
+		return err
+	}
+
+	err = hlr.service.Create(c.Request().Context(), foster)
+	if err != nil {
+		logrus.Errorf("create foster error: %s", err)
+		return newHTTPError(err, "could not create foster")
+	}
+
+	return c.JSON(http.StatusCreated, foster)
+}
+
+// Get returns foster by ID
+// @Summary      Get foster by ID
+// @Tags         foster
+// @Description  get foster
+// @ID           get-foster
+// @Produce      json
+// @Param        id  path       string  true  "Foster ID"
+// @Success      200  {object}  model.Foster
+// @Failure      400  {string}  bad request
+// @Failure      404  {string}  not found
+// @Failure      500  {string}  internal error
+// @Router       /foster/{id} [get]
+func (hlr *FosterHandler) Get(c echo.Context) error {
+	fosterID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err)
+	}
+
+	foster, err := hlr.service.Get(c.Request().Context(), fosterID)
+	if err != nil {
+		logrus.Errorf("get foster error %s", err)
+		return newHTTPError(err, "could not get foster")
+	}
+
+	return c.JSON(http.StatusOK, foster)
+}
+
+// Update foster by ID
+// @Summary      Update foster by ID
+// @Tags         foster
+// @Description  update foster
+// @ID           update-foster
+// @Accept       json
+// @Produce      json
+// @Param        id     path       string         true  "Foster ID"
+// @Param        input  body       fosterRequest  true  "Foster info"
+// @Success      200  {object}  model.Foster
+// @Failure      400  {string}  bad request
+// @Failure      404  {string}  not found
+// @Failure      422  {string}  unprocessable entity
+// @Failure      500  {string}  internal error
+// @Router       /foster/{id} [put]
+func (hlr *FosterHandler) Update(c echo.Context) error {
+	fosterID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err)
+	}
+	foster, err := bindFoster(c)
+	if err != nil {
+		return err
+	}
+	foster.ID = fosterID
+
+	err = hlr.service.Update(c.Request().Context(), foster)
+	if err != nil {
+		logrus.Errorf("foster update error %s", err)
+		return newHTTPError(err, "could not update foster")
+	}
+
+	return c.JSON(http.StatusOK, foster)
+}
+
+// Delete foster by ID
+// @Summary      Delete foster by ID
+// @Tags         foster
+// @Description  delete foster which has no placements
+// @ID           delete-foster
+// @Param        id  path       string  true  "Foster ID"
+// @Success      204  {string}  no content
+// @Failure      400  {string}  bad request
+// @Failure      404  {string}  not found
+// @Failure      409  {string}  conflict
+// @Failure      500  {string}  internal error
+// @Router       /foster/{id} [delete]
+func (hlr *FosterHandler) Delete(c echo.Context) error {
+	fosterID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err)
+	}
+
+	err = hlr.service.Delete(c.Request().Context(), fosterID)
+	if err != nil {
+		logrus.Errorf("foster delete error %s", err)
+		return newHTTPError(err, "could not delete foster")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// List returns fosters page by page
+// @Summary      List fosters
+// @Tags         foster
+// @Description  list fosters with cursor pagination
+// @ID           list-fosters
+// @Produce      json
+// @Param        limit   query      int     false  "Page size, 20 by default"
+// @Param        cursor  query      string  false  "Cursor of the next page"
+// @Success      200  {object}  fosterListResponse
+// @Failure      400  {string}  bad request
+// @Failure      422  {string}  unprocessable entity
+// @Failure      500  {string}  internal error
+// @Router       /foster/ [get]
+func (hlr *FosterHandler) List(c echo.Context) error {
+	limit, err := queryInt(c, "limit")
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err)
+	}
+	if limit == nil {
+		limit = new(int)
+	}
+
+	page, err := hlr.service.List(c.Request().Context(), *limit, c.QueryParam("cursor"))
+	if err != nil {
+		logrus.Errorf("foster list error %s", err)
+		return newHTTPError(err, "could not list fosters")
+	}
+
+	return c.JSON(http.StatusOK, fosterListResponse{
+		Fosters:    page.Fosters,
+		NextCursor: page.NextCursor,
+	})
+}
+
+// Placements of cat by ID
+// @Summary      List cat placements
+// @Tags         foster
+// @Description  foster placements of cat in the order they started
+// @ID           list-cat-placements
+// @Produce      json
+// @Param        id  path       string  true  "Cat ID"
+// @Success      200  {object}  placementListResponse
+// @Failure      400  {string}  bad request
+// @Failure      404  {string}  not found
+// @Failure      500  {string}  internal error
+// @Router       /cat/{id}/placements [get]
+func (hlr *FosterHandler) Placements(c echo.Context) error {
+	catID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err)
+	}
+
+	placements, err := hlr.service.Placements(c.Request().Context(), catID)
+	if err != nil {
+		logrus.Errorf("cat placements error %s", err)
+		return newHTTPError(err, "could not get cat placements")
+	}
+
+	return c.JSON(http.StatusOK, placementListResponse{Placements: placements})
+}
+
+// Place cat by ID with foster
+// @Summary      Place cat with foster
+// @Tags         foster
+// @Description  start placement of cat with the foster. Cat has one active placement at most
+// @Description  and the placement must not start before the previous one ended
+// @ID           place-cat
+// @Accept       json
+// @Produce      json
+// @Param        id     path       string            true  "Cat ID"
+// @Param        input  body       placementRequest  true  "Placement"
+// @Success      201  {object}  model.Placement
+// @Failure      400  {string}  bad request
+// @Failure      404  {string}  not found
+// @Failure      409  {string}  conflict
+// @Failure      422  {string}  unprocessable entity
+// @Failure      500  {string}  internal error
+// @Router       /cat/{id}/placements [post]
+func (hlr *FosterHandler) Place(c echo.Context) error {
+	catID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err)
+	}
+	var request placementRequest
+	if err := c.Bind(&request); err != nil {
+		logrus.Errorf("bind failed: %s", err)
+		return echo.NewHTTPError(http.StatusBadRequest)
+	}
+	if err := c.Validate(&request); err != nil {
+		logrus.Errorf("validate failed: %s", err)
+		return echo.NewHTTPError(http.StatusUnprocessableEntity, err)
+	}
+
+	placement := &model.Placement{
+		FosterID:  request.FosterID,
+		StartedAt: request.StartedAt,
+		Notes:     request.Notes,
+	}
+	err = hlr.service.Place(c.Request().Context(), catID, placement)
+	if err != nil {
+		logrus.Errorf("place cat error %s", err)
+		return newHTTPError(err, "could not place cat")
+	}
+
+	return c.JSON(http.StatusCreated, placement)
+}
+
+// BringBack cat by ID from foster
+// @Summary      Bring cat back from foster
+// @Tags         foster
+// @Description  end the active placement of cat
+// @ID           bring-back-cat
+// @Accept       json
+// @Produce      json
+// @Param        id     path       string               true  "Cat ID"
+// @Param        input  body       placementEndRequest  false  "End of placement"
+// @Success      200  {object}  model.Placement
+// @Failure      400  {string}  bad request
+// @Failure      404  {string}  not found
+// @Failure      409  {string}  conflict
+// @Failure      422  {string}  unprocessable entity
+// @Failure      500  {string}  internal error
+// @Router       /cat/{id}/placements/end [post]
+func (hlr *FosterHandler) BringBack(c echo.Context) error {
+	catID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err)
+	}
+	var request placementEndRequest
+	if err := c.Bind(&request); err != nil {
+		logrus.Errorf("bind failed: %s", err)
+		return echo.NewHTTPError(http.StatusBadRequest)
+	}
+
+	placement, err := hlr.service.BringBack(c.Request().Context(), catID, request.EndedAt, request.Notes)
+	if err != nil {
+		logrus.Errorf("bring back cat error %s", err)
+		return newHTTPError(err, "could not bring back cat")
+	}
+
+	return c.JSON(http.StatusOK, placement)
+}
+
+// bindFoster reads and validates foster from the request body
+func bindFoster(c echo.Context) (*model.Foster, error) {
+	var request fosterRequest
+	if err := c.Bind(&request); err != nil {
+		logrus.Errorf("bind failed: %s", err)
+		return nil, echo.NewHTTPError(http.StatusBadRequest)
+	}
+	if err := c.Validate(&request); err != nil {
+		logrus.Errorf("validate failed: %s", err)
+		return nil, echo.NewHTTPError(http.StatusUnprocessableEntity, err)
+	}
+
+	return &model.Foster{
+		Name:    request.Name,
+		Address: request.Address,
+		Contact: request.Contact,
+		Notes:   request.Notes,
+	}, nil
+}
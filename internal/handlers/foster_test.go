@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	servicemock "github.com/catService/internal/service/service_mock"
+
+	"github.com/catService/internal/model"
+	"github.com/catService/internal/validator"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFosterHandler_Create(t *testing.T) {
+	service := &servicemock.FostersService{}
+	service.On("Create", context.Background(), &model.Foster{Name: "Anna", Contact: "+375291111111"}).Return(nil)
+
+	e := echo.New()
+	e.Validator = validator.NewValidator()
+	req := httptest.NewRequest(http.MethodPost, "/v1/foster/", strings.NewReader(`{"name":"Anna","contact":"+375291111111"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+
+	require.NoError(t, NewFoster(service).Create(e.NewContext(req, rec)))
+	require.Equal(t, http.StatusCreated, rec.Code)
+	service.AssertExpectations(t)
+
+	req = httptest.NewRequest(http.MethodPost, "/v1/foster/", strings.NewReader(`{"name":"Anna"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	err := NewFoster(service).Create(e.NewContext(req, httptest.NewRecorder()))
+	require.Equal(t, http.StatusUnprocessableEntity, err.(*echo.HTTPError).Code)
+}
+
+func TestFosterHandler_Place(t *testing.T) {
+	id := uuid.New()
+	fosterID := uuid.New()
+
+	service := &servicemock.FostersService{}
+	service.On("Place", context.Background(), id, mock.MatchedBy(func(placement *model.Placement) bool {
+		return placement.FosterID == fosterID && placement.StartedAt.IsZero() && placement.Notes == "shy"
+	})).Return(nil)
+
+	ctx, rec := intakeContext(id, `{"foster_id":"`+fosterID.String()+`","notes":"shy"}`)
+	require.NoError(t, NewFoster(service).Place(ctx))
+	require.Equal(t, http.StatusCreated, rec.Code)
+	service.AssertExpectations(t)
+
+	ctx, _ = intakeContext(id, `{"notes":"shy"}`)
+	err := NewFoster(service).Place(ctx)
+	require.Equal(t, http.StatusUnprocessableEntity, err.(*echo.HTTPError).Code)
+}
+
+func TestFosterHandler_BringBackErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{name: "not placed", err: model.ErrConflict, status: http.StatusConflict},
+		{name: "cat not found", err: model.ErrCatNotFound, status: http.StatusNotFound},
+		{name: "end before start", err: model.ErrInvalid, status: http.StatusUnprocessableEntity},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id := uuid.New()
+			service := &servicemock.FostersService{}
+			service.On("BringBack", context.Background(), id, time.Time{}, "").Return(nil, tt.err)
+
+			ctx, _ := intakeContext(id, `{}`)
+			err := NewFoster(service).BringBack(ctx)
+			require.Equal(t, tt.status, err.(*echo.HTTPError).Code)
+		})
+	}
+}
@@ -0,0 +1,360 @@
+package handlers
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/catService/internal/model"
+	"github.com/catService/internal/service"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+// Import content types
+const (
+	mimeCSV    = "text/csv"
+	mimeNDJSON = "application/x-ndjson"
+)
+
+// dateLayout is the format of dates in CSV files
+const dateLayout = "2006-01-02"
+
+// maxNDJSONLineSize limits the length of one NDJSON row, the rows are read one by one
+const maxNDJSONLineSize = 64 << 10
+
+// maxCSVRecordSize limits the length of one CSV row. The limit is checked on the input read for the row,
+// it includes the part of the next rows buffered by csv.Reader, so rows a bit longer may pass too
+const maxCSVRecordSize = 64 << 10
+
+// csvReadAhead is the buffer size of csv.Reader
+const csvReadAhead = 4096
+
+// errCSVRecordTooLong is returned by recordLimitReader when a CSV row exceeds maxCSVRecordSize
+var errCSVRecordTooLong = errors.New("csv row is too long")
+
+// importRowError describes the invalid row, rows are numbered from 1 without the CSV header
+type importRowError struct {
+	Row   int    `json:"row"`
+	Error string `json:"error"`
+}
+
+type importResponse struct {
+	DryRun   bool             `json:"dry_run"`
+	Total    int              `json:"total"`
+	Imported int              `json:"imported"`
+	Errors   []importRowError `json:"errors,omitempty"`
+}
+
+// Import cats from CSV or NDJSON
+// @Summary      Import cats
+// @Tags         cat
+// @Description  bulk import of cats from CSV with header of name,age,vaccinated,shelter_id,birth_date,birth_date_estimated,
+// @Description  sex,breed,coat_color,neutered,intake_date,microchip,good_with_kids columns or from NDJSON. Dates in CSV are YYYY-MM-DD.
+// @Description  Nothing is imported if any row is invalid, dry run runs the same validation without import.
+// @Description  Cats are saved in batches, after a storage error the saved ones stay and the report shows the first row which wasn't imported
+// @ID           import-cats
+// @Accept       text/csv,application/x-ndjson
+// @Produce      json
+// @Param        dry_run  query      bool    false  "Validate the rows without import"
+// @Param        input    body       string  true   "CSV or NDJSON document"
+// @Success      200  {object}  importResponse
+// @Success      201  {object}  importResponse
+// @Failure      400  {string}  bad request
+// @Failure      413  {string}  request entity too large
+// @Failure      415  {string}  unsupported media type
+// @Failure      422  {object}  importResponse
+// @Failure      409  {object}  importResponse
+// @Failure      500  {object}  importResponse
+// @Router       /cat/import [post]
+func (hlr *CatHandler) Import(c echo.Context) error {
+	dryRun, err := queryBool(c, "dry_run")
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err)
+	}
+
+	var rows []*catCreateRequest
+	var rowErrors []importRowError
+	contentType := strings.TrimSpace(strings.Split(c.Request().Header.Get(echo.HeaderContentType), ";")[0])
+	switch contentType {
+	case mimeCSV:
+		rows, rowErrors, err = readCSVRows(c.Request().Body)
+	case mimeNDJSON:
+		rows, rowErrors, err = readNDJSONRows(c.Request().Body)
+	default:
+		return echo.NewHTTPError(http.StatusUnsupportedMediaType, errors.New("import must be text/csv or application/x-ndjson"))
+	}
+	if err != nil {
+		logrus.Errorf("read import failed: %s", err)
+		return echo.NewHTTPError(http.StatusBadRequest, err)
+	}
+	if len(rows) > service.MaxImportRows {
+		return echo.NewHTTPError(http.StatusRequestEntityTooLarge, fmt.Errorf("import is limited to %d cats", service.MaxImportRows))
+	}
+
+	cats := make([]*model.Cat, 0, len(rows))
+	// catRows keeps the input row number of every cat
+	catRows := make([]int, 0, len(rows))
+	for i, row := range rows {
+		if row == nil {
+			continue
+		}
+		if err := c.Validate(row); err != nil {
+			rowErrors = append(rowErrors, importRowError{Row: i + 1, Error: err.Error()})
+			continue
+		}
+		cats = append(cats, &model.Cat{Name: row.Name, Age: row.Age, Vaccinated: row.Vaccinated, ShelterID: row.ShelterID,
+			CatProfile: row.profile()})
+		catRows = append(catRows, i+1)
+	}
+
+	report := importResponse{DryRun: dryRun != nil && *dryRun, Total: len(rows)}
+	var invalid *model.ImportError
+	err = hlr.service.ValidateImport(c.Request().Context(), cats)
+	if errors.As(err, &invalid) {
+		rowErrors = append(rowErrors, importErrors(invalid, catRows)...)
+	} else if err != nil {
+		logrus.Errorf("validate import error: %s", err)
+		return newHTTPError(err, "could not validate cats")
+	}
+
+	sort.Slice(rowErrors, func(i, j int) bool { return rowErrors[i].Row < rowErrors[j].Row })
+	report.Errors = rowErrors
+	if len(rowErrors) > 0 {
+		return c.JSON(http.StatusUnprocessableEntity, report)
+	}
+	if report.DryRun {
+		return c.JSON(http.StatusOK, report)
+	}
+
+	report.Imported, err = hlr.service.Import(c.Request().Context(), cats)
+	if errors.As(err, &invalid) {
+		// a cat with the same microchip may be saved after the validation
+		report.Errors = importErrors(invalid, catRows)
+		sort.Slice(report.Errors, func(i, j int) bool { return report.Errors[i].Row < report.Errors[j].Row })
+		return c.JSON(http.StatusUnprocessableEntity, report)
+	}
+	if err != nil {
+		logrus.Errorf("import error after %d cats: %s", report.Imported, err)
+		// the batches saved before the error stay imported, the report shows the first row which wasn't
+		httpErr := newHTTPError(err, "could not import cats")
+		report.Errors = []importRowError{{Row: catRows[report.Imported], Error: fmt.Sprint(httpErr.Message)}}
+		return c.JSON(httpErr.Code, report)
+	}
+
+	return c.JSON(http.StatusCreated, report)
+}
+
+// importErrors converts the invalid cats of the service into errors of the input rows
+func importErrors(invalid *model.ImportError, catRows []int) []importRowError {
+	rowErrors := make([]importRowError, 0, len(invalid.Cats))
+	for i, err := range invalid.Cats {
+		rowErrors = append(rowErrors, importRowError{Row: catRows[i], Error: err.Error()})
+	}
+
+	return rowErrors
+}
+
+// recordLimitReader fails when more than limit bytes are read since the last reset
+type recordLimitReader struct {
+	reader io.Reader
+	read   int
+	limit  int
+}
+
+func (r *recordLimitReader) Read(p []byte) (int, error) {
+	if r.read >= r.limit {
+		return 0, errCSVRecordTooLong
+	}
+	if len(p) > r.limit-r.read {
+		p = p[:r.limit-r.read]
+	}
+	n, err := r.reader.Read(p)
+	r.read += n
+
+	return n, err
+}
+
+// readCSVRows reads cats from CSV with header, reading stops after service.MaxImportRows is exceeded.
+// Rows which can't be parsed are nil and reported as errors
+func readCSVRows(body io.Reader) ([]*catCreateRequest, []importRowError, error) {
+	limited := &recordLimitReader{reader: body, limit: maxCSVRecordSize + csvReadAhead}
+	reader := csv.NewReader(limited)
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if errors.Is(err, errCSVRecordTooLong) {
+		return nil, nil, fmt.Errorf("read csv: header is longer than %d bytes", maxCSVRecordSize)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("read csv header: %w", err)
+	}
+	columns := make([]string, len(header))
+	for i, name := range header {
+		columns[i] = strings.ToLower(strings.TrimSpace(name))
+		switch columns[i] {
+		case "name", "age", "vaccinated", "shelter_id", "birth_date", "birth_date_estimated",
+			"sex", "breed", "coat_color", "neutered", "intake_date", "microchip", "good_with_kids":
+		default:
+			return nil, nil, fmt.Errorf("unknown csv column %q", name)
+		}
+	}
+
+	var rows []*catCreateRequest
+	var rowErrors []importRowError
+	for {
+		limited.read = 0
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, rowErrors, nil
+		}
+		if errors.Is(err, errCSVRecordTooLong) {
+			return nil, nil, fmt.Errorf("read csv: row %d is longer than %d bytes", len(rows)+1, maxCSVRecordSize)
+		}
+		if err != nil && !errors.Is(err, csv.ErrFieldCount) {
+			return nil, nil, fmt.Errorf("read csv: %w", err)
+		}
+		if len(rows) > service.MaxImportRows {
+			return rows, rowErrors, nil
+		}
+
+		row, err := parseCSVRecord(columns, record, err)
+		if err != nil {
+			rowErrors = append(rowErrors, importRowError{Row: len(rows) + 1, Error: err.Error()})
+		}
+		rows = append(rows, row)
+	}
+}
+
+// parseCSVRecord converts the record into the create request
+func parseCSVRecord(columns, record []string, readErr error) (*catCreateRequest, error) {
+	if readErr != nil {
+		return nil, errors.New("wrong number of fields")
+	}
+
+	var row catCreateRequest
+	for i, value := range record {
+		value = strings.TrimSpace(value)
+		switch columns[i] {
+		case "name":
+			row.Name = value
+		case "age":
+			if value == "" {
+				continue
+			}
+			age, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, errors.New("age must be an integer")
+			}
+			row.Age = age
+		case "vaccinated":
+			if value == "" {
+				continue
+			}
+			vaccinated, err := strconv.ParseBool(value)
+			if err != nil {
+				return nil, errors.New("vaccinated must be a boolean")
+			}
+			row.Vaccinated = vaccinated
+		case "shelter_id":
+			if value == "" {
+				continue
+			}
+			shelterID, err := uuid.Parse(value)
+			if err != nil {
+				return nil, errors.New("shelter_id must be a UUID")
+			}
+			row.ShelterID = &shelterID
+		case "birth_date", "intake_date":
+			if value == "" {
+				continue
+			}
+			date, err := time.Parse(dateLayout, value)
+			if err != nil {
+				return nil, fmt.Errorf("%s must be a date in YYYY-MM-DD format", columns[i])
+			}
+			if columns[i] == "birth_date" {
+				row.BirthDate = &date
+			} else {
+				row.IntakeDate = &date
+			}
+		case "birth_date_estimated", "neutered", "good_with_kids":
+			if value == "" {
+				continue
+			}
+			flag, err := strconv.ParseBool(value)
+			if err != nil {
+				return nil, fmt.Errorf("%s must be a boolean", columns[i])
+			}
+			switch columns[i] {
+			case "neutered":
+				row.Neutered = &flag
+			case "good_with_kids":
+				row.GoodWithKids = &flag
+			default:
+				row.BirthDateEstimated = flag
+			}
+		case "sex":
+			row.Sex = value
+		case "breed":
+			row.Breed = value
+		case "coat_color":
+			row.CoatColor = value
+		case "microchip":
+			if value == "" {
+				continue
+			}
+			microchip := value
+			row.Microchip = &microchip
+		}
+	}
+
+	return &row, nil
+}
+
+// readNDJSONRows reads cats from JSON objects separated by new lines, blank lines are skipped.
+// Reading stops after service.MaxImportRows is exceeded, rows which can't be parsed are nil and reported as errors
+func readNDJSONRows(body io.Reader) ([]*catCreateRequest, []importRowError, error) {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxNDJSONLineSize)
+
+	var rows []*catCreateRequest
+	var rowErrors []importRowError
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		if len(rows) > service.MaxImportRows {
+			return rows, rowErrors, nil
+		}
+
+		decoder := json.NewDecoder(bytes.NewReader(line))
+		decoder.DisallowUnknownFields()
+		var row catCreateRequest
+		if err := decoder.Decode(&row); err != nil {
+			rowErrors = append(rowErrors, importRowError{Row: len(rows) + 1, Error: err.Error()})
+			rows = append(rows, nil)
+			continue
+		}
+		rows = append(rows, &row)
+	}
+	if errors.Is(scanner.Err(), bufio.ErrTooLong) {
+		return nil, nil, fmt.Errorf("read ndjson: row %d is longer than %d bytes", len(rows)+1, maxNDJSONLineSize)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, fmt.Errorf("read ndjson: %w", err)
+	}
+
+	return rows, rowErrors, nil
+}
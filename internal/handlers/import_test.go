@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	servicemock "github.com/catService/internal/service/service_mock"
+
+	"github.com/catService/internal/model"
+	"github.com/catService/internal/validator"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func importRequest(t *testing.T, handler *CatHandler, target, contentType, body string) (*httptest.ResponseRecorder, importResponse) {
+	e := echo.New()
+	e.Validator = validator.NewValidator()
+	req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, contentType)
+	rec := httptest.NewRecorder()
+	require.NoError(t, handler.Import(e.NewContext(req, rec)))
+
+	var report importResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &report))
+	return rec, report
+}
+
+func TestCatHandler_ImportCSV(t *testing.T) {
+	service := &servicemock.SheltersCatService{}
+	service.On("ValidateImport", context.Background(), mock.Anything).Return(nil)
+	service.On("Import", context.Background(), mock.MatchedBy(func(cats []*model.Cat) bool {
+		return len(cats) == 2 && cats[0].Name == "Cat 1" && cats[0].Vaccinated && cats[1].Age == 3 && !cats[1].Vaccinated
+	})).Return(2, nil)
+
+	csv := "name,age,vaccinated\nCat 1,2,true\nCat 2,3,\n"
+	rec, report := importRequest(t, NewCat(service), "/v1/cat/import", "text/csv; charset=utf-8", csv)
+	require.Equal(t, http.StatusCreated, rec.Code)
+	require.Equal(t, 2, report.Total)
+	require.Equal(t, 2, report.Imported)
+	service.AssertExpectations(t)
+}
+
+func TestCatHandler_ImportCSVProfile(t *testing.T) {
+	service := &servicemock.SheltersCatService{}
+	service.On("ValidateImport", context.Background(), mock.Anything).Return(nil)
+	service.On("Import", context.Background(), mock.MatchedBy(func(cats []*model.Cat) bool {
+		return len(cats) == 1 && cats[0].BirthDate != nil && cats[0].BirthDate.Month() == 5 && cats[0].BirthDateEstimated &&
+			cats[0].CoatColor == "black" && cats[0].Neutered != nil && !*cats[0].Neutered && cats[0].Microchip == nil
+	})).Return(1, nil)
+
+	csv := "name,birth_date,birth_date_estimated,coat_color,neutered,microchip\nCat 1,2020-05-03,true,black,false,\n"
+	rec, report := importRequest(t, NewCat(service), "/v1/cat/import", mimeCSV, csv)
+	require.Equal(t, http.StatusCreated, rec.Code)
+	require.Equal(t, 1, report.Imported)
+	service.AssertExpectations(t)
+
+	csv = "name,age,birth_date\nCat 1,2,03.05.2020\n"
+	rec, report = importRequest(t, NewCat(service), "/v1/cat/import", mimeCSV, csv)
+	require.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	require.Len(t, report.Errors, 1)
+}
+
+func TestCatHandler_ImportNDJSONDryRun(t *testing.T) {
+	service := &servicemock.SheltersCatService{}
+	service.On("ValidateImport", context.Background(), mock.Anything).Return(nil)
+
+	ndjson := `{"name":"Cat 1","age":2}` + "\n\n" + `{"name":"Cat 2","age":3,"vaccinated":true}` + "\n"
+	rec, report := importRequest(t, NewCat(service), "/v1/cat/import?dry_run=true", mimeNDJSON, ndjson)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.True(t, report.DryRun)
+	require.Equal(t, 2, report.Total)
+	require.Zero(t, report.Imported)
+	require.Empty(t, report.Errors)
+}
+
+func TestCatHandler_ImportInvalidRows(t *testing.T) {
+	service := &servicemock.SheltersCatService{}
+	service.On("ValidateImport", context.Background(), mock.Anything).Return(nil)
+
+	csv := "name,age\nCat 1,two\n,3\nCat 3,4,true\nCat 4,5\n"
+	rec, report := importRequest(t, NewCat(service), "/v1/cat/import", mimeCSV, csv)
+	require.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	require.Equal(t, 4, report.Total)
+	require.Len(t, report.Errors, 3)
+	require.Equal(t, 1, report.Errors[0].Row)
+	require.Equal(t, 2, report.Errors[1].Row)
+	require.Equal(t, 3, report.Errors[2].Row)
+
+	ndjson := `{"name":"Cat 1","age":2,"color":"black"}`
+	rec, report = importRequest(t, NewCat(service), "/v1/cat/import?dry_run=true", mimeNDJSON, ndjson)
+	require.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	require.Len(t, report.Errors, 1)
+}
+
+func TestCatHandler_ImportServiceValidation(t *testing.T) {
+	invalid := &model.ImportError{Cats: map[int]error{1: fmt.Errorf("%w: sex must be male or female", model.ErrInvalid)}}
+	service := &servicemock.SheltersCatService{}
+	service.On("ValidateImport", context.Background(), mock.MatchedBy(func(cats []*model.Cat) bool {
+		return len(cats) == 2 && cats[0].Name == "Cat 2" && cats[1].Name == "Cat 3"
+	})).Return(invalid)
+
+	csv := "name,age,sex\n,1,\nCat 2,2,male\nCat 3,3,unknown\n"
+	for _, target := range []string{"/v1/cat/import?dry_run=true", "/v1/cat/import"} {
+		rec, report := importRequest(t, NewCat(service), target, mimeCSV, csv)
+		require.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		require.Len(t, report.Errors, 2)
+		require.Equal(t, 1, report.Errors[0].Row)
+		require.Equal(t, 3, report.Errors[1].Row)
+		require.Contains(t, report.Errors[1].Error, "sex")
+	}
+	service.AssertNotCalled(t, "Import", mock.Anything, mock.Anything)
+}
+
+func TestCatHandler_ImportPartial(t *testing.T) {
+	service := &servicemock.SheltersCatService{}
+	service.On("ValidateImport", context.Background(), mock.Anything).Return(nil)
+	service.On("Import", context.Background(), mock.Anything).Return(1, errors.New("connection lost"))
+
+	ndjson := `{"name":"Cat 1","age":1}` + "\n\n" + `{"name":"Cat 2","age":2}` + "\n"
+	rec, report := importRequest(t, NewCat(service), "/v1/cat/import", mimeNDJSON, ndjson)
+	require.Equal(t, http.StatusInternalServerError, rec.Code)
+	require.Equal(t, 1, report.Imported)
+	require.Len(t, report.Errors, 1)
+	require.Equal(t, 2, report.Errors[0].Row)
+	require.Equal(t, "could not import cats", report.Errors[0].Error)
+}
+
+func TestCatHandler_ImportBadDocument(t *testing.T) {
+	catHandler := NewCat(&servicemock.SheltersCatService{})
+	e := echo.New()
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/cat/import", strings.NewReader("name,color\nCat 1,black\n"))
+	req.Header.Set(echo.HeaderContentType, mimeCSV)
+	err := catHandler.Import(e.NewContext(req, httptest.NewRecorder()))
+	require.Equal(t, http.StatusBadRequest, err.(*echo.HTTPError).Code)
+
+	csv := "name,age\nCat 1,1\n" + strings.Repeat("a", maxCSVRecordSize+2*csvReadAhead) + ",2\n"
+	req = httptest.NewRequest(http.MethodPost, "/v1/cat/import", strings.NewReader(csv))
+	req.Header.Set(echo.HeaderContentType, mimeCSV)
+	err = catHandler.Import(e.NewContext(req, httptest.NewRecorder()))
+	require.Equal(t, http.StatusBadRequest, err.(*echo.HTTPError).Code)
+	require.Contains(t, err.Error(), "row 2")
+
+	long := `{"name":"` + strings.Repeat("a", maxNDJSONLineSize) + `"}`
+	req = httptest.NewRequest(http.MethodPost, "/v1/cat/import", strings.NewReader(`{"name":"Cat 1"}`+"\n"+long))
+	req.Header.Set(echo.HeaderContentType, mimeNDJSON)
+	err = catHandler.Import(e.NewContext(req, httptest.NewRecorder()))
+	require.Equal(t, http.StatusBadRequest, err.(*echo.HTTPError).Code)
+	require.Contains(t, err.Error(), "row 2")
+
+	req = httptest.NewRequest(http.MethodPost, "/v1/cat/import", strings.NewReader("{}"))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	err = catHandler.Import(e.NewContext(req, httptest.NewRecorder()))
+	require.Equal(t, http.StatusUnsupportedMediaType, err.(*echo.HTTPError).Code)
+}
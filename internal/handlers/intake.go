@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/catService/internal/model"
+	"github.com/catService/internal/service"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+// IntakeHandler contain link to service
+type IntakeHandler struct {
+	service service.IntakesService
+}
+
+// NewIntake return IntakeHandler
+func NewIntake(s service.IntakesService) *IntakeHandler {
+	return &IntakeHandler{
+		service: s,
+	}
+}
+
+// intakeRecordRequest is the body of both intake and outcome records
+type intakeRecordRequest struct {
+	Type     string    `json:"type" validate:"required"`
+	Date     time.Time `json:"date" validate:"required"`
+	Location string    `json:"location"`
+	Notes    string    `json:"notes"`
+}
+
+type intakeListResponse struct {
+	Intakes []*model.Intake `json:"intakes"`
+}
+
+// Intakes of cat by ID
+// @Summary      List cat intakes
+// @Tags         intake
+// @Description  intake records of cat with their outcomes in the order of intake
+// @ID           list-cat-intakes
+// @Produce      json
+// @Param        id  path       string  true  "Cat ID"
+// @Success      200  {object}  intakeListResponse
+// @Failure      400  {string}  bad request
+// @Failure      404  {string}  not found
+// @Failure      500  {string}  internal error
+// @Router       /cat/{id}/intakes [get]
+func (hlr *IntakeHandler) Intakes(c echo.Context) error {
+	catID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err)
+	}
+
+	intakes, err := hlr.service.Intakes(c.Request().Context(), catID)
+	if err != nil {
+		logrus.Errorf("cat intakes error %s", err)
+		return newHTTPError(err, "could not get cat intakes")
+	}
+
+	return c.JSON(http.StatusOK, intakeListResponse{Intakes: intakes})
+}
+
+// AddIntake to cat by ID
+// @Summary      Add cat intake
+// @Tags         intake
+// @Description  record how cat came in: stray, owner_surrender, transfer or born_in_care.
+// @Description  The intake stays open until the outcome is recorded, cat has one open intake at most
+// @ID           add-cat-intake
+// @Accept       json
+// @Produce      json
+// @Param        id     path       string               true  "Cat ID"
+// @Param        input  body       intakeRecordRequest  true  "Intake record"
+// @Success      201  {object}  model.Intake
+// @Failure      400  {string}  bad request
+// @Failure      404  {string}  not found
+// @Failure      409  {string}  conflict
+// @Failure      422  {string}  unprocessable entity
+// @Failure      500  {string}  internal error
+// @Router       /cat/{id}/intakes [post]
+func (hlr *IntakeHandler) AddIntake(c echo.Context) error {
+	catID, request, err := bindIntakeRecord(c)
+	if err != nil {
+		return err
+	}
+
+	intake := &model.Intake{
+		Type:     request.Type,
+		Date:     request.Date,
+		Location: request.Location,
+		Notes:    request.Notes,
+	}
+	err = hlr.service.AddIntake(c.Request().Context(), catID, intake)
+	if err != nil {
+		logrus.Errorf("add cat intake error %s", err)
+		return newHTTPError(err, "could not add cat intake")
+	}
+
+	return c.JSON(http.StatusCreated, intake)
+}
+
+// AddOutcome to cat by ID
+// @Summary      Add cat outcome
+// @Tags         intake
+// @Description  record how cat left: adoption, return_to_owner, transfer or death. The outcome closes the open intake
+// @ID           add-cat-outcome
+// @Accept       json
+// @Produce      json
+// @Param        id     path       string               true  "Cat ID"
+// @Param        input  body       intakeRecordRequest  true  "Outcome record"
+// @Success      201  {object}  model.Intake
+// @Failure      400  {string}  bad request
+// @Failure      404  {string}  not found
+// @Failure      409  {string}  conflict
+// @Failure      422  {string}  unprocessable entity
+// @Failure      500  {string}  internal error
+// @Router       /cat/{id}/outcomes [post]
+func (hlr *IntakeHandler) AddOutcome(c echo.Context) error {
+	catID, request, err := bindIntakeRecord(c)
+	if err != nil {
+		return err
+	}
+
+	intake, err := hlr.service.AddOutcome(c.Request().Context(), catID, &model.Outcome{
+		Type:     request.Type,
+		Date:     request.Date,
+		Location: request.Location,
+		Notes:    request.Notes,
+	})
+	if err != nil {
+		logrus.Errorf("add cat outcome error %s", err)
+		return newHTTPError(err, "could not add cat outcome")
+	}
+
+	return c.JSON(http.StatusCreated, intake)
+}
+
+// bindIntakeRecord reads cat ID from the path and validates the record from the request body
+func bindIntakeRecord(c echo.Context) (uuid.UUID, *intakeRecordRequest, error) {
+	catID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return uuid.Nil, nil, echo.NewHTTPError(http.StatusBadRequest, err)
+	}
+	var request intakeRecordRequest
+	if err := c.Bind(&request); err != nil {
+		logrus.Errorf("bind failed: %s", err)
+		return uuid.Nil, nil, echo.NewHTTPError(http.StatusBadRequest)
+	}
+	if err := c.Validate(&request); err != nil {
+		logrus.Errorf("validate failed: %s", err)
+		return uuid.Nil, nil, echo.NewHTTPError(http.StatusUnprocessableEntity, err)
+	}
+
+	return catID, &request, nil
+}
@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	servicemock "github.com/catService/internal/service/service_mock"
+
+	"github.com/catService/internal/model"
+	"github.com/catService/internal/validator"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// intakeContext returns the context of the record request to the cat
+func intakeContext(id uuid.UUID, body string) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	e.Validator = validator.NewValidator()
+	req := httptest.NewRequest(http.MethodPost, "/v1/cat/", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	ctx.SetParamNames("id")
+	ctx.SetParamValues(id.String())
+
+	return ctx, rec
+}
+
+func TestIntakeHandler_AddIntake(t *testing.T) {
+	id := uuid.New()
+
+	service := &servicemock.IntakesService{}
+	service.On("AddIntake", context.Background(), id, mock.MatchedBy(func(intake *model.Intake) bool {
+		return intake.Type == model.IntakeStray && intake.Location == "Main street" && intake.Date.Year() == 2022
+	})).Return(nil)
+
+	ctx, rec := intakeContext(id, `{"type":"stray","date":"2022-03-01T10:00:00Z","location":"Main street"}`)
+	require.NoError(t, NewIntake(service).AddIntake(ctx))
+	require.Equal(t, http.StatusCreated, rec.Code)
+	service.AssertExpectations(t)
+
+	ctx, _ = intakeContext(id, `{"type":"stray"}`)
+	err := NewIntake(service).AddIntake(ctx)
+	require.Equal(t, http.StatusUnprocessableEntity, err.(*echo.HTTPError).Code)
+}
+
+func TestIntakeHandler_AddOutcomeWithoutIntake(t *testing.T) {
+	id := uuid.New()
+
+	service := &servicemock.IntakesService{}
+	service.On("AddOutcome", context.Background(), id, mock.Anything).Return(nil, model.ErrConflict)
+
+	ctx, _ := intakeContext(id, `{"type":"adoption","date":"2022-03-01T10:00:00Z"}`)
+	err := NewIntake(service).AddOutcome(ctx)
+	require.Equal(t, http.StatusConflict, err.(*echo.HTTPError).Code)
+}
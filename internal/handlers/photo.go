@@ -0,0 +1,201 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/catService/internal/model"
+	"github.com/catService/internal/service"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"github.com/sirupsen/logrus"
+)
+
+// maxPhotoRequestSize limits the upload request, the photo with the multipart envelope
+const maxPhotoRequestSize = service.MaxPhotoSize + 1<<20
+
+// PhotoHandler contain link to service
+type PhotoHandler struct {
+	service service.PhotosService
+}
+
+// NewPhoto return PhotoHandler
+func NewPhoto(s service.PhotosService) *PhotoHandler {
+	return &PhotoHandler{
+		service: s,
+	}
+}
+
+// PhotoUploadLimit rejects upload requests larger than the photo with the multipart envelope
+// before the form is parsed, so the large body is neither kept in memory nor spooled to disk
+func PhotoUploadLimit() echo.MiddlewareFunc {
+	return middleware.BodyLimit(strconv.Itoa(maxPhotoRequestSize))
+}
+
+type photoListResponse struct {
+	Photos []*model.Photo `json:"photos"`
+}
+
+// Upload photo of cat by ID
+// @Summary      Upload cat photo
+// @Tags         photo
+// @Description  upload jpeg or png photo, medium and thumbnail sizes are generated from it
+// @ID           upload-cat-photo
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        id     path       string  true  "Cat ID"
+// @Param        photo  formData   file    true  "Photo, 10 MiB at most"
+// @Success      201  {object}  model.Photo
+// @Failure      400  {string}  bad request
+// @Failure      404  {string}  not found
+// @Failure      409  {string}  conflict
+// @Failure      413  {string}  request entity too large
+// @Failure      415  {string}  unsupported media type
+// @Failure      422  {string}  unprocessable entity
+// @Failure      500  {string}  internal error
+// @Router       /cat/{id}/photos [post]
+func (hlr *PhotoHandler) Upload(c echo.Context) error {
+	catID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err)
+	}
+	file, err := c.FormFile("photo")
+	if errors.Is(err, echo.ErrStatusRequestEntityTooLarge) {
+		return err
+	}
+	if err != nil {
+		logrus.Errorf("read photo failed: %s", err)
+		return echo.NewHTTPError(http.StatusBadRequest, errors.New("photo file is required"))
+	}
+	if file.Size > service.MaxPhotoSize {
+		return echo.NewHTTPError(http.StatusRequestEntityTooLarge, fmt.Errorf("photo is limited to %d bytes", service.MaxPhotoSize))
+	}
+	src, err := file.Open()
+	if err != nil {
+		logrus.Errorf("open photo failed: %s", err)
+		return echo.NewHTTPError(http.StatusBadRequest)
+	}
+	defer src.Close()
+	data, err := io.ReadAll(io.LimitReader(src, service.MaxPhotoSize+1))
+	if err != nil {
+		logrus.Errorf("read photo failed: %s", err)
+		return echo.NewHTTPError(http.StatusBadRequest)
+	}
+	if service.PhotoContentType(data) == "" {
+		return echo.NewHTTPError(http.StatusUnsupportedMediaType, errors.New("photo must be jpeg or png"))
+	}
+
+	photo, err := hlr.service.AddPhoto(c.Request().Context(), catID, data)
+	if err != nil {
+		logrus.Errorf("upload cat photo error %s", err)
+		return newHTTPError(err, "could not upload cat photo")
+	}
+
+	return c.JSON(http.StatusCreated, photo)
+}
+
+// List photos of cat by ID
+// @Summary      List cat photos
+// @Tags         photo
+// @Description  photos of cat in the order they were uploaded
+// @ID           list-cat-photos
+// @Produce      json
+// @Param        id  path       string  true  "Cat ID"
+// @Success      200  {object}  photoListResponse
+// @Failure      400  {string}  bad request
+// @Failure      404  {string}  not found
+// @Failure      500  {string}  internal error
+// @Router       /cat/{id}/photos [get]
+func (hlr *PhotoHandler) List(c echo.Context) error {
+	catID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err)
+	}
+
+	photos, err := hlr.service.Photos(c.Request().Context(), catID)
+	if err != nil {
+		logrus.Errorf("cat photos error %s", err)
+		return newHTTPError(err, "could not get cat photos")
+	}
+
+	return c.JSON(http.StatusOK, photoListResponse{Photos: photos})
+}
+
+// Delete photo of cat by ID
+// @Summary      Delete cat photo
+// @Tags         photo
+// @Description  delete photo with all its sizes
+// @ID           delete-cat-photo
+// @Param        id        path       string  true  "Cat ID"
+// @Param        photo_id  path       string  true  "Photo ID"
+// @Success      204  {string}  no content
+// @Failure      400  {string}  bad request
+// @Failure      404  {string}  not found
+// @Failure      500  {string}  internal error
+// @Router       /cat/{id}/photos/{photo_id} [delete]
+func (hlr *PhotoHandler) Delete(c echo.Context) error {
+	catID, photoID, err := photoParams(c)
+	if err != nil {
+		return err
+	}
+
+	err = hlr.service.DeletePhoto(c.Request().Context(), catID, photoID)
+	if err != nil {
+		logrus.Errorf("delete cat photo error %s", err)
+		return newHTTPError(err, "could not delete cat photo")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// Image of cat photo
+// @Summary      Get cat photo image
+// @Tags         photo
+// @Description  image of the photo in original, medium or thumbnail size
+// @ID           get-cat-photo-image
+// @Produce      image/jpeg,image/png
+// @Param        id        path       string  true  "Cat ID"
+// @Param        photo_id  path       string  true  "Photo ID"
+// @Param        size      path       string  true  "Size: original, medium or thumbnail"
+// @Success      200  {file}    binary
+// @Failure      400  {string}  bad request
+// @Failure      404  {string}  not found
+// @Failure      422  {string}  unprocessable entity
+// @Failure      500  {string}  internal error
+// @Router       /cat/{id}/photos/{photo_id}/{size} [get]
+func (hlr *PhotoHandler) Image(c echo.Context) error {
+	catID, photoID, err := photoParams(c)
+	if err != nil {
+		return err
+	}
+
+	image, contentType, err := hlr.service.Image(c.Request().Context(), catID, photoID, c.Param("size"))
+	if err != nil {
+		logrus.Errorf("cat photo image error %s", err)
+		return newHTTPError(err, "could not get cat photo")
+	}
+	defer image.Close()
+
+	// images of the photo never change, a new upload gets a new ID
+	c.Response().Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+	return c.Stream(http.StatusOK, contentType, image)
+}
+
+// photoParams parses cat and photo IDs from the path
+func photoParams(c echo.Context) (uuid.UUID, uuid.UUID, error) {
+	catID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return uuid.Nil, uuid.Nil, echo.NewHTTPError(http.StatusBadRequest, err)
+	}
+	photoID, err := uuid.Parse(c.Param("photo_id"))
+	if err != nil {
+		return uuid.Nil, uuid.Nil, echo.NewHTTPError(http.StatusBadRequest, err)
+	}
+
+	return catID, photoID, nil
+}
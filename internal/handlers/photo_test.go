@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	servicemock "github.com/catService/internal/service/service_mock"
+
+	"github.com/catService/internal/model"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// photoRequest returns the multipart upload request with the file
+func photoRequest(t *testing.T, data []byte) *http.Request {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("photo", "cat.png")
+	require.NoError(t, err)
+	_, err = part.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/cat/", &body)
+	req.Header.Set(echo.HeaderContentType, writer.FormDataContentType())
+	return req
+}
+
+func TestPhotoHandler_Upload(t *testing.T) {
+	id := uuid.MustParse("a0664c54-4ad3-4445-bb25-fb34f2ff67fc")
+	var data bytes.Buffer
+	require.NoError(t, png.Encode(&data, image.NewGray(image.Rect(0, 0, 4, 4))))
+
+	service := &servicemock.PhotosService{}
+	service.On("AddPhoto", context.Background(), id, data.Bytes()).Return(&model.Photo{ID: uuid.New(), ContentType: "image/png"}, nil)
+
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(photoRequest(t, data.Bytes()), rec)
+	ctx.SetParamNames("id")
+	ctx.SetParamValues(id.String())
+	require.NoError(t, NewPhoto(service).Upload(ctx))
+	require.Equal(t, http.StatusCreated, rec.Code)
+
+	ctx = e.NewContext(photoRequest(t, []byte("name,age\nCat 1,2\n")), httptest.NewRecorder())
+	ctx.SetParamNames("id")
+	ctx.SetParamValues(id.String())
+	err := NewPhoto(service).Upload(ctx)
+	require.Equal(t, http.StatusUnsupportedMediaType, err.(*echo.HTTPError).Code)
+	service.AssertNumberOfCalls(t, "AddPhoto", 1)
+}
+
+func TestPhotoHandler_UploadLimit(t *testing.T) {
+	e := echo.New()
+	e.POST("/v1/cat/:id/photos", NewPhoto(&servicemock.PhotosService{}).Upload, PhotoUploadLimit())
+
+	req := photoRequest(t, bytes.Repeat([]byte{0}, maxPhotoRequestSize))
+	req.URL.Path = "/v1/cat/" + uuid.NewString() + "/photos"
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+
+	req = photoRequest(t, bytes.Repeat([]byte{0}, maxPhotoRequestSize))
+	req.URL.Path = "/v1/cat/" + uuid.NewString() + "/photos"
+	req.ContentLength = -1
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+}
+
+func TestPhotoHandler_Image(t *testing.T) {
+	id := uuid.MustParse("a0664c54-4ad3-4445-bb25-fb34f2ff67fc")
+	photoID := uuid.MustParse("2f4b7e1e-43f5-4a49-9b53-5b8f3a2a9c1d")
+
+	service := &servicemock.PhotosService{}
+	service.On("Image", context.Background(), id, photoID, model.PhotoThumbnail).
+		Return(io.NopCloser(bytes.NewReader([]byte("jpeg"))), "image/jpeg", nil)
+	service.On("Image", context.Background(), id, photoID, model.PhotoMedium).Return(nil, "", model.ErrPhotoNotFound)
+
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(httptest.NewRequest(http.MethodGet, "/v1/cat/", nil), rec)
+	ctx.SetParamNames("id", "photo_id", "size")
+	ctx.SetParamValues(id.String(), photoID.String(), model.PhotoThumbnail)
+	require.NoError(t, NewPhoto(service).Image(ctx))
+	require.Equal(t, "image/jpeg", rec.Header().Get(echo.HeaderContentType))
+	require.Equal(t, "jpeg", rec.Body.String())
+
+	ctx = e.NewContext(httptest.NewRequest(http.MethodGet, "/v1/cat/", nil), httptest.NewRecorder())
+	ctx.SetParamNames("id", "photo_id", "size")
+	ctx.SetParamValues(id.String(), photoID.String(), model.PhotoMedium)
+	err := NewPhoto(service).Image(ctx)
+	require.Equal(t, http.StatusNotFound, err.(*echo.HTTPError).Code)
+	service.AssertCalled(t, "Image", mock.Anything, id, photoID, model.PhotoMedium)
+}
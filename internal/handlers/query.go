@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// queryBool returns optional bool query parameter, nil if it's absent
+func queryBool(c echo.Context, name string) (*bool, error) {
+	raw := c.QueryParam(name)
+	if raw == "" {
+		return nil, nil
+	}
+	value, err := strconv.ParseBool(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be a boolean", name)
+	}
+
+	return &value, nil
+}
+
+// queryInt returns optional int query parameter, nil if it's absent
+func queryInt(c echo.Context, name string) (*int, error) {
+	raw := c.QueryParam(name)
+	if raw == "" {
+		return nil, nil
+	}
+	value, err := strconv.Atoi(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be an integer", name)
+	}
+
+	return &value, nil
+}
+
+// queryUUID returns optional UUID query parameter, nil if it's absent
+func queryUUID(c echo.Context, name string) (*uuid.UUID, error) {
+	raw := c.QueryParam(name)
+	if raw == "" {
+		return nil, nil
+	}
+	value, err := uuid.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be a UUID", name)
+	}
+
+	return &value, nil
+}
+
+// queryDate returns optional date query parameter in YYYY-MM-DD format, nil if it's absent
+func queryDate(c echo.Context, name string) (*time.Time, error) {
+	raw := c.QueryParam(name)
+	if raw == "" {
+		return nil, nil
+	}
+	value, err := time.Parse(dateLayout, raw)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be a date in YYYY-MM-DD format", name)
+	}
+
+	return &value, nil
+}
@@ -0,0 +1,252 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/catService/internal/model"
+	"github.com/catService/internal/service"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+// ShelterHandler contain link to service
+type ShelterHandler struct {
+	service service.SheltersService
+}
+
+// NewShelter return ShelterHandler
+func NewShelter(s service.SheltersService) *ShelterHandler {
+	return &ShelterHandler{
+		service: s,
+	}
+}
+
+type shelterRequest struct {
+	Name     string `json:"name" validate:"required"`
+	Address  string `json:"address"`
+	Capacity int    `json:"capacity" validate:"min=0"`
+	Contact  string `json:"contact"`
+}
+
+type shelterListResponse struct {
+	Shelters   []*model.Shelter `json:"shelters"`
+	NextCursor string           `json:"next_cursor,omitempty"`
+}
+
+// Create shelter
+// @Summary      Create shelter
+// @Tags         shelter
+// @Description  create shelter
+// @ID           create-shelter
+// @Accept       json
+// @Produce      json
+// @Param        input  body       shelterRequest  true  "Shelter info"
+// @Success      201  {object}  model.Shelter
+// @Failure      400  {string}  bad request
+// @Failure      422  {string}  unprocessable entity
+// @Failure      500  {string}  internal error
+// @Router       /shelter/ [post]
+func (hlr *ShelterHandler) Create(c echo.Context) error {
+	shelter, err := bindShelter(c)
+	if err != nil {
+		return err
+	}
+
+	err = hlr.service.Create(c.Request().Context(), shelter)
+	if err != nil {
+		logrus.Errorf("create shelter error: %s", err)
+		return newHTTPError(err, "could not create shelter")
+	}
+
+	return c.JSON(http.StatusCreated, shelter)
+}
+
+// Get returns shelter by ID
+// @Summary      Get shelter by ID
+// @Tags         shelter
+// @Description  get shelter
+// @ID           get-shelter
+// @Produce      json
+// @Param        id  path       string  true  "Shelter ID"
+// @Success      200  {object}  model.Shelter
+// @Failure      400  {string}  bad request
+// @Failure      404  {string}  not found
+// @Failure      500  {string}  internal error
+// @Router       /shelter/{id} [get]
+func (hlr *ShelterHandler) Get(c echo.Context) error {
+	shelterID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err)
+	}
+
+	shelter, err := hlr.service.Get(c.Request().Context(), shelterID)
+	if err != nil {
+		logrus.Errorf("get shelter error %s", err)
+		return newHTTPError(err, "could not get shelter")
+	}
+
+	return c.JSON(http.StatusOK, shelter)
+}
+
+// Update shelter by ID
+// @Summary      Update shelter by ID
+// @Tags         shelter
+// @Description  update shelter
+// @ID           update-shelter
+// @Accept       json
+// @Produce      json
+// @Param        id     path       string          true  "Shelter ID"
+// @Param        input  body       shelterRequest  true  "Shelter info"
+// @Success      200  {object}  model.Shelter
+// @Failure      400  {string}  bad request
+// @Failure      404  {string}  not found
+// @Failure      422  {string}  unprocessable entity
+// @Failure      500  {string}  internal error
+// @Router       /shelter/{id} [put]
+func (hlr *ShelterHandler) Update(c echo.Context) error {
+	shelterID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err)
+	}
+	shelter, err := bindShelter(c)
+	if err != nil {
+		return err
+	}
+	shelter.ID = shelterID
+
+	err = hlr.service.Update(c.Request().Context(), shelter)
+	if err != nil {
+		logrus.Errorf("shelter update error %s", err)
+		return newHTTPError(err, "could not update shelter")
+	}
+
+	return c.JSON(http.StatusOK, shelter)
+}
+
+// Delete shelter by ID
+// @Summary      Delete shelter by ID
+// @Tags         shelter
+// @Description  delete shelter which has no cats
+// @ID           delete-shelter
+// @Param        id  path       string  true  "Shelter ID"
+// @Success      204  {string}  no content
+// @Failure      400  {string}  bad request
+// @Failure      404  {string}  not found
+// @Failure      409  {string}  conflict
+// @Failure      500  {string}  internal error
+// @Router       /shelter/{id} [delete]
+func (hlr *ShelterHandler) Delete(c echo.Context) error {
+	shelterID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err)
+	}
+
+	err = hlr.service.Delete(c.Request().Context(), shelterID)
+	if err != nil {
+		logrus.Errorf("shelter delete error %s", err)
+		return newHTTPError(err, "could not delete shelter")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// List returns shelters page by page
+// @Summary      List shelters
+// @Tags         shelter
+// @Description  list shelters with cursor pagination
+// @ID           list-shelters
+// @Produce      json
+// @Param        limit   query      int     false  "Page size, 20 by default"
+// @Param        cursor  query      string  false  "Cursor of the next page"
+// @Success      200  {object}  shelterListResponse
+// @Failure      400  {string}  bad request
+// @Failure      422  {string}  unprocessable entity
+// @Failure      500  {string}  internal error
+// @Router       /shelter/ [get]
+func (hlr *ShelterHandler) List(c echo.Context) error {
+	limit, err := queryInt(c, "limit")
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err)
+	}
+	if limit == nil {
+		limit = new(int)
+	}
+
+	page, err := hlr.service.List(c.Request().Context(), *limit, c.QueryParam("cursor"))
+	if err != nil {
+		logrus.Errorf("shelter list error %s", err)
+		return newHTTPError(err, "could not list shelters")
+	}
+
+	return c.JSON(http.StatusOK, shelterListResponse{
+		Shelters:   page.Shelters,
+		NextCursor: page.NextCursor,
+	})
+}
+
+// Cats returns cats of the shelter page by page
+// @Summary      List shelter cats
+// @Tags         shelter
+// @Description  list cats of the shelter with the filters of the cat list
+// @ID           list-shelter-cats
+// @Produce      json
+// @Param        id          path       string  true   "Shelter ID"
+// @Param        vaccinated  query      bool    false  "Vaccination status"
+// @Param        min_age     query      int     false  "Minimal age"
+// @Param        max_age     query      int     false  "Maximal age"
+// @Param        name        query      string  false  "Name prefix"
+// @Param        deleted     query      string  false  "Deleted cats: include or only, hidden by default"
+// @Param        status      query      string  false  "Adoption status: available, reserved, adopted or returned"
+// @Param        in_foster   query      bool    false  "Cats which are with a foster now or which are not"
+// @Param        sort        query      string  false  "Sort field: id, name, age or vaccinated. Prefix - means descending order"
+// @Param        limit       query      int     false  "Page size, 20 by default"
+// @Param        cursor      query      string  false  "Cursor of the next page"
+// @Success      200  {object}  catListResponse
+// @Failure      400  {string}  bad request
+// @Failure      404  {string}  not found
+// @Failure      422  {string}  unprocessable entity
+// @Failure      500  {string}  internal error
+// @Router       /shelter/{id}/cats [get]
+func (hlr *ShelterHandler) Cats(c echo.Context) error {
+	shelterID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err)
+	}
+	query, err := bindCatQuery(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err)
+	}
+
+	page, err := hlr.service.Cats(c.Request().Context(), shelterID, query)
+	if err != nil {
+		logrus.Errorf("shelter cats error %s", err)
+		return newHTTPError(err, "could not list shelter cats")
+	}
+
+	return c.JSON(http.StatusOK, catListResponse{
+		Cats:       page.Cats,
+		NextCursor: page.NextCursor,
+	})
+}
+
+// bindShelter reads and validates shelter from the request body
+func bindShelter(c echo.Context) (*model.Shelter, error) {
+	var request shelterRequest
+	if err := c.Bind(&request); err != nil {
+		logrus.Errorf("bind failed: %s", err)
+		return nil, echo.NewHTTPError(http.StatusBadRequest)
+	}
+	if err := c.Validate(&request); err != nil {
+		logrus.Errorf("validate failed: %s", err)
+		return nil, echo.NewHTTPError(http.StatusUnprocessableEntity, err)
+	}
+
+	return &model.Shelter{
+		Name:     request.Name,
+		Address:  request.Address,
+		Capacity: request.Capacity,
+		Contact:  request.Contact,
+	}, nil
+}
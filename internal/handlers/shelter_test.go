@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	servicemock "github.com/catService/internal/service/service_mock"
+
+	"github.com/catService/internal/model"
+	"github.com/catService/internal/validator"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShelterHandler_Create(t *testing.T) {
+	service := &servicemock.SheltersService{}
+	service.On("Create", context.Background(), &model.Shelter{Name: "Shelter 1", Address: "Main st. 1", Capacity: 20}).Return(nil)
+
+	e := echo.New()
+	e.Validator = validator.NewValidator()
+	req := httptest.NewRequest(http.MethodPost, "/v1/shelter/", strings.NewReader(`{"name":"Shelter 1","address":"Main st. 1","capacity":20}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+
+	err := NewShelter(service).Create(e.NewContext(req, rec))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, rec.Code)
+	service.AssertExpectations(t)
+
+	req = httptest.NewRequest(http.MethodPost, "/v1/shelter/", strings.NewReader(`{"address":"Main st. 1"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	err = NewShelter(service).Create(e.NewContext(req, httptest.NewRecorder()))
+	require.Equal(t, http.StatusUnprocessableEntity, err.(*echo.HTTPError).Code)
+}
+
+func TestShelterHandler_DeleteErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{name: "has cats", err: model.ErrConflict, status: http.StatusConflict},
+		{name: "not found", err: model.ErrShelterNotFound, status: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id := uuid.New()
+			service := &servicemock.SheltersService{}
+			service.On("Delete", context.Background(), id).Return(tt.err)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodDelete, "/v1/shelter/", nil)
+			ctx := e.NewContext(req, httptest.NewRecorder())
+			ctx.SetPath("/shelter/:id")
+			ctx.SetParamNames("id")
+			ctx.SetParamValues(id.String())
+			err := NewShelter(service).Delete(ctx)
+			require.Equal(t, tt.status, err.(*echo.HTTPError).Code)
+		})
+	}
+}
+
+func TestShelterHandler_Cats(t *testing.T) {
+	id := uuid.New()
+	service := &servicemock.SheltersService{}
+	service.On("Cats", context.Background(), id, mock.MatchedBy(func(query *model.CatQuery) bool {
+		return query.SortBy == model.CatSortAge && query.Desc && query.Limit == 5
+	})).Return(&model.CatPage{Cats: []*model.Cat{{ID: uuid.New(), ShelterID: &id}}}, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/v1/shelter/"+id.String()+"/cats?sort=-age&limit=5", nil)
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	ctx.SetPath("/shelter/:id/cats")
+	ctx.SetParamNames("id")
+	ctx.SetParamValues(id.String())
+	err := NewShelter(service).Cats(ctx)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), id.String())
+}
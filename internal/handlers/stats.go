@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/catService/internal/model"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+type ageCountResponse struct {
+	Ages  string `json:"ages"`
+	Count int64  `json:"count"`
+}
+
+type statsResponse struct {
+	Total           int64              `json:"total"`
+	ByStatus        map[string]int64   `json:"by_status"`
+	VaccinatedRatio float64            `json:"vaccinated_ratio"`
+	Ages            []ageCountResponse `json:"ages"`
+	Stays           int64              `json:"stays"`
+	AverageStayDays float64            `json:"average_stay_days"`
+	MedianStayDays  float64            `json:"median_stay_days"`
+}
+
+// Stats of cats and their stays
+// @Summary      Cat statistics
+// @Tags         stats
+// @Description  count of not deleted cats by status and age, vaccinated ratio and length of finished stays in days.
+// @Description  The date range selects cats by the intake date of their profile and stays by the date of the intake record
+// @ID           cat-stats
+// @Produce      json
+// @Param        shelter_id  query      string  false  "Shelter ID"
+// @Param        from        query      string  false  "First date of the range, YYYY-MM-DD"
+// @Param        to          query      string  false  "Last date of the range, YYYY-MM-DD"
+// @Success      200  {object}  statsResponse
+// @Failure      400  {string}  bad request
+// @Failure      404  {string}  not found
+// @Failure      422  {string}  unprocessable entity
+// @Failure      500  {string}  internal error
+// @Router       /stats [get]
+func (hlr *CatHandler) Stats(c echo.Context) error {
+	filter := &model.StatsFilter{}
+	var err error
+	if filter.ShelterID, err = queryUUID(c, "shelter_id"); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err)
+	}
+	if filter.From, err = queryDate(c, "from"); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err)
+	}
+	if filter.To, err = queryDate(c, "to"); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err)
+	}
+	if filter.To != nil {
+		// the last date is included
+		to := filter.To.AddDate(0, 0, 1)
+		filter.To = &to
+	}
+
+	stats, err := hlr.service.Stats(c.Request().Context(), filter)
+	if err != nil {
+		logrus.Errorf("cat stats error %s", err)
+		return newHTTPError(err, "could not count cats")
+	}
+
+	response := statsResponse{
+		Total:           stats.Total,
+		ByStatus:        stats.ByStatus,
+		VaccinatedRatio: stats.VaccinatedRatio,
+		Ages:            make([]ageCountResponse, 0, len(stats.Ages)),
+		Stays:           stats.Stays,
+		AverageStayDays: stats.AverageStayDays,
+		MedianStayDays:  stats.MedianStayDays,
+	}
+	for _, ages := range stats.Ages {
+		response.Ages = append(response.Ages, ageCountResponse{Ages: ages.Ages, Count: ages.Count})
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	servicemock "github.com/catService/internal/service/service_mock"
+
+	"github.com/catService/internal/model"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCatHandler_Stats(t *testing.T) {
+	stats := model.NewCatStats()
+	stats.Add(model.CatReserved, true, 1, 4)
+
+	service := &servicemock.SheltersCatService{}
+	service.On("Stats", context.Background(), mock.MatchedBy(func(filter *model.StatsFilter) bool {
+		return filter.ShelterID == nil && filter.From.Equal(time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC)) &&
+			filter.To.Equal(time.Date(2022, time.February, 1, 0, 0, 0, 0, time.UTC))
+	})).Return(stats, nil)
+
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/v1/stats?from=2022-01-01&to=2022-01-31", nil)
+	require.NoError(t, NewCat(service).Stats(e.NewContext(req, rec)))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var response statsResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	require.Equal(t, int64(4), response.Total)
+	require.Equal(t, int64(4), response.ByStatus[model.CatReserved])
+	require.Equal(t, "1-2", response.Ages[1].Ages)
+	require.Equal(t, int64(4), response.Ages[1].Count)
+
+	req = httptest.NewRequest(http.MethodGet, "/v1/stats?from=01.01.2022", nil)
+	err := NewCat(service).Stats(e.NewContext(req, httptest.NewRecorder()))
+	require.Equal(t, http.StatusBadRequest, err.(*echo.HTTPError).Code)
+}
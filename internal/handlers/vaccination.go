@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/catService/internal/model"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+type vaccinationRequest struct {
+	Vaccine        string     `json:"vaccine" validate:"required"`
+	AdministeredAt time.Time  `json:"administered_at" validate:"required"`
+	Lot            string     `json:"lot"`
+	Vet            string     `json:"vet"`
+	ExpiresAt      *time.Time `json:"expires_at"`
+}
+
+type vaccinationListResponse struct {
+	Vaccinations []*model.Vaccination `json:"vaccinations"`
+}
+
+// Vaccinations of cat by ID
+// @Summary      List cat vaccinations
+// @Tags         vaccination
+// @Description  vaccination records of cat in the order they were administered
+// @ID           list-cat-vaccinations
+// @Produce      json
+// @Param        id  path       string  true  "Cat ID"
+// @Success      200  {object}  vaccinationListResponse
+// @Failure      400  {string}  bad request
+// @Failure      404  {string}  not found
+// @Failure      500  {string}  internal error
+// @Router       /cat/{id}/vaccinations [get]
+func (hlr *CatHandler) Vaccinations(c echo.Context) error {
+	catID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err)
+	}
+
+	vaccinations, err := hlr.service.Vaccinations(c.Request().Context(), catID)
+	if err != nil {
+		logrus.Errorf("cat vaccinations error %s", err)
+		return newHTTPError(err, "could not get cat vaccinations")
+	}
+
+	return c.JSON(http.StatusOK, vaccinationListResponse{Vaccinations: vaccinations})
+}
+
+// AddVaccination to cat by ID
+// @Summary      Add cat vaccination
+// @Tags         vaccination
+// @Description  add vaccination record, vaccinated flag of cat is derived from its records:
+// @Description  the cat is vaccinated while FVRCP and rabies vaccines haven't expired
+// @ID           add-cat-vaccination
+// @Accept       json
+// @Produce      json
+// @Param        id     path       string              true  "Cat ID"
+// @Param        input  body       vaccinationRequest  true  "Vaccination record"
+// @Success      201  {object}  model.Vaccination
+// @Failure      400  {string}  bad request
+// @Failure      404  {string}  not found
+// @Failure      422  {string}  unprocessable entity
+// @Failure      500  {string}  internal error
+// @Router       /cat/{id}/vaccinations [post]
+func (hlr *CatHandler) AddVaccination(c echo.Context) error {
+	catID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err)
+	}
+	var request vaccinationRequest
+	if err := c.Bind(&request); err != nil {
+		logrus.Errorf("bind failed: %s", err)
+		return echo.NewHTTPError(http.StatusBadRequest)
+	}
+	if err := c.Validate(&request); err != nil {
+		logrus.Errorf("validate failed: %s", err)
+		return echo.NewHTTPError(http.StatusUnprocessableEntity, err)
+	}
+
+	vaccination := &model.Vaccination{
+		Vaccine:        request.Vaccine,
+		AdministeredAt: request.AdministeredAt,
+		Lot:            request.Lot,
+		Vet:            request.Vet,
+		ExpiresAt:      request.ExpiresAt,
+	}
+	err = hlr.service.AddVaccination(c.Request().Context(), catID, vaccination)
+	if err != nil {
+		logrus.Errorf("add cat vaccination error %s", err)
+		return newHTTPError(err, "could not add cat vaccination")
+	}
+
+	return c.JSON(http.StatusCreated, vaccination)
+}
+
+// DeleteVaccination of cat by ID
+// @Summary      Delete cat vaccination
+// @Tags         vaccination
+// @Description  delete vaccination record entered by mistake
+// @ID           delete-cat-vaccination
+// @Param        id              path       string  true  "Cat ID"
+// @Param        vaccination_id  path       string  true  "Vaccination ID"
+// @Success      204  {string}  no content
+// @Failure      400  {string}  bad request
+// @Failure      404  {string}  not found
+// @Failure      500  {string}  internal error
+// @Router       /cat/{id}/vaccinations/{vaccination_id} [delete]
+func (hlr *CatHandler) DeleteVaccination(c echo.Context) error {
+	catID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err)
+	}
+	vaccinationID, err := uuid.Parse(c.Param("vaccination_id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err)
+	}
+
+	err = hlr.service.DeleteVaccination(c.Request().Context(), catID, vaccinationID)
+	if err != nil {
+		logrus.Errorf("delete cat vaccination error %s", err)
+		return newHTTPError(err, "could not delete cat vaccination")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,166 @@
+package model
+
+import (
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+// Match weights, the age range weighs most as adopters usually come either for a kitten or for an adult cat
+const (
+	matchAgeWeight        = 3
+	matchNearAgeWeight    = 1
+	matchKidsWeight       = 2
+	matchSexWeight        = 1
+	matchVaccinatedWeight = 1
+)
+
+// Match reasons explain adopters and the staff why the cat is suggested
+const (
+	MatchAgeInRange   = "age_in_range"
+	MatchAgeNearRange = "age_near_range"
+	MatchGoodWithKids = "good_with_kids"
+	MatchSex          = "sex"
+	MatchVaccinated   = "vaccinated"
+)
+
+// Adopter is a person who wants to adopt a cat
+type Adopter struct {
+	ID          uuid.UUID          `bson:"_id"`
+	Name        string             `bson:"name"`
+	Email       string             `bson:"email"`
+	Phone       string             `bson:"phone"`
+	Address     string             `bson:"address"`
+	Household   Household          `bson:"household"`
+	Preferences AdopterPreferences `bson:"preferences"`
+}
+
+// Household describes the home the cat goes to
+type Household struct {
+	Adults    int    `bson:"adults"`
+	Children  int    `bson:"children"`
+	OtherPets bool   `bson:"other_pets"`
+	HomeType  string `bson:"home_type"`
+}
+
+// AdopterPreferences are the wishes of the adopter, zero values mean the adopter doesn't mind.
+// Vaccinated-only, the sex and the age range with a year around it exclude cats, the other preferences rank the cats
+type AdopterPreferences struct {
+	MinAge         *int   `bson:"min_age"`
+	MaxAge         *int   `bson:"max_age"`
+	Sex            string `bson:"sex"`
+	VaccinatedOnly bool   `bson:"vaccinated_only"`
+	GoodWithKids   bool   `bson:"good_with_kids"`
+}
+
+// CandidateFilter returns the filter of the cats Match doesn't exclude
+func (a *Adopter) CandidateFilter() CatFilter {
+	p := &a.Preferences
+	filter := CatFilter{Sex: p.Sex, NotBadWithKids: a.NeedsGoodWithKids()}
+	if p.VaccinatedOnly {
+		filter.Vaccinated = &p.VaccinatedOnly
+	}
+	if p.MinAge != nil && *p.MinAge > 0 {
+		minAge := *p.MinAge - 1
+		filter.MinAge = &minAge
+	}
+	if p.MaxAge != nil {
+		maxAge := *p.MaxAge + 1
+		filter.MaxAge = &maxAge
+	}
+
+	return filter
+}
+
+// AdopterPage is a part of the adopter list with a cursor to the next part
+type AdopterPage struct {
+	Adopters   []*Adopter
+	NextCursor string
+}
+
+// CatMatch is the cat suggested to the adopter with the score of the match and its reasons
+type CatMatch struct {
+	Cat     *Cat
+	Score   int
+	Reasons []string
+}
+
+// NeedsGoodWithKids reports whether the cat must get on with children,
+// adopters with children in the household need it even if they didn't ask
+func (a *Adopter) NeedsGoodWithKids() bool {
+	return a.Preferences.GoodWithKids || a.Household.Children > 0
+}
+
+// Match scores the cat against the preferences of the adopter.
+// It returns nil for the cat which is excluded: not vaccinated for vaccinated-only adopters,
+// of the other sex, more than a year out of the age range
+// or known to be bad with kids for adopters who need a cat good with kids
+func (a *Adopter) Match(cat *Cat) *CatMatch {
+	p := &a.Preferences
+	if p.VaccinatedOnly && !cat.Vaccinated {
+		return nil
+	}
+	if p.Sex != "" && p.Sex != cat.Sex || p.ageDistance(cat.Age) > 1 {
+		return nil
+	}
+	if a.NeedsGoodWithKids() && cat.GoodWithKids != nil && !*cat.GoodWithKids {
+		return nil
+	}
+
+	match := &CatMatch{Cat: cat, Reasons: make([]string, 0)}
+	add := func(weight int, reason string) {
+		match.Score += weight
+		match.Reasons = append(match.Reasons, reason)
+	}
+	if p.MinAge != nil || p.MaxAge != nil {
+		switch distance := p.ageDistance(cat.Age); {
+		case distance == 0:
+			add(matchAgeWeight, MatchAgeInRange)
+		case distance == 1:
+			add(matchNearAgeWeight, MatchAgeNearRange)
+		}
+	}
+	if a.NeedsGoodWithKids() && cat.GoodWithKids != nil {
+		add(matchKidsWeight, MatchGoodWithKids)
+	}
+	if p.Sex != "" {
+		add(matchSexWeight, MatchSex)
+	}
+	if !p.VaccinatedOnly && cat.Vaccinated {
+		add(matchVaccinatedWeight, MatchVaccinated)
+	}
+
+	return match
+}
+
+// ageDistance returns how many years the age is out of the preferred range
+func (p *AdopterPreferences) ageDistance(age int) int {
+	switch {
+	case p.MinAge != nil && age < *p.MinAge:
+		return *p.MinAge - age
+	case p.MaxAge != nil && age > *p.MaxAge:
+		return age - *p.MaxAge
+	default:
+		return 0
+	}
+}
+
+// SortMatches orders the matches by score. Cats with the same score which have waited longer go first
+func SortMatches(matches []*CatMatch) {
+	sort.SliceStable(matches, func(i, j int) bool {
+		a, b := matches[i], matches[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		switch {
+		case a.Cat.IntakeDate != nil && b.Cat.IntakeDate != nil && !a.Cat.IntakeDate.Equal(*b.Cat.IntakeDate):
+			return a.Cat.IntakeDate.Before(*b.Cat.IntakeDate)
+		case a.Cat.IntakeDate != nil && b.Cat.IntakeDate == nil:
+			return true
+		case a.Cat.IntakeDate == nil && b.Cat.IntakeDate != nil:
+			return false
+		}
+
+		return a.Cat.ID.String() < b.Cat.ID.String()
+	})
+}
@@ -0,0 +1,71 @@
+package model
+
+// Cat adoption statuses
+const (
+	CatAvailable = "available"
+	CatReserved  = "reserved"
+	CatAdopted   = "adopted"
+	CatReturned  = "returned"
+)
+
+// Adoption transitions, they are also the actions of the cat events
+const (
+	CatReserve           = "reserve"
+	CatCancelReservation = "cancel-reservation"
+	CatAdopt             = "adopt"
+	CatReturn            = "return"
+)
+
+// IsTransition reports whether the action is an adoption transition
+func IsTransition(action string) bool {
+	switch action {
+	case CatReserve, CatCancelReservation, CatAdopt, CatReturn:
+		return true
+	default:
+		return false
+	}
+}
+
+// StatusOrDefault returns the status, cats stored before statuses appeared are available
+func StatusOrDefault(status string) string {
+	if status == "" {
+		return CatAvailable
+	}
+
+	return status
+}
+
+// TransitionTo returns the transition which leads to the status, each status has one
+func TransitionTo(status string) string {
+	switch status {
+	case CatReserved:
+		return CatReserve
+	case CatAvailable:
+		return CatCancelReservation
+	case CatAdopted:
+		return CatAdopt
+	case CatReturned:
+		return CatReturn
+	default:
+		return ""
+	}
+}
+
+// NextStatus returns the status the transition leads to from the given status,
+// empty string means the transition is not allowed
+func NextStatus(status, transition string) string {
+	status = StatusOrDefault(status)
+
+	switch {
+	case transition == CatReserve && (status == CatAvailable || status == CatReturned):
+		return CatReserved
+	case transition == CatCancelReservation && status == CatReserved:
+		return CatAvailable
+	case transition == CatAdopt && status != CatAdopted:
+		return CatAdopted
+	case transition == CatReturn && status == CatAdopted:
+		return CatReturned
+	default:
+		return ""
+	}
+}
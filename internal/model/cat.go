@@ -3,19 +3,226 @@ package model
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/google/uuid"
 )
 
-// Cat struct
+// Cat sort fields
+const (
+	CatSortID         = "id"
+	CatSortName       = "name"
+	CatSortAge        = "age"
+	CatSortVaccinated = "vaccinated"
+)
+
+// AnyVersion disables version check of the changed cat
+const AnyVersion int64 = 0
+
+// Cat sexes, empty sex is unknown
+const (
+	CatMale   = "male"
+	CatFemale = "female"
+)
+
+// Cat struct.
+// Age of the cat with birth date is derived from it, see DeriveAge.
+// Vaccinated of the cat with vaccination records is derived from them, see UpToDate.
+// Photos are filled only when a single cat is returned
 type Cat struct {
-	ID         uuid.UUID `bson:"_id"`
-	Name       string    `bson:"name"`
-	Age        int       `bson:"age"`
-	Vaccinated bool      `bson:"vaccinated"`
+	ID         uuid.UUID  `bson:"_id"`
+	Name       string     `bson:"name"`
+	Age        int        `bson:"age"`
+	Vaccinated bool       `bson:"vaccinated"`
+	ShelterID  *uuid.UUID `bson:"shelter_id"`
+	Status     string     `bson:"status"`
+	CatProfile `bson:",inline"`
+	Version    int64      `bson:"version"`
+	DeletedAt  *time.Time `bson:"deleted_at"`
+	Photos     []*Photo   `bson:"-" json:",omitempty"`
+}
+
+// CatProfile describes the cat for adopters and the staff.
+// Dates are kept without time, nil Neutered and GoodWithKids mean the staff doesn't know yet
+type CatProfile struct {
+	BirthDate          *time.Time `bson:"birth_date"`
+	BirthDateEstimated bool       `bson:"birth_date_estimated"`
+	Sex                string     `bson:"sex"`
+	Breed              string     `bson:"breed"`
+	CoatColor          string     `bson:"coat_color"`
+	Neutered           *bool      `bson:"neutered"`
+	IntakeDate         *time.Time `bson:"intake_date"`
+	Microchip          *string    `bson:"microchip"`
+	GoodWithKids       *bool      `bson:"good_with_kids"`
+}
+
+// Equal reports whether the profiles describe the cat the same way
+func (p *CatProfile) Equal(other *CatProfile) bool {
+	return equalDates(p.BirthDate, other.BirthDate) && p.BirthDateEstimated == other.BirthDateEstimated &&
+		p.Sex == other.Sex && p.Breed == other.Breed && p.CoatColor == other.CoatColor &&
+		equalBools(p.Neutered, other.Neutered) && equalDates(p.IntakeDate, other.IntakeDate) &&
+		equalStrings(p.Microchip, other.Microchip) && equalBools(p.GoodWithKids, other.GoodWithKids)
+}
+
+// DeriveAge sets the age in full years at the given time for the cat with birth date
+func (c *Cat) DeriveAge(now time.Time) {
+	if c.BirthDate == nil {
+		return
+	}
+	birth := c.BirthDate.UTC()
+	now = now.UTC()
+	age := now.Year() - birth.Year()
+	if now.Month() < birth.Month() || now.Month() == birth.Month() && now.Day() < birth.Day() {
+		age--
+	}
+	if age < 0 {
+		age = 0
+	}
+	c.Age = age
+}
+
+// LatestBirthDate returns the last date a cat could be born on to be at least age years old at the given time
+func LatestBirthDate(age int, now time.Time) time.Time {
+	year, month, day := now.UTC().Date()
+	date := time.Date(year-age, month, day, 0, 0, 0, 0, time.UTC)
+	if date.Month() != month {
+		// February 29 of a common year, the last day of February is meant
+		date = time.Date(year-age, month+1, 0, 0, 0, 0, 0, time.UTC)
+	}
+
+	return date
+}
+
+func equalDates(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return a.Equal(*b)
+}
+
+func equalBools(a, b *bool) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
+
+func equalStrings(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
 }
 
 // MarshalBinary convert struct to []byte
 func (c Cat) MarshalBinary() ([]byte, error) {
 	return json.Marshal(c)
 }
+
+// SortKey returns a pointer to the field cats are sorted by, nil for unknown fields
+func (c *Cat) SortKey(field string) interface{} {
+	switch field {
+	case CatSortID:
+		return &c.ID
+	case CatSortName:
+		return &c.Name
+	case CatSortAge:
+		return &c.Age
+	case CatSortVaccinated:
+		return &c.Vaccinated
+	default:
+		return nil
+	}
+}
+
+// CatPatch contains cat fields which must be changed, nil fields stay as they are.
+// ShelterID equal to uuid.Nil takes the cat out of its shelter.
+// Profile replaces the whole profile of the cat.
+// Status is changed by adoption transitions only
+type CatPatch struct {
+	Name       *string
+	Age        *int
+	Vaccinated *bool
+	ShelterID  *uuid.UUID
+	Status     *string
+	Profile    *CatProfile
+}
+
+// Empty reports whether the patch changes nothing
+func (p *CatPatch) Empty() bool {
+	return p.Name == nil && p.Age == nil && p.Vaccinated == nil && p.ShelterID == nil && p.Status == nil &&
+		p.Profile == nil
+}
+
+// Shelter returns the shelter ID set by the patch, nil means the cat is taken out of the shelter
+func (p *CatPatch) Shelter() *uuid.UUID {
+	if p.ShelterID == nil || *p.ShelterID == uuid.Nil {
+		return nil
+	}
+	id := *p.ShelterID
+
+	return &id
+}
+
+// Apply changes the cat fields set in the patch
+func (p *CatPatch) Apply(cat *Cat) {
+	if p.Name != nil {
+		cat.Name = *p.Name
+	}
+	if p.Age != nil {
+		cat.Age = *p.Age
+	}
+	if p.Vaccinated != nil {
+		cat.Vaccinated = *p.Vaccinated
+	}
+	if p.ShelterID != nil {
+		cat.ShelterID = p.Shelter()
+	}
+	if p.Status != nil {
+		cat.Status = *p.Status
+	}
+	if p.Profile != nil {
+		cat.CatProfile = *p.Profile
+	}
+}
+
+// Deleted cats visibility in the cat list
+const (
+	DeletedExclude = ""
+	DeletedInclude = "include"
+	DeletedOnly    = "only"
+)
+
+// CatFilter contains conditions cats are selected by
+type CatFilter struct {
+	Vaccinated *bool
+	MinAge     *int
+	MaxAge     *int
+	NamePrefix string
+	ShelterID  *uuid.UUID
+	Status     string
+	Deleted    string
+	// InFoster selects cats which are with a foster now or which are not
+	InFoster *bool
+	Sex      string
+	// NotBadWithKids leaves out cats known to be bad with kids, cats without the record stay
+	NotBadWithKids bool
+}
+
+// CatQuery describes one page of the cat list
+type CatQuery struct {
+	CatFilter
+	SortBy string
+	Desc   bool
+	Limit  int
+	Cursor string
+}
+
+// CatPage is a part of the cat list with a cursor to the next part
+type CatPage struct {
+	Cats       []*Cat
+	NextCursor string
+}
@@ -0,0 +1,51 @@
+package model
+
+import "github.com/google/uuid"
+
+// Copy stages in the order they run, the records cats refer to are copied first
+const (
+	CopyShelters = "shelters"
+	CopyFosters  = "fosters"
+	CopyAdopters = "adopters"
+	CopyCats     = "cats"
+	CopyDone     = "done"
+)
+
+// CopyCheckpoint is the progress of the copy between storages.
+// After is the last record of the stage which was copied with everything before it
+type CopyCheckpoint struct {
+	From  string
+	To    string
+	Stage string
+	After *uuid.UUID
+}
+
+// CopyReport counts the copied records, records are the ones kept with the cats: vaccinations, photos,
+// intakes, placements and events. The dry run counts the records it would copy
+type CopyReport struct {
+	Shelters int64
+	Fosters  int64
+	Adopters int64
+	Cats     int64
+	Records  int64
+}
+
+// VerifyResult compares the records of one kind in two storages by their checksums.
+// Missing records are only in the source, extra ones only in the target, changed ones differ.
+// The lists keep first IDs of the differences, the counts are complete
+type VerifyResult struct {
+	Kind         string
+	Source       int64
+	Target       int64
+	MissingCount int64
+	ExtraCount   int64
+	ChangedCount int64
+	Missing      []uuid.UUID
+	Extra        []uuid.UUID
+	Changed      []uuid.UUID
+}
+
+// Equal reports whether the storages have the same records
+func (r *VerifyResult) Equal() bool {
+	return r.MissingCount == 0 && r.ExtraCount == 0 && r.ChangedCount == 0
+}
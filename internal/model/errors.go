@@ -0,0 +1,48 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrAdopterNotFound is returned when an adopter with the given ID doesn't exist
+	ErrAdopterNotFound = errors.New("adopter not found")
+	// ErrCatNotFound is returned when a cat with the given ID doesn't exist
+	ErrCatNotFound = errors.New("cat not found")
+	// ErrConflict is returned when a change conflicts with the stored state
+	ErrConflict = errors.New("conflict")
+	// ErrFosterNotFound is returned when a foster with the given ID doesn't exist
+	ErrFosterNotFound = errors.New("foster not found")
+	// ErrPhotoNotFound is returned when the cat has no photo with the given ID
+	ErrPhotoNotFound = errors.New("photo not found")
+	// ErrShelterNotFound is returned when a shelter with the given ID doesn't exist
+	ErrShelterNotFound = errors.New("shelter not found")
+	// ErrInvalid is returned when a cat or a shelter doesn't pass domain validation
+	ErrInvalid = errors.New("invalid")
+	// ErrVaccinationNotFound is returned when the cat has no vaccination record with the given ID
+	ErrVaccinationNotFound = errors.New("vaccination not found")
+	// ErrVersionMismatch is returned when the stored cat version differs from the expected one
+	ErrVersionMismatch = errors.New("version mismatch")
+)
+
+// ImportError lists the invalid cats of an import, the keys are indexes of the cats in the import
+type ImportError struct {
+	Cats map[int]error
+}
+
+func (e *ImportError) Error() string {
+	first := -1
+	for i := range e.Cats {
+		if first < 0 || i < first {
+			first = i
+		}
+	}
+
+	return fmt.Sprintf("%d invalid cats, first is cat %d: %s", len(e.Cats), first+1, e.Cats[first])
+}
+
+// Unwrap makes the import error match ErrInvalid
+func (e *ImportError) Unwrap() error {
+	return ErrInvalid
+}
@@ -0,0 +1,33 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Cat event actions, adoption transitions are recorded with their own actions
+const (
+	CatEventCreate  = "create"
+	CatEventUpdate  = "update"
+	CatEventDelete  = "delete"
+	CatEventRestore = "restore"
+)
+
+// CatEvent is an immutable record of the cat change.
+// Actor is "admin" for requests with the admin token, otherwise the name claimed by the client or "anonymous"
+type CatEvent struct {
+	ID        uuid.UUID `bson:"_id"`
+	CatID     uuid.UUID `bson:"cat_id"`
+	Action    string    `bson:"action"`
+	Before    *Cat      `bson:"before"`
+	After     *Cat      `bson:"after"`
+	Actor     string    `bson:"actor"`
+	CreatedAt time.Time `bson:"created_at"`
+}
+
+// CatEventPage is a part of the cat history with a cursor to the next part
+type CatEventPage struct {
+	Events     []*CatEvent
+	NextCursor string
+}
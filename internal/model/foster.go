@@ -0,0 +1,38 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Foster is a person who keeps cats at home until adoption
+type Foster struct {
+	ID      uuid.UUID `bson:"_id"`
+	Name    string    `bson:"name"`
+	Address string    `bson:"address"`
+	Contact string    `bson:"contact"`
+	Notes   string    `bson:"notes"`
+}
+
+// FosterPage is a part of the foster list with a cursor to the next part
+type FosterPage struct {
+	Fosters    []*Foster
+	NextCursor string
+}
+
+// Placement is a period the cat lives with the foster.
+// The placement is active until the cat is brought back, placements of a cat don't overlap
+type Placement struct {
+	ID        uuid.UUID  `bson:"_id"`
+	CatID     uuid.UUID  `bson:"cat_id"`
+	FosterID  uuid.UUID  `bson:"foster_id"`
+	StartedAt time.Time  `bson:"started_at"`
+	EndedAt   *time.Time `bson:"ended_at"`
+	Notes     string     `bson:"notes"`
+}
+
+// Active reports whether the cat is still with the foster
+func (p *Placement) Active() bool {
+	return p.EndedAt == nil
+}
@@ -0,0 +1,78 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Intake types, how the cat came in
+const (
+	IntakeStray          = "stray"
+	IntakeOwnerSurrender = "owner_surrender"
+	IntakeTransfer       = "transfer"
+	IntakeBornInCare     = "born_in_care"
+)
+
+// Outcome types, how the cat left
+const (
+	OutcomeAdoption      = "adoption"
+	OutcomeReturnToOwner = "return_to_owner"
+	OutcomeTransfer      = "transfer"
+	OutcomeDeath         = "death"
+)
+
+// Intake is a record of the cat coming into care.
+// The intake is open until the outcome of the stay is recorded, a cat has one open intake at most
+type Intake struct {
+	ID       uuid.UUID `bson:"_id"`
+	Type     string    `bson:"type"`
+	Date     time.Time `bson:"date"`
+	Location string    `bson:"location"`
+	Notes    string    `bson:"notes"`
+	Outcome  *Outcome  `bson:"outcome"`
+}
+
+// Outcome is a record of the cat leaving care which closes the intake
+type Outcome struct {
+	Type     string    `bson:"type"`
+	Date     time.Time `bson:"date"`
+	Location string    `bson:"location"`
+	Notes    string    `bson:"notes"`
+}
+
+// Open reports whether the cat is still in care after the intake
+func (i *Intake) Open() bool {
+	return i.Outcome == nil
+}
+
+// OpenIntake returns the open intake, nil if the cat is not in care
+func OpenIntake(intakes []*Intake) *Intake {
+	for _, intake := range intakes {
+		if intake.Open() {
+			return intake
+		}
+	}
+
+	return nil
+}
+
+// IsIntakeType reports whether the intake type is known
+func IsIntakeType(intakeType string) bool {
+	switch intakeType {
+	case IntakeStray, IntakeOwnerSurrender, IntakeTransfer, IntakeBornInCare:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsOutcomeType reports whether the outcome type is known
+func IsOutcomeType(outcomeType string) bool {
+	switch outcomeType {
+	case OutcomeAdoption, OutcomeReturnToOwner, OutcomeTransfer, OutcomeDeath:
+		return true
+	default:
+		return false
+	}
+}
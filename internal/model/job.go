@@ -0,0 +1,35 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Job run statuses
+const (
+	JobSucceeded = "succeeded"
+	JobFailed    = "failed"
+)
+
+// JobRun is one run of a scheduled job on one of the replicas
+type JobRun struct {
+	ID          uuid.UUID
+	Job         string
+	Owner       string
+	ScheduledAt time.Time
+	StartedAt   time.Time
+	FinishedAt  time.Time
+	Status      string
+	Result      string
+	Error       string
+}
+
+// JobStatus describes the scheduled job with its latest runs, the last run goes first
+type JobStatus struct {
+	Name     string
+	Schedule string
+	NextRun  time.Time
+	Running  bool
+	Runs     []*JobRun
+}
@@ -0,0 +1,71 @@
+package model
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Lengths of the microchip numbers in the common formats
+const (
+	// MicrochipAVIDLength is the length of the 9-digit AVID number
+	MicrochipAVIDLength = 9
+	// MicrochipFDXALength is the length of the 10-character hexadecimal FDX-A number
+	MicrochipFDXALength = 10
+	// MicrochipISOLength is the length of the 15-digit ISO 11784 number
+	MicrochipISOLength = 15
+)
+
+// ISO 11784 limits, the number is 3 digits of the country or manufacturer code
+// followed by 12 digits of the national ID kept in 38 bits
+const (
+	isoMaxNationalID = 1<<38 - 1
+	isoTestCode      = 999
+)
+
+// NormalizeMicrochip removes the separators scanners and people put into the chip number
+// and converts hexadecimal numbers to upper case.
+// It reports false for the number which is none of the 9, 10 and 15-character formats
+// or breaks the ISO 11784 rules
+func NormalizeMicrochip(chip string) (string, bool) {
+	chip = strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '.', '*', ':', '\t':
+			return -1
+		}
+		return r
+	}, strings.ToUpper(strings.TrimSpace(chip)))
+
+	switch len(chip) {
+	case MicrochipAVIDLength:
+		return chip, isDigits(chip)
+	case MicrochipFDXALength:
+		_, err := strconv.ParseUint(chip, 16, 64)
+		return chip, err == nil
+	case MicrochipISOLength:
+		return chip, isDigits(chip) && validISO11784(chip)
+	default:
+		return chip, false
+	}
+}
+
+// validISO11784 checks the code and the national ID of the 15-digit number.
+// Code 000 is not assigned and 999 is reserved for test transponders
+func validISO11784(chip string) bool {
+	code, err := strconv.Atoi(chip[:3])
+	if err != nil || code == 0 || code == isoTestCode {
+		return false
+	}
+	nationalID, err := strconv.ParseUint(chip[3:], 10, 64)
+
+	return err == nil && nationalID <= isoMaxNationalID
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,30 @@
+package model
+
+import "time"
+
+// Migration states
+const (
+	MigrationApplied  = "applied"
+	MigrationPending  = "pending"
+	MigrationBaseline = "baseline"
+	MigrationMissing  = "missing"
+)
+
+// Migration is the versioned schema change with the script undoing it
+type Migration struct {
+	Version     int
+	Description string
+	Script      string
+	Checksum    int32
+	SQL         string
+	UndoSQL     string
+}
+
+// MigrationStatus is the state of the migration in the database.
+// Baseline migrations are below the baseline version, missing ones are applied but not known to the binary
+type MigrationStatus struct {
+	Version     int
+	Description string
+	State       string
+	InstalledOn *time.Time
+}
@@ -0,0 +1,67 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Actions of the cache stream messages besides the adoption transitions, which publish the cat state like create
+const (
+	OutboxCreate = "create"
+	OutboxDelete = "delete"
+)
+
+// OutboxMessage is the cache event written in the same transaction as the cat change.
+// The relay publishes it to the cache stream later, so the event isn't lost if the process stops
+// right after the change. Payload is the cat JSON or the ID of the deleted cat.
+// DeadAt is set when the relay gave the message up after too many failed attempts
+type OutboxMessage struct {
+	ID        uuid.UUID  `bson:"_id"`
+	Action    string     `bson:"action"`
+	Payload   string     `bson:"payload"`
+	CreatedAt time.Time  `bson:"created_at"`
+	Attempts  int        `bson:"attempts"`
+	LastError string     `bson:"last_error"`
+	SentAt    *time.Time `bson:"sent_at"`
+	DeadAt    *time.Time `bson:"dead_at"`
+}
+
+// NewCatMessage returns the message publishing the cat state after the change made by the action
+func NewCatMessage(action string, cat *Cat) (*OutboxMessage, error) {
+	payload, err := cat.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	return &OutboxMessage{ID: uuid.New(), Action: action, Payload: string(payload)}, nil
+}
+
+// NewCatMessages returns the messages publishing the states of the created or changed cats
+func NewCatMessages(cats ...*Cat) ([]*OutboxMessage, error) {
+	messages := make([]*OutboxMessage, 0, len(cats))
+	for _, cat := range cats {
+		message, err := NewCatMessage(OutboxCreate, cat)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, message)
+	}
+
+	return messages, nil
+}
+
+// NewDeleteMessage returns the message removing the deleted cat from the cache
+func NewDeleteMessage(id uuid.UUID) *OutboxMessage {
+	return &OutboxMessage{ID: uuid.New(), Action: OutboxDelete, Payload: id.String()}
+}
+
+// PatchAction returns the action of the message about the patched cat,
+// the status is changed by the adoption transitions only
+func PatchAction(patch *CatPatch) string {
+	if patch.Status != nil && TransitionTo(*patch.Status) != "" {
+		return TransitionTo(*patch.Status)
+	}
+
+	return OutboxCreate
+}
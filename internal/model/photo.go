@@ -0,0 +1,26 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Photo sizes, original is the uploaded file, others are generated from it
+const (
+	PhotoOriginal  = "original"
+	PhotoMedium    = "medium"
+	PhotoThumbnail = "thumbnail"
+)
+
+// Photo describes the uploaded photo of a cat, the images are kept in the blob store.
+// URLs of the sizes are filled when the photo is returned to the client
+type Photo struct {
+	ID          uuid.UUID         `bson:"_id"`
+	ContentType string            `bson:"content_type"`
+	Size        int64             `bson:"size"`
+	Width       int               `bson:"width"`
+	Height      int               `bson:"height"`
+	CreatedAt   time.Time         `bson:"created_at"`
+	URLs        map[string]string `bson:"-"`
+}
@@ -0,0 +1,18 @@
+package model
+
+import "github.com/google/uuid"
+
+// Shelter is a place cats live in until adoption
+type Shelter struct {
+	ID       uuid.UUID `bson:"_id"`
+	Name     string    `bson:"name"`
+	Address  string    `bson:"address"`
+	Capacity int       `bson:"capacity"`
+	Contact  string    `bson:"contact"`
+}
+
+// ShelterPage is a part of the shelter list with a cursor to the next part
+type ShelterPage struct {
+	Shelters   []*Shelter
+	NextCursor string
+}
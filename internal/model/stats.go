@@ -0,0 +1,75 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// StatsFilter selects cats for the stats.
+// The date range applies to the intake date of the cat profile and to the date of the intake records,
+// From is inclusive and To is exclusive
+type StatsFilter struct {
+	ShelterID *uuid.UUID
+	From      *time.Time
+	To        *time.Time
+}
+
+// AgeCount is the number of cats in the age bucket, the bucket holds ages from MinAge to the next bucket
+type AgeCount struct {
+	Ages   string
+	MinAge int
+	Count  int64
+}
+
+// CatStats are the numbers of not deleted cats and of their finished stays.
+// Stay lengths are counted in days from the intake to the outcome
+type CatStats struct {
+	Total           int64
+	ByStatus        map[string]int64
+	Vaccinated      int64
+	VaccinatedRatio float64
+	Ages            []*AgeCount
+	Stays           int64
+	AverageStayDays float64
+	MedianStayDays  float64
+}
+
+// NewCatStats returns stats with zero counts of all statuses and age buckets
+func NewCatStats() *CatStats {
+	return &CatStats{
+		ByStatus: map[string]int64{CatAvailable: 0, CatReserved: 0, CatAdopted: 0, CatReturned: 0},
+		Ages: []*AgeCount{
+			{Ages: "0", MinAge: 0},
+			{Ages: "1-2", MinAge: 1},
+			{Ages: "3-6", MinAge: 3},
+			{Ages: "7-10", MinAge: 7},
+			{Ages: "11+", MinAge: 11},
+		},
+	}
+}
+
+// Add counts the group of cats with the same status, vaccination and age bucket index
+func (s *CatStats) Add(status string, vaccinated bool, bucket int, count int64) {
+	s.Total += count
+	s.ByStatus[StatusOrDefault(status)] += count
+	if vaccinated {
+		s.Vaccinated += count
+	}
+	if bucket >= 0 && bucket < len(s.Ages) {
+		s.Ages[bucket].Count += count
+	}
+}
+
+// Median returns the median of the sorted values, the mean of the two middle values for even number of them
+func Median(sorted []float64) float64 {
+	n := len(sorted)
+	switch {
+	case n == 0:
+		return 0
+	case n%2 == 1:
+		return sorted[n/2]
+	default:
+		return (sorted[n/2-1] + sorted[n/2]) / 2
+	}
+}
@@ -0,0 +1,58 @@
+package model
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Known vaccines, names are compared case-insensitively
+const (
+	VaccineFVRCP  = "FVRCP"
+	VaccineRabies = "rabies"
+	VaccineFeLV   = "FeLV"
+)
+
+// Vaccination is a record of one vaccine given to a cat.
+// Nil ExpiresAt means the vaccine doesn't expire
+type Vaccination struct {
+	ID             uuid.UUID  `bson:"_id"`
+	Vaccine        string     `bson:"vaccine"`
+	AdministeredAt time.Time  `bson:"administered_at"`
+	Lot            string     `bson:"lot"`
+	Vet            string     `bson:"vet"`
+	ExpiresAt      *time.Time `bson:"expires_at"`
+}
+
+// ActiveAt reports whether the vaccine protects the cat at the given time
+func (v *Vaccination) ActiveAt(now time.Time) bool {
+	return !v.AdministeredAt.After(now) && (v.ExpiresAt == nil || v.ExpiresAt.After(now))
+}
+
+// UpToDate reports whether the cat has active records of all core vaccines, FVRCP and rabies.
+// Other vaccines are kept for the record but don't affect the result
+func UpToDate(vaccinations []*Vaccination, now time.Time) bool {
+	fvrcp, rabies := false, false
+	for _, vaccination := range vaccinations {
+		if !vaccination.ActiveAt(now) {
+			continue
+		}
+		switch {
+		case strings.EqualFold(vaccination.Vaccine, VaccineFVRCP):
+			fvrcp = true
+		case strings.EqualFold(vaccination.Vaccine, VaccineRabies):
+			rabies = true
+		}
+	}
+
+	return fvrcp && rabies
+}
+
+// VaccinationDue is the latest record of the vaccine which expires soon or has already expired
+type VaccinationDue struct {
+	CatID       uuid.UUID  `bson:"cat_id"`
+	CatName     string     `bson:"cat_name"`
+	ShelterID   *uuid.UUID `bson:"shelter_id"`
+	Vaccination `bson:",inline"`
+}
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/catService/internal/model"
+
+	"github.com/google/uuid"
+)
+
+// AdopterMemoryRepository keeps adopters in the memory store
+type AdopterMemoryRepository struct {
+	store *MemoryStore
+}
+
+// NewAdopterMemory create new instance
+func NewAdopterMemory(store *MemoryStore) *AdopterMemoryRepository {
+	return &AdopterMemoryRepository{store: store}
+}
+
+// Get returns adopter
+func (r *AdopterMemoryRepository) Get(ctx context.Context, id uuid.UUID) (*model.Adopter, error) {
+	r.store.mutex.RLock()
+	defer r.store.mutex.RUnlock()
+
+	adopter, exist := r.store.data.Adopters[id]
+	if !exist {
+		return nil, fmt.Errorf("get method error %w", model.ErrAdopterNotFound)
+	}
+	copied := *adopter
+
+	return &copied, nil
+}
+
+// Create new adopter in the store
+func (r *AdopterMemoryRepository) Create(ctx context.Context, adopter *model.Adopter) error {
+	r.store.mutex.Lock()
+	defer r.store.mutex.Unlock()
+
+	if _, exist := r.store.data.Adopters[adopter.ID]; exist {
+		return fmt.Errorf("create method error %w: adopter %s already exists", model.ErrConflict, adopter.ID)
+	}
+	copied := *adopter
+	r.store.data.Adopters[adopter.ID] = &copied
+
+	return nil
+}
+
+// Update states for adopter
+func (r *AdopterMemoryRepository) Update(ctx context.Context, adopter *model.Adopter) error {
+	r.store.mutex.Lock()
+	defer r.store.mutex.Unlock()
+
+	if _, exist := r.store.data.Adopters[adopter.ID]; !exist {
+		return fmt.Errorf("update method error %w", model.ErrAdopterNotFound)
+	}
+	copied := *adopter
+	r.store.data.Adopters[adopter.ID] = &copied
+
+	return nil
+}
+
+// Delete removes adopter
+func (r *AdopterMemoryRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.store.mutex.Lock()
+	defer r.store.mutex.Unlock()
+
+	if _, exist := r.store.data.Adopters[id]; !exist {
+		return fmt.Errorf("delete method error %w", model.ErrAdopterNotFound)
+	}
+	delete(r.store.data.Adopters, id)
+
+	return nil
+}
+
+// List returns adopters in id order starting after the given one
+func (r *AdopterMemoryRepository) List(ctx context.Context, after *uuid.UUID, limit int) ([]*model.Adopter, error) {
+	r.store.mutex.RLock()
+	defer r.store.mutex.RUnlock()
+
+	ids := make([]uuid.UUID, 0, len(r.store.data.Adopters))
+	for id := range r.store.data.Adopters {
+		ids = append(ids, id)
+	}
+	ids = pageIDs(ids, after, limit)
+	adopters := make([]*model.Adopter, 0, len(ids))
+	for _, id := range ids {
+		copied := *r.store.data.Adopters[id]
+		adopters = append(adopters, &copied)
+	}
+
+	return adopters, nil
+}
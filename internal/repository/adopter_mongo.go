@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/catService/internal/model"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// AdopterMongoRepository contains a link to the mongo database
+type AdopterMongoRepository struct {
+	db *mongo.Database
+}
+
+// NewAdopterMongo create new instance
+func NewAdopterMongo(database *mongo.Database) *AdopterMongoRepository {
+	return &AdopterMongoRepository{db: database}
+}
+
+// Get returns adopter
+func (c *AdopterMongoRepository) Get(ctx context.Context, id uuid.UUID) (*model.Adopter, error) {
+	adopter := model.Adopter{}
+	err := c.db.Collection("adopter").FindOne(ctx, bson.M{"_id": id}).Decode(&adopter)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, fmt.Errorf("get method error %w", model.ErrAdopterNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get method error %w", err)
+	}
+
+	return &adopter, nil
+}
+
+// Create new adopter in db
+func (c *AdopterMongoRepository) Create(ctx context.Context, adopter *model.Adopter) error {
+	_, err := c.db.Collection("adopter").InsertOne(ctx, adopter)
+	if err != nil {
+		return fmt.Errorf("create method error %w", mongoError(err))
+	}
+
+	return nil
+}
+
+// Update states for adopter
+func (c *AdopterMongoRepository) Update(ctx context.Context, adopter *model.Adopter) error {
+	result, err := c.db.Collection("adopter").ReplaceOne(ctx, bson.M{"_id": adopter.ID}, adopter)
+	if err != nil {
+		return fmt.Errorf("update method error %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("update method error %w", model.ErrAdopterNotFound)
+	}
+
+	return nil
+}
+
+// Delete removes adopter
+func (c *AdopterMongoRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result, err := c.db.Collection("adopter").DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return fmt.Errorf("delete method error %w", err)
+	}
+	if result.DeletedCount == 0 {
+		return fmt.Errorf("delete method error %w", model.ErrAdopterNotFound)
+	}
+
+	return nil
+}
+
+// List returns adopters in id order starting after the given one
+func (c *AdopterMongoRepository) List(ctx context.Context, after *uuid.UUID, limit int) ([]*model.Adopter, error) {
+	filter := bson.M{}
+	if after != nil {
+		filter["_id"] = bson.M{"$gt": *after}
+	}
+
+	cursor, err := c.db.Collection("adopter").Find(ctx, filter,
+		options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}).SetLimit(int64(limit)))
+	if err != nil {
+		return nil, fmt.Errorf("list method error %w", err)
+	}
+	adopters := make([]*model.Adopter, 0, limit)
+	if err := cursor.All(ctx, &adopters); err != nil {
+		return nil, fmt.Errorf("failed decode adopters from DB %w", err)
+	}
+
+	return adopters, nil
+}
@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/catService/internal/model"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// adopterColumns are selected in the order scanAdopter reads them
+const adopterColumns = "id, name, email, phone, address, " +
+	"household_adults, household_children, household_other_pets, household_home_type, " +
+	"preferred_min_age, preferred_max_age, preferred_sex, preferred_vaccinated_only, preferred_good_with_kids"
+
+// AdopterPostgresRepository contains a link to the connection to db
+type AdopterPostgresRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewAdopterPostgres create new instance
+func NewAdopterPostgres(pool *pgxpool.Pool) *AdopterPostgresRepository {
+	return &AdopterPostgresRepository{db: pool}
+}
+
+// Get returns adopter
+func (r *AdopterPostgresRepository) Get(ctx context.Context, id uuid.UUID) (*model.Adopter, error) {
+	row := r.db.QueryRow(ctx, "SELECT "+adopterColumns+" FROM adopters WHERE id = $1", id)
+
+	adopter, err := scanAdopter(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("get method error %w", model.ErrAdopterNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get method error %w", err)
+	}
+
+	return adopter, nil
+}
+
+// Create new adopter in db
+func (r *AdopterPostgresRepository) Create(ctx context.Context, adopter *model.Adopter) error {
+	_, err := r.db.Exec(ctx, "INSERT INTO adopters("+adopterColumns+") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14)",
+		adopterValues(adopter)...)
+	if err != nil {
+		return fmt.Errorf("create method error %w", pgError(err))
+	}
+
+	return nil
+}
+
+// Update states for adopter
+func (r *AdopterPostgresRepository) Update(ctx context.Context, adopter *model.Adopter) error {
+	tag, err := r.db.Exec(ctx, `UPDATE adopters SET name=$2, email=$3, phone=$4, address=$5,
+		household_adults=$6, household_children=$7, household_other_pets=$8, household_home_type=$9,
+		preferred_min_age=$10, preferred_max_age=$11, preferred_sex=$12, preferred_vaccinated_only=$13, preferred_good_with_kids=$14
+		WHERE id=$1`, adopterValues(adopter)...)
+	if err != nil {
+		return fmt.Errorf("update method error %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("update method error %w", model.ErrAdopterNotFound)
+	}
+
+	return nil
+}
+
+// Delete removes adopter
+func (r *AdopterPostgresRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	tag, err := r.db.Exec(ctx, "DELETE FROM adopters WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("delete method error %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("delete method error %w", model.ErrAdopterNotFound)
+	}
+
+	return nil
+}
+
+// List returns adopters in id order starting after the given one
+func (r *AdopterPostgresRepository) List(ctx context.Context, after *uuid.UUID, limit int) ([]*model.Adopter, error) {
+	rows, err := r.db.Query(ctx, "SELECT "+adopterColumns+` FROM adopters
+		WHERE ($1::uuid IS NULL OR id > $1) ORDER BY id LIMIT $2`, after, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list method error %w", err)
+	}
+	defer rows.Close()
+
+	adopters := make([]*model.Adopter, 0, limit)
+	for rows.Next() {
+		adopter, err := scanAdopter(rows)
+		if err != nil {
+			return nil, fmt.Errorf("list method error %w", err)
+		}
+		adopters = append(adopters, adopter)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list method error %w", err)
+	}
+
+	return adopters, nil
+}
+
+// scanAdopter reads adopter from the row with adopterColumns
+func scanAdopter(row pgx.Row) (*model.Adopter, error) {
+	adopter := model.Adopter{}
+	household, preferences := &adopter.Household, &adopter.Preferences
+	err := row.Scan(&adopter.ID, &adopter.Name, &adopter.Email, &adopter.Phone, &adopter.Address,
+		&household.Adults, &household.Children, &household.OtherPets, &household.HomeType,
+		&preferences.MinAge, &preferences.MaxAge, &preferences.Sex, &preferences.VaccinatedOnly, &preferences.GoodWithKids)
+	if err != nil {
+		return nil, err
+	}
+
+	return &adopter, nil
+}
+
+// adopterValues returns the adopter fields in the order of adopterColumns
+func adopterValues(adopter *model.Adopter) []interface{} {
+	household, preferences := &adopter.Household, &adopter.Preferences
+	return []interface{}{adopter.ID, adopter.Name, adopter.Email, adopter.Phone, adopter.Address,
+		household.Adults, household.Children, household.OtherPets, household.HomeType,
+		preferences.MinAge, preferences.MaxAge, preferences.Sex, preferences.VaccinatedOnly, preferences.GoodWithKids}
+}
@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/catService/internal/model"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdopters(t *testing.T) {
+	requirePostgres(t)
+	maxAge := 3
+	adopter := &model.Adopter{ID: uuid.New(), Name: "Kate", Email: "kate@example.com",
+		Household:   model.Household{Adults: 2, Children: 1, HomeType: "house"},
+		Preferences: model.AdopterPreferences{MaxAge: &maxAge, VaccinatedOnly: true}}
+	require.NoError(t, adopters.Create(context.Background(), adopter))
+
+	stored, err := adopters.Get(context.Background(), adopter.ID)
+	require.NoError(t, err)
+	require.Equal(t, adopter, stored)
+
+	adopter.Preferences.MaxAge = nil
+	adopter.Preferences.Sex = model.CatFemale
+	require.NoError(t, adopters.Update(context.Background(), adopter))
+	stored, err = adopters.Get(context.Background(), adopter.ID)
+	require.NoError(t, err)
+	require.Nil(t, stored.Preferences.MaxAge)
+	require.Equal(t, model.CatFemale, stored.Preferences.Sex)
+
+	require.NoError(t, adopters.Delete(context.Background(), adopter.ID))
+	_, err = adopters.Get(context.Background(), adopter.ID)
+	require.ErrorIs(t, err, model.ErrAdopterNotFound)
+}
+
+func TestGoodWithKids(t *testing.T) {
+	requirePostgres(t)
+	goodWithKids := false
+	cat := &model.Cat{ID: uuid.New(), Name: "Cat 15", Age: 4, Version: 1,
+		CatProfile: model.CatProfile{GoodWithKids: &goodWithKids}}
+	require.NoError(t, repository.Create(context.Background(), cat))
+
+	stored, err := repository.Get(context.Background(), cat.ID)
+	require.NoError(t, err)
+	require.NotNil(t, stored.GoodWithKids)
+	require.False(t, *stored.GoodWithKids)
+}
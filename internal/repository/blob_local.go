@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/catService/internal/model"
+)
+
+// LocalBlobStore keeps blobs as files in the directory, keys are relative paths
+type LocalBlobStore struct {
+	dir string
+}
+
+// NewLocalBlob create new instance
+func NewLocalBlob(dir string) *LocalBlobStore {
+	return &LocalBlobStore{dir: dir}
+}
+
+// Put writes the blob, the previous blob with the same key is replaced.
+// The blob appears only after it is completely written
+func (s *LocalBlobStore) Put(_ context.Context, key string, data io.Reader) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("put blob %s: %w", key, err)
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("put blob %s: %w", key, err)
+	}
+	defer os.Remove(file.Name())
+	if _, err := io.Copy(file, data); err != nil {
+		file.Close()
+		return fmt.Errorf("put blob %s: %w", key, err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("put blob %s: %w", key, err)
+	}
+	if err := os.Rename(file.Name(), path); err != nil {
+		return fmt.Errorf("put blob %s: %w", key, err)
+	}
+
+	return nil
+}
+
+// Get opens the blob for reading, the caller closes it
+func (s *LocalBlobStore) Get(_ context.Context, key string) (io.ReadCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("get blob %s: %w", key, model.ErrPhotoNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get blob %s: %w", key, err)
+	}
+
+	return file, nil
+}
+
+// Delete removes the blob, missing blob isn't an error
+func (s *LocalBlobStore) Delete(_ context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("delete blob %s: %w", key, err)
+	}
+
+	return nil
+}
+
+// DeletePrefix removes the directory of the prefix with all blobs in it, missing directory isn't an error
+func (s *LocalBlobStore) DeletePrefix(_ context.Context, prefix string) error {
+	path, err := s.path(prefix)
+	if err != nil {
+		return err
+	}
+	if err := os.RemoveAll(path); err != nil {
+		return fmt.Errorf("delete blobs %s: %w", prefix, err)
+	}
+
+	return nil
+}
+
+// path returns the file of the blob, keys leaving the directory are rejected
+func (s *LocalBlobStore) path(key string) (string, error) {
+	clean := filepath.Clean(filepath.FromSlash(key))
+	if clean == "." || filepath.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%w: blob key %q", model.ErrInvalid, key)
+	}
+
+	return filepath.Join(s.dir, clean), nil
+}
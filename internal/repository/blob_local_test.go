@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/catService/internal/model"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalBlobStore(t *testing.T) {
+	blobs := NewLocalBlobStore(t.TempDir())
+	key := "cats/1/photos/2/original"
+
+	require.NoError(t, blobs.Put(context.Background(), key, strings.NewReader("image")))
+	blob, err := blobs.Get(context.Background(), key)
+	require.NoError(t, err)
+	data, err := io.ReadAll(blob)
+	require.NoError(t, err)
+	require.NoError(t, blob.Close())
+	require.Equal(t, "image", string(data))
+
+	require.NoError(t, blobs.Delete(context.Background(), key))
+	require.NoError(t, blobs.Delete(context.Background(), key))
+	_, err = blobs.Get(context.Background(), key)
+	require.ErrorIs(t, err, model.ErrPhotoNotFound)
+
+	err = blobs.Put(context.Background(), "../outside", strings.NewReader("image"))
+	require.ErrorIs(t, err, model.ErrInvalid)
+
+	other := "cats/12/photos/2/original"
+	require.NoError(t, blobs.Put(context.Background(), key, strings.NewReader("image")))
+	require.NoError(t, blobs.Put(context.Background(), other, strings.NewReader("image")))
+	require.NoError(t, blobs.DeletePrefix(context.Background(), "cats/1/"))
+	require.NoError(t, blobs.DeletePrefix(context.Background(), "cats/1/"))
+	_, err = blobs.Get(context.Background(), key)
+	require.ErrorIs(t, err, model.ErrPhotoNotFound)
+	blob, err = blobs.Get(context.Background(), other)
+	require.NoError(t, err)
+	require.NoError(t, blob.Close())
+	require.ErrorIs(t, blobs.DeletePrefix(context.Background(), ".."), model.ErrInvalid)
+}
@@ -6,61 +6,126 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/catService/internal/model"
 
 	"github.com/go-redis/redis/v8"
-	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 )
 
-// CatRedisCache struct
+// catsStream is the redis stream of the cache events, every replica applies them to its cache
+const catsStream = "cats"
+
+// CatRedisCache struct, the cache without redis client applies the published messages itself
 type CatRedisCache struct {
 	client *redis.Client
 	mutex  sync.RWMutex
 	cats   map[string]*model.Cat
+	// chips indexes IDs of the cached cats by microchip
+	chips map[string]string
 }
 
-// NewRedisCache ...
+// Cache stream reading settings
+const (
+	// cacheReadBlock is how long XREAD waits for new entries before the reader checks the context again
+	cacheReadBlock = 5 * time.Second
+	// cacheReadMinBackoff is the pause after the first failed read, it doubles up to cacheReadMaxBackoff
+	cacheReadMinBackoff = 100 * time.Millisecond
+	cacheReadMaxBackoff = 10 * time.Second
+)
+
+// NewRedisCache returns the cache which applies the entries of the cats stream added after its start
 func NewRedisCache(ctx context.Context, client *redis.Client) *CatRedisCache {
 	cats := make(map[string]*model.Cat)
 	cache := CatRedisCache{
 		client: client,
 		cats:   cats,
+		chips:  make(map[string]string),
 	}
-	go func() {
-		for {
-			select {
-			case <-ctx.Done():
-				return
-			default:
-				data, err := client.XRead(ctx, &redis.XReadArgs{
-					Streams: []string{"cats", "$"},
-				}).Result()
-				if err != nil {
-					logrus.Errorf("XREAD result error %v", err)
-				}
-				for _, result := range data {
-					for _, value := range result.Messages {
-						for key, value := range value.Values {
-							err := cache.handleAction(key, value)
-							if err != nil {
-								logrus.Errorf("handle error %v", err)
-							}
-						}
-					}
-				}
+	go cache.consume(ctx)
+	return &cache
+}
+
+// consume applies the stream entries until the context is done. Every read continues after the last
+// applied entry, so the entries added while the previous ones were applied or redis failed aren't skipped
+func (c *CatRedisCache) consume(ctx context.Context) {
+	lastID := ""
+	backoff := cacheReadMinBackoff
+	for ctx.Err() == nil {
+		var err error
+		if lastID == "" {
+			lastID, err = c.streamEnd(ctx)
+		} else {
+			var data []redis.XStream
+			data, err = c.client.XRead(ctx, &redis.XReadArgs{
+				Streams: []string{catsStream, lastID},
+				Block:   cacheReadBlock,
+			}).Result()
+			for _, result := range data {
+				lastID = c.apply(result.Messages, lastID)
+			}
+			if errors.Is(err, redis.Nil) {
+				// nothing was added while the read was blocked
+				err = nil
 			}
 		}
-	}()
-	return &cache
+		if err == nil {
+			backoff = cacheReadMinBackoff
+			continue
+		}
+
+		logrus.Errorf("XREAD result error %v, retry in %s", err, backoff)
+		select {
+		case <-ctx.Done():
+		case <-time.After(backoff):
+		}
+		if backoff *= 2; backoff > cacheReadMaxBackoff {
+			backoff = cacheReadMaxBackoff
+		}
+	}
+}
+
+// streamEnd returns the ID of the last entry of the stream, the cache starts with the entries after it
+func (c *CatRedisCache) streamEnd(ctx context.Context) (string, error) {
+	last, err := c.client.XRevRangeN(ctx, catsStream, "+", "-", 1).Result()
+	if err != nil {
+		return "", err
+	}
+	if len(last) == 0 {
+		return "0-0", nil
+	}
+
+	return last[0].ID, nil
+}
+
+// apply handles the stream entries in order and returns the ID of the last one
+func (c *CatRedisCache) apply(messages []redis.XMessage, lastID string) string {
+	for _, message := range messages {
+		for key, value := range message.Values {
+			if err := c.handleAction(key, value); err != nil {
+				logrus.Errorf("handle error %v", err)
+			}
+		}
+		lastID = message.ID
+	}
+
+	return lastID
+}
+
+// NewProcessCache returns the cache of the single replica without redis
+func NewProcessCache() *CatRedisCache {
+	return &CatRedisCache{
+		cats:  make(map[string]*model.Cat),
+		chips: make(map[string]string),
+	}
 }
 
 func (c *CatRedisCache) handleAction(action string, value interface{}) error {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 	switch action {
-	case "create":
+	case model.OutboxCreate, model.CatReserve, model.CatCancelReservation, model.CatAdopt, model.CatReturn:
 		cat := model.Cat{}
 		val, ok := value.(string)
 		if !ok {
@@ -70,26 +135,35 @@ func (c *CatRedisCache) handleAction(action string, value interface{}) error {
 		if err != nil {
 			return errors.New("cant unmarshal value")
 		}
-		c.cats[cat.ID.String()] = &model.Cat{
-			ID:         cat.ID,
-			Name:       cat.Name,
-			Age:        cat.Age,
-			Vaccinated: cat.Vaccinated,
+		c.forget(cat.ID.String())
+		c.cats[cat.ID.String()] = &cat
+		if cat.Microchip != nil {
+			c.chips[*cat.Microchip] = cat.ID.String()
 		}
-	case "delete":
+	case model.OutboxDelete:
 		k, ok := value.(string)
 		if !ok {
 			return errors.New("cast error")
 		}
-		if _, exist := c.cats[k]; !exist {
-			return errors.New("key not found")
-		}
-		delete(c.cats, k)
+		// deleted cat may be absent in the cache if it was never read after start
+		c.forget(k)
 	}
 
 	return nil
 }
 
+// forget removes the cat and its microchip from the cache, the caller holds the lock
+func (c *CatRedisCache) forget(id string) {
+	cat, exist := c.cats[id]
+	if !exist {
+		return
+	}
+	if cat.Microchip != nil && c.chips[*cat.Microchip] == id {
+		delete(c.chips, *cat.Microchip)
+	}
+	delete(c.cats, id)
+}
+
 // Get return cat
 func (c *CatRedisCache) Get(id fmt.Stringer) (*model.Cat, error) {
 	c.mutex.RLock()
@@ -103,39 +177,29 @@ func (c *CatRedisCache) Get(id fmt.Stringer) (*model.Cat, error) {
 	return cat, nil
 }
 
-// Create add new cat in stream
-func (c *CatRedisCache) Create(ctx context.Context, cat *model.Cat) error {
-	c.mutex.Lock()
-	defer c.mutex.Unlock()
+// GetByMicrochip return cat with the normalized microchip number
+func (c *CatRedisCache) GetByMicrochip(chip string) (*model.Cat, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
 
-	marshalCat, err := cat.MarshalBinary()
-	if err != nil {
-		return err
+	id, exist := c.chips[chip]
+	if !exist {
+		return nil, errors.New("cat don't exist")
 	}
 
-	err = c.client.XAdd(ctx, &redis.XAddArgs{
-		Stream: "cats",
-		MaxLen: 0,
-		Values: map[string]interface{}{
-			"create": marshalCat,
-		},
-	}).Err()
-
-	return err
+	return c.cats[id], nil
 }
 
-// Delete add id in stream for removing
-func (c *CatRedisCache) Delete(ctx context.Context, id uuid.UUID) error {
-	c.mutex.Lock()
-	defer c.mutex.Unlock()
-
-	err := c.client.XAdd(ctx, &redis.XAddArgs{
-		Stream: "cats",
+// Publish adds the outbox message to the stream, the action is the key of the stream entry
+func (c *CatRedisCache) Publish(ctx context.Context, message *model.OutboxMessage) error {
+	if c.client == nil {
+		return c.handleAction(message.Action, message.Payload)
+	}
+	return c.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: catsStream,
 		MaxLen: 0,
 		Values: map[string]interface{}{
-			"delete": id,
+			message.Action: message.Payload,
 		},
 	}).Err()
-
-	return err
 }
@@ -0,0 +1,451 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/catService/internal/model"
+
+	"github.com/google/uuid"
+)
+
+// CatMemoryRepository keeps cats in the memory store
+type CatMemoryRepository struct {
+	store *MemoryStore
+}
+
+// NewCatMemory create new instance
+func NewCatMemory(store *MemoryStore) *CatMemoryRepository {
+	return &CatMemoryRepository{store: store}
+}
+
+// Get returns cat
+func (r *CatMemoryRepository) Get(ctx context.Context, id uuid.UUID) (*model.Cat, error) {
+	r.store.mutex.RLock()
+	defer r.store.mutex.RUnlock()
+
+	entry, exist := r.store.data.Cats[id]
+	if !exist || entry.Cat.DeletedAt != nil {
+		return nil, fmt.Errorf("get method error %w", model.ErrCatNotFound)
+	}
+
+	return copyCat(entry.Cat), nil
+}
+
+// GetByMicrochip returns cat with the microchip number
+func (r *CatMemoryRepository) GetByMicrochip(ctx context.Context, chip string) (*model.Cat, error) {
+	r.store.mutex.RLock()
+	defer r.store.mutex.RUnlock()
+
+	for _, entry := range r.store.data.Cats {
+		cat := entry.Cat
+		if cat.DeletedAt == nil && cat.Microchip != nil && *cat.Microchip == chip {
+			return copyCat(cat), nil
+		}
+	}
+
+	return nil, fmt.Errorf("get by microchip method error %w", model.ErrCatNotFound)
+}
+
+// Create new cat in the store
+func (r *CatMemoryRepository) Create(ctx context.Context, cat *model.Cat) error {
+	r.store.mutex.Lock()
+	defer r.store.mutex.Unlock()
+
+	if err := r.checkNew([]*model.Cat{cat}); err != nil {
+		return fmt.Errorf("create method error %w", err)
+	}
+	messages, err := model.NewCatMessages(cat)
+	if err != nil {
+		return fmt.Errorf("create method error %w", err)
+	}
+	r.store.data.Cats[cat.ID] = &memoryCat{Cat: copyCat(cat)}
+	r.store.addOutbox(messages...)
+
+	return nil
+}
+
+// CreateMany saves the batch of cats, none of them is saved if one breaks the constraints
+func (r *CatMemoryRepository) CreateMany(ctx context.Context, cats []*model.Cat) error {
+	r.store.mutex.Lock()
+	defer r.store.mutex.Unlock()
+
+	if err := r.checkNew(cats); err != nil {
+		return fmt.Errorf("create many method error %w", err)
+	}
+	messages, err := model.NewCatMessages(cats...)
+	if err != nil {
+		return fmt.Errorf("create many method error %w", err)
+	}
+	for _, cat := range cats {
+		r.store.data.Cats[cat.ID] = &memoryCat{Cat: copyCat(cat)}
+	}
+	r.store.addOutbox(messages...)
+
+	return nil
+}
+
+// checkNew checks the constraints the database keeps for the new cats. The caller holds the lock
+func (r *CatMemoryRepository) checkNew(cats []*model.Cat) error {
+	ids := make(map[uuid.UUID]bool, len(cats))
+	chips := make(map[string]bool, len(cats))
+	for _, cat := range cats {
+		if _, exist := r.store.data.Cats[cat.ID]; exist || ids[cat.ID] {
+			return fmt.Errorf("%w: cat %s already exists", model.ErrConflict, cat.ID)
+		}
+		ids[cat.ID] = true
+		if cat.Microchip != nil {
+			if chips[*cat.Microchip] {
+				return fmt.Errorf("%w: microchip already registered", model.ErrConflict)
+			}
+			chips[*cat.Microchip] = true
+		}
+		if err := r.checkCat(cat); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkCat checks the microchip is unique among all cats, deleted ones included like in the databases,
+// and the shelter of the cat exists. The caller holds the lock
+func (r *CatMemoryRepository) checkCat(cat *model.Cat) error {
+	if cat.Microchip != nil {
+		for id, entry := range r.store.data.Cats {
+			other := entry.Cat.Microchip
+			if id != cat.ID && other != nil && *other == *cat.Microchip {
+				return fmt.Errorf("%w: microchip already registered", model.ErrConflict)
+			}
+		}
+	}
+	if cat.ShelterID != nil {
+		if _, exist := r.store.data.Shelters[*cat.ShelterID]; !exist {
+			return fmt.Errorf("%w: shelter %s doesn't exist", model.ErrConflict, *cat.ShelterID)
+		}
+	}
+
+	return nil
+}
+
+// Update states for cat, the status and the deleted mark are not changed
+func (r *CatMemoryRepository) Update(ctx context.Context, cat *model.Cat) error {
+	version, err := r.updateVersioned(cat.ID, cat.Version, model.OutboxCreate, func(stored *model.Cat) {
+		stored.Name = cat.Name
+		stored.Age = cat.Age
+		stored.Vaccinated = cat.Vaccinated
+		stored.ShelterID = cat.ShelterID
+		stored.CatProfile = cat.CatProfile
+	})
+	if err != nil {
+		return fmt.Errorf("update method error %w", err)
+	}
+	cat.Version = version
+
+	return nil
+}
+
+// Patch sets only the fields set in the patch
+func (r *CatMemoryRepository) Patch(ctx context.Context, id uuid.UUID, version int64, patch *model.CatPatch) (int64, error) {
+	newVersion, err := r.updateVersioned(id, version, model.PatchAction(patch), patch.Apply)
+	if err != nil {
+		return 0, fmt.Errorf("patch method error %w", err)
+	}
+
+	return newVersion, nil
+}
+
+// Delete marks cat as deleted, it stays in the store until purge
+func (r *CatMemoryRepository) Delete(ctx context.Context, id uuid.UUID, version int64) error {
+	now := time.Now().UTC()
+	_, err := r.updateVersioned(id, version, model.OutboxDelete, func(stored *model.Cat) {
+		stored.DeletedAt = &now
+	})
+	if err != nil {
+		return fmt.Errorf("delete method error %w", err)
+	}
+
+	return nil
+}
+
+// updateVersioned applies the change to the copy of not deleted cat with the expected version,
+// stores the copy with the outbox message of the action if it keeps the constraints and returns the new version
+func (r *CatMemoryRepository) updateVersioned(id uuid.UUID, version int64, action string, change func(*model.Cat)) (int64, error) {
+	r.store.mutex.Lock()
+	defer r.store.mutex.Unlock()
+
+	entry, exist := r.store.data.Cats[id]
+	switch {
+	case !exist || entry.Cat.DeletedAt != nil:
+		return 0, model.ErrCatNotFound
+	case version != model.AnyVersion && entry.Cat.Version != version:
+		return 0, model.ErrVersionMismatch
+	}
+
+	cat := copyCat(entry.Cat)
+	change(cat)
+	if err := r.checkCat(cat); err != nil {
+		return 0, err
+	}
+	cat.Version++
+	message := model.NewDeleteMessage(id)
+	if action != model.OutboxDelete {
+		var err error
+		if message, err = model.NewCatMessage(action, cat); err != nil {
+			return 0, err
+		}
+	}
+	entry.Cat = cat
+	r.store.addOutbox(message)
+
+	return cat.Version, nil
+}
+
+// Restore removes the deleted mark from cat and returns it
+func (r *CatMemoryRepository) Restore(ctx context.Context, id uuid.UUID, version int64) (*model.Cat, error) {
+	r.store.mutex.Lock()
+	defer r.store.mutex.Unlock()
+
+	entry, exist := r.store.data.Cats[id]
+	switch {
+	case !exist:
+		return nil, fmt.Errorf("restore method error %w", model.ErrCatNotFound)
+	case entry.Cat.DeletedAt == nil:
+		return nil, fmt.Errorf("restore method error %w: cat is not deleted", model.ErrConflict)
+	case version != model.AnyVersion && entry.Cat.Version != version:
+		return nil, fmt.Errorf("restore method error %w", model.ErrVersionMismatch)
+	}
+
+	cat := copyCat(entry.Cat)
+	cat.DeletedAt = nil
+	cat.Version++
+	messages, err := model.NewCatMessages(cat)
+	if err != nil {
+		return nil, fmt.Errorf("restore method error %w", err)
+	}
+	entry.Cat = cat
+	r.store.addOutbox(messages...)
+
+	return copyCat(cat), nil
+}
+
+// Purge permanently removes cats deleted before the given time with their records
+func (r *CatMemoryRepository) Purge(ctx context.Context, before time.Time) ([]uuid.UUID, error) {
+	r.store.mutex.Lock()
+	defer r.store.mutex.Unlock()
+
+	var purged []uuid.UUID
+	for id, entry := range r.store.data.Cats {
+		if entry.Cat.DeletedAt != nil && entry.Cat.DeletedAt.Before(before) {
+			delete(r.store.data.Cats, id)
+			purged = append(purged, id)
+		}
+	}
+
+	return purged, nil
+}
+
+// List returns cats matching the query
+func (r *CatMemoryRepository) List(ctx context.Context, query *model.CatQuery, after *model.Cat) ([]*model.Cat, error) {
+	// descending order is the ascending one reversed, the ID breaks ties in both
+	less := func(a, b *model.Cat) bool {
+		order := compareCats(a, b, query.SortBy)
+		if query.Desc {
+			return order > 0
+		}
+		return order < 0
+	}
+
+	cats := r.matching(&query.CatFilter, func(cat *model.Cat) bool {
+		return after == nil || less(after, cat)
+	})
+	sort.Slice(cats, func(i, j int) bool {
+		return less(cats[i], cats[j])
+	})
+	if len(cats) > query.Limit {
+		cats = cats[:query.Limit]
+	}
+
+	return cats, nil
+}
+
+// Export passes cats matching the filter to fn in id order.
+// Cats are copied before the export starts, so fn may use the repository
+func (r *CatMemoryRepository) Export(ctx context.Context, filter *model.CatFilter, fn func(*model.Cat) error) error {
+	cats := r.matching(filter, nil)
+	sort.Slice(cats, func(i, j int) bool {
+		return compareIDs(cats[i].ID, cats[j].ID) < 0
+	})
+
+	for _, cat := range cats {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("export method error %w", err)
+		}
+		if err := fn(cat); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// matching returns copies of cats matching the filter and the extra condition, nil condition accepts all cats
+func (r *CatMemoryRepository) matching(filter *model.CatFilter, accept func(*model.Cat) bool) []*model.Cat {
+	r.store.mutex.RLock()
+	defer r.store.mutex.RUnlock()
+
+	now := time.Now()
+	cats := make([]*model.Cat, 0)
+	for _, entry := range r.store.data.Cats {
+		if matchCat(entry, filter, now) && (accept == nil || accept(entry.Cat)) {
+			cats = append(cats, copyCat(entry.Cat))
+		}
+	}
+
+	return cats
+}
+
+// matchCat checks the cat with its records against the filter
+func matchCat(entry *memoryCat, filter *model.CatFilter, now time.Time) bool {
+	cat := entry.Cat
+	switch filter.Deleted {
+	case model.DeletedInclude:
+	case model.DeletedOnly:
+		if cat.DeletedAt == nil {
+			return false
+		}
+	default:
+		if cat.DeletedAt != nil {
+			return false
+		}
+	}
+	if filter.Vaccinated != nil && cat.Vaccinated != *filter.Vaccinated {
+		return false
+	}
+	// the age of cats with birth date is compared by the date, the stored age may be outdated
+	if filter.MinAge != nil && !catAgeAtLeast(cat, *filter.MinAge, now) {
+		return false
+	}
+	if filter.MaxAge != nil && catAgeAtLeast(cat, *filter.MaxAge+1, now) {
+		return false
+	}
+	if filter.NamePrefix != "" && !strings.HasPrefix(cat.Name, filter.NamePrefix) {
+		return false
+	}
+	if filter.ShelterID != nil && (cat.ShelterID == nil || *cat.ShelterID != *filter.ShelterID) {
+		return false
+	}
+	if filter.Status != "" && model.StatusOrDefault(cat.Status) != filter.Status {
+		return false
+	}
+	if filter.InFoster != nil && activePlacement(entry.Placements) != *filter.InFoster {
+		return false
+	}
+	if filter.Sex != "" && cat.Sex != filter.Sex {
+		return false
+	}
+	if filter.NotBadWithKids && cat.GoodWithKids != nil && !*cat.GoodWithKids {
+		return false
+	}
+
+	return true
+}
+
+// catAgeAtLeast reports whether the cat is at least age years old
+func catAgeAtLeast(cat *model.Cat, age int, now time.Time) bool {
+	if cat.BirthDate == nil {
+		return cat.Age >= age
+	}
+
+	return !cat.BirthDate.After(model.LatestBirthDate(age, now))
+}
+
+// activePlacement reports whether the cat is with a foster now
+func activePlacement(placements []*model.Placement) bool {
+	for _, placement := range placements {
+		if placement.Active() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// compareCats orders cats by the sort field and then by ID.
+// Names are compared bytewise like in the databases, false goes before true
+func compareCats(a, b *model.Cat, field string) int {
+	order := 0
+	switch field {
+	case model.CatSortName:
+		order = strings.Compare(a.Name, b.Name)
+	case model.CatSortAge:
+		order = a.Age - b.Age
+	case model.CatSortVaccinated:
+		switch {
+		case a.Vaccinated == b.Vaccinated:
+		case b.Vaccinated:
+			order = -1
+		default:
+			order = 1
+		}
+	}
+	if order != 0 {
+		return order
+	}
+
+	return compareIDs(a.ID, b.ID)
+}
+
+// Stats counts not deleted cats and their finished stays
+func (r *CatMemoryRepository) Stats(ctx context.Context, filter *model.StatsFilter) (*model.CatStats, error) {
+	r.store.mutex.RLock()
+	defer r.store.mutex.RUnlock()
+
+	stats := model.NewCatStats()
+	now := time.Now()
+	var days []float64
+	for _, entry := range r.store.data.Cats {
+		cat := entry.Cat
+		if cat.DeletedAt != nil || filter.ShelterID != nil && (cat.ShelterID == nil || *cat.ShelterID != *filter.ShelterID) {
+			continue
+		}
+		if cat.IntakeDate != nil && inStatsRange(*cat.IntakeDate, filter) ||
+			cat.IntakeDate == nil && filter.From == nil && filter.To == nil {
+			// buckets are checked from the oldest like in the databases
+			bucket := 0
+			for i := len(stats.Ages) - 1; i > 0; i-- {
+				if catAgeAtLeast(cat, stats.Ages[i].MinAge, now) {
+					bucket = i
+					break
+				}
+			}
+			stats.Add(cat.Status, cat.Vaccinated, bucket, 1)
+		}
+		for _, intake := range entry.Intakes {
+			if intake.Outcome != nil && inStatsRange(intake.Date, filter) {
+				days = append(days, intake.Outcome.Date.Sub(intake.Date).Hours()/24)
+			}
+		}
+	}
+
+	if len(days) > 0 {
+		sort.Float64s(days)
+		sum := 0.0
+		for _, stay := range days {
+			sum += stay
+		}
+		stats.Stays = int64(len(days))
+		stats.AverageStayDays = sum / float64(len(days))
+		stats.MedianStayDays = model.Median(days)
+	}
+
+	return stats, nil
+}
+
+// inStatsRange reports whether the date is in the range of the filter, From is inclusive and To is exclusive
+func inStatsRange(date time.Time, filter *model.StatsFilter) bool {
+	return (filter.From == nil || !date.Before(*filter.From)) && (filter.To == nil || date.Before(*filter.To))
+}
@@ -3,13 +3,17 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"regexp"
+	"time"
 
 	"github.com/catService/internal/model"
 
 	"github.com/google/uuid"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 // CatMongoRepository contains a link to the connection to db
@@ -22,10 +26,39 @@ func NewCatMongo(collection *mongo.Database) *CatMongoRepository {
 	return &CatMongoRepository{db: collection}
 }
 
+// CreateCatMongoIndexes creates the indexes of the cat collection if they don't exist.
+// Microchip numbers are unique among the cats which have them, deleted cats included like in Postgres.
+// Sorted fields are indexed with the ID, pages go by both of them
+func CreateCatMongoIndexes(ctx context.Context, database *mongo.Database) error {
+	_, err := database.Collection("cat").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "microchip", Value: 1}},
+			Options: options.Index().SetName("microchip_unique").SetUnique(true).
+				SetPartialFilterExpression(bson.M{"microchip": bson.M{"$type": "string"}}),
+		},
+		{Keys: bson.D{{Key: "deleted_at", Value: 1}}, Options: options.Index().SetName("deleted_at")},
+		{Keys: bson.D{{Key: "shelter_id", Value: 1}}, Options: options.Index().SetName("shelter_id")},
+		{Keys: bson.D{{Key: "status", Value: 1}}, Options: options.Index().SetName("status")},
+		{Keys: bson.D{{Key: "name", Value: 1}, {Key: "_id", Value: 1}}, Options: options.Index().SetName("name_id")},
+		{Keys: bson.D{{Key: "age", Value: 1}, {Key: "_id", Value: 1}}, Options: options.Index().SetName("age_id")},
+		{Keys: bson.D{{Key: "placements.foster_id", Value: 1}}, Options: options.Index().SetName("placements_foster_id")},
+		{Keys: bson.D{{Key: "vaccinations.expires_at", Value: 1}}, Options: options.Index().SetName("vaccinations_expires_at")},
+	})
+	if err != nil {
+		return fmt.Errorf("create cat indexes error %w", err)
+	}
+
+	return nil
+}
+
 // Get returns cat
 func (c *CatMongoRepository) Get(ctx context.Context, id uuid.UUID) (*model.Cat, error) {
 	cat := model.Cat{}
-	result := c.db.Collection("cat").FindOne(ctx, bson.M{"_id": id})
+	result := c.db.Collection("cat").FindOne(ctx, bson.M{"_id": id, "deleted_at": nil},
+		options.FindOne().SetProjection(catProjection()))
+	if errors.Is(result.Err(), mongo.ErrNoDocuments) {
+		return nil, fmt.Errorf("get method error %w", model.ErrCatNotFound)
+	}
 	if result.Err() != nil {
 		return nil, fmt.Errorf("get method error %w", result.Err())
 	}
@@ -37,11 +70,55 @@ func (c *CatMongoRepository) Get(ctx context.Context, id uuid.UUID) (*model.Cat,
 	return &cat, nil
 }
 
+// GetByMicrochip returns cat with the microchip number
+func (c *CatMongoRepository) GetByMicrochip(ctx context.Context, chip string) (*model.Cat, error) {
+	cat := model.Cat{}
+	result := c.db.Collection("cat").FindOne(ctx, bson.M{"microchip": chip, "deleted_at": nil},
+		options.FindOne().SetProjection(catProjection()))
+	if errors.Is(result.Err(), mongo.ErrNoDocuments) {
+		return nil, fmt.Errorf("get by microchip method error %w", model.ErrCatNotFound)
+	}
+	if result.Err() != nil {
+		return nil, fmt.Errorf("get by microchip method error %w", result.Err())
+	}
+
+	if err := result.Decode(&cat); err != nil {
+		return nil, fmt.Errorf("failed decode cat from DB %w", err)
+	}
+
+	return &cat, nil
+}
+
 // Create new cat in db
 func (c *CatMongoRepository) Create(ctx context.Context, cat *model.Cat) error {
-	_, err := c.db.Collection("cat").InsertOne(ctx, &cat)
+	err := c.withOutbox(ctx, func(ctx mongo.SessionContext) ([]*model.OutboxMessage, error) {
+		if _, err := c.db.Collection("cat").InsertOne(ctx, &cat); err != nil {
+			return nil, err
+		}
+		return model.NewCatMessages(cat)
+	})
+	if err != nil {
+		return fmt.Errorf("create method error %w", mongoError(err))
+	}
+
+	return nil
+}
+
+// CreateMany inserts cats into db with one request
+func (c *CatMongoRepository) CreateMany(ctx context.Context, cats []*model.Cat) error {
+	documents := make([]interface{}, 0, len(cats))
+	for _, cat := range cats {
+		documents = append(documents, cat)
+	}
+
+	err := c.withOutbox(ctx, func(ctx mongo.SessionContext) ([]*model.OutboxMessage, error) {
+		if _, err := c.db.Collection("cat").InsertMany(ctx, documents); err != nil {
+			return nil, err
+		}
+		return model.NewCatMessages(cats...)
+	})
 	if err != nil {
-		return fmt.Errorf("create method error %w", err)
+		return fmt.Errorf("create many method error %w", mongoError(err))
 	}
 
 	return nil
@@ -49,28 +126,541 @@ func (c *CatMongoRepository) Create(ctx context.Context, cat *model.Cat) error {
 
 // Update states for cat
 func (c *CatMongoRepository) Update(ctx context.Context, cat *model.Cat) error {
-	filter := bson.M{"_id": cat.ID}
-
-	update := bson.M{"name": cat.Name, "age": cat.Age, "vaccinated": cat.Vaccinated}
+	update := catProfileDocument(&cat.CatProfile)
+	update["name"] = cat.Name
+	update["age"] = cat.Age
+	update["vaccinated"] = cat.Vaccinated
+	update["shelter_id"] = cat.ShelterID
 
-	_, err := c.db.Collection("cat").UpdateOne(ctx, filter, bson.M{
-		"$set": update,
+	var updated *model.Cat
+	err := c.withOutbox(ctx, func(ctx mongo.SessionContext) ([]*model.OutboxMessage, error) {
+		var err error
+		if updated, err = c.updateVersioned(ctx, cat.ID, cat.Version, bson.M{"$set": update}); err != nil {
+			return nil, err
+		}
+		return model.NewCatMessages(updated)
 	})
 	if err != nil {
 		return fmt.Errorf("failed to execute update cat query: %w", err)
 	}
+	cat.Version = updated.Version
 
 	return nil
 }
 
-// Delete cat from db
-func (c *CatMongoRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	filter := bson.M{"_id": id}
+// Patch sets only the fields set in the patch
+func (c *CatMongoRepository) Patch(ctx context.Context, id uuid.UUID, version int64, patch *model.CatPatch) (int64, error) {
+	set := bson.M{}
+	if patch.Name != nil {
+		set["name"] = *patch.Name
+	}
+	if patch.Age != nil {
+		set["age"] = *patch.Age
+	}
+	if patch.Vaccinated != nil {
+		set["vaccinated"] = *patch.Vaccinated
+	}
+	if patch.ShelterID != nil {
+		set["shelter_id"] = patch.Shelter()
+	}
+	if patch.Status != nil {
+		set["status"] = *patch.Status
+	}
+	if patch.Profile != nil {
+		for field, value := range catProfileDocument(patch.Profile) {
+			set[field] = value
+		}
+	}
+
+	update := bson.M{}
+	if len(set) > 0 {
+		update["$set"] = set
+	}
+	var patched *model.Cat
+	err := c.withOutbox(ctx, func(ctx mongo.SessionContext) ([]*model.OutboxMessage, error) {
+		var err error
+		if patched, err = c.updateVersioned(ctx, id, version, update); err != nil {
+			return nil, err
+		}
+		message, err := model.NewCatMessage(model.PatchAction(patch), patched)
+		if err != nil {
+			return nil, err
+		}
+		return []*model.OutboxMessage{message}, nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to execute patch cat query: %w", err)
+	}
+
+	return patched.Version, nil
+}
 
-	_, err := c.db.Collection("cat").DeleteOne(ctx, filter)
+// Delete marks cat as deleted, it stays in db until purge
+func (c *CatMongoRepository) Delete(ctx context.Context, id uuid.UUID, version int64) error {
+	err := c.withOutbox(ctx, func(ctx mongo.SessionContext) ([]*model.OutboxMessage, error) {
+		_, err := c.updateVersioned(ctx, id, version, bson.M{"$currentDate": bson.M{"deleted_at": true}})
+		if err != nil {
+			return nil, err
+		}
+		return []*model.OutboxMessage{model.NewDeleteMessage(id)}, nil
+	})
 	if err != nil {
 		return fmt.Errorf("delete method error %w", err)
 	}
 
 	return nil
 }
+
+// Restore removes the deleted mark from cat and returns it
+func (c *CatMongoRepository) Restore(ctx context.Context, id uuid.UUID, version int64) (*model.Cat, error) {
+	filter := bson.M{"_id": id, "deleted_at": bson.M{"$ne": nil}}
+	if version != model.AnyVersion {
+		filter["version"] = version
+	}
+	update := bson.M{"$set": bson.M{"deleted_at": nil}, "$inc": bson.M{"version": 1}}
+
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After).SetProjection(catProjection())
+	cat := model.Cat{}
+	err := c.withOutbox(ctx, func(ctx mongo.SessionContext) ([]*model.OutboxMessage, error) {
+		result := c.db.Collection("cat").FindOneAndUpdate(ctx, filter, update, opts)
+		if errors.Is(result.Err(), mongo.ErrNoDocuments) {
+			return nil, c.restoreMissError(ctx, id)
+		}
+		if result.Err() != nil {
+			return nil, result.Err()
+		}
+		if err := result.Decode(&cat); err != nil {
+			return nil, fmt.Errorf("failed decode cat from DB %w", err)
+		}
+		return model.NewCatMessages(&cat)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("restore method error %w", err)
+	}
+
+	return &cat, nil
+}
+
+// Purge permanently removes cats deleted before the given time.
+// Every cat is removed with the deleted_at condition, so the cat restored meanwhile stays
+func (c *CatMongoRepository) Purge(ctx context.Context, before time.Time) ([]uuid.UUID, error) {
+	filter := bson.M{"deleted_at": bson.M{"$lt": before}}
+	cursor, err := c.db.Collection("cat").Find(ctx, filter, options.Find().SetProjection(bson.M{"_id": 1}))
+	if err != nil {
+		return nil, fmt.Errorf("purge method error %w", err)
+	}
+	var found []struct {
+		ID uuid.UUID `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &found); err != nil {
+		return nil, fmt.Errorf("failed decode cats from DB %w", err)
+	}
+
+	var purged []uuid.UUID
+	for _, cat := range found {
+		result, err := c.db.Collection("cat").DeleteOne(ctx, bson.M{"_id": cat.ID, "deleted_at": filter["deleted_at"]})
+		if err != nil {
+			return purged, fmt.Errorf("purge method error %w", err)
+		}
+		if result.DeletedCount == 1 {
+			purged = append(purged, cat.ID)
+		}
+	}
+
+	return purged, nil
+}
+
+// withOutbox runs the change of cats and writes the cache events it returns into the outbox in one transaction.
+// The transaction is retried on transient errors, so the change must be safe to run again
+func (c *CatMongoRepository) withOutbox(ctx context.Context, change func(ctx mongo.SessionContext) ([]*model.OutboxMessage, error)) error {
+	session, err := c.db.Client().StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(ctx mongo.SessionContext) (interface{}, error) {
+		messages, err := change(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return nil, insertMongoOutbox(ctx, c.db, messages)
+	})
+
+	return err
+}
+
+// updateVersioned applies the update to not deleted cat with the expected version and returns the updated cat
+func (c *CatMongoRepository) updateVersioned(ctx context.Context, id uuid.UUID, version int64, update bson.M) (*model.Cat, error) {
+	update["$inc"] = bson.M{"version": 1}
+	filter := bson.M{"_id": id, "deleted_at": nil}
+	if version != model.AnyVersion {
+		filter["version"] = version
+	}
+	opts := options.FindOneAndUpdate().
+		SetReturnDocument(options.After).
+		SetProjection(catProjection())
+
+	result := c.db.Collection("cat").FindOneAndUpdate(ctx, filter, update, opts)
+	if errors.Is(result.Err(), mongo.ErrNoDocuments) {
+		return nil, c.missError(ctx, id)
+	}
+	if result.Err() != nil {
+		return nil, mongoError(result.Err())
+	}
+
+	var updated model.Cat
+	if err := result.Decode(&updated); err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+// missError explains why a conditional change of not deleted cat matched no documents
+func (c *CatMongoRepository) missError(ctx context.Context, id uuid.UUID) error {
+	count, err := c.db.Collection("cat").CountDocuments(ctx, bson.M{"_id": id, "deleted_at": nil})
+	switch {
+	case err != nil:
+		return err
+	case count > 0:
+		return model.ErrVersionMismatch
+	default:
+		return model.ErrCatNotFound
+	}
+}
+
+// restoreMissError explains why restore matched no documents
+func (c *CatMongoRepository) restoreMissError(ctx context.Context, id uuid.UUID) error {
+	var cat struct {
+		DeletedAt *time.Time `bson:"deleted_at"`
+	}
+	err := c.db.Collection("cat").FindOne(ctx, bson.M{"_id": id}).Decode(&cat)
+	switch {
+	case errors.Is(err, mongo.ErrNoDocuments):
+		return model.ErrCatNotFound
+	case err != nil:
+		return err
+	case cat.DeletedAt == nil:
+		return fmt.Errorf("%w: cat is not deleted", model.ErrConflict)
+	default:
+		return model.ErrVersionMismatch
+	}
+}
+
+// List returns cats matching the query
+func (c *CatMongoRepository) List(ctx context.Context, query *model.CatQuery, after *model.Cat) ([]*model.Cat, error) {
+	filter := catFilterDocument(&query.CatFilter)
+
+	field := catSortField(query.SortBy)
+	operator, direction := "$gt", 1
+	if query.Desc {
+		operator, direction = "$lt", -1
+	}
+	sort := bson.D{{Key: "_id", Value: direction}}
+	if query.SortBy != model.CatSortID {
+		sort = bson.D{{Key: field, Value: direction}, {Key: "_id", Value: direction}}
+	}
+	if after != nil {
+		keyset := bson.M{"_id": bson.M{operator: after.ID}}
+		if query.SortBy != model.CatSortID {
+			value := after.SortKey(query.SortBy)
+			keyset = bson.M{"$or": bson.A{
+				bson.M{field: bson.M{operator: value}},
+				bson.M{field: value, "_id": bson.M{operator: after.ID}},
+			}}
+		}
+		filter = bson.M{"$and": bson.A{filter, keyset}}
+	}
+
+	opts := options.Find().SetSort(sort).SetLimit(int64(query.Limit)).SetProjection(catProjection())
+	cursor, err := c.db.Collection("cat").Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("list method error %w", err)
+	}
+	cats := make([]*model.Cat, 0, query.Limit)
+	if err := cursor.All(ctx, &cats); err != nil {
+		return nil, fmt.Errorf("failed decode cats from DB %w", err)
+	}
+
+	return cats, nil
+}
+
+// Export streams cats matching the filter to fn
+func (c *CatMongoRepository) Export(ctx context.Context, filter *model.CatFilter, fn func(*model.Cat) error) error {
+	cursor, err := c.db.Collection("cat").Find(ctx, catFilterDocument(filter),
+		options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}).SetProjection(catProjection()))
+	if err != nil {
+		return fmt.Errorf("export method error %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var cat model.Cat
+		if err := cursor.Decode(&cat); err != nil {
+			return fmt.Errorf("failed decode cat from DB %w", err)
+		}
+		if err := fn(&cat); err != nil {
+			return err
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return fmt.Errorf("export method error %w", err)
+	}
+
+	return nil
+}
+
+// catFilterDocument builds query document for the filter
+func catFilterDocument(filter *model.CatFilter) bson.M {
+	document := bson.M{}
+	switch filter.Deleted {
+	case model.DeletedInclude:
+	case model.DeletedOnly:
+		document["deleted_at"] = bson.M{"$ne": nil}
+	default:
+		document["deleted_at"] = nil
+	}
+	if filter.Vaccinated != nil {
+		document["vaccinated"] = *filter.Vaccinated
+	}
+	// the age of cats with birth date is compared by the date, the stored age may be outdated
+	var ages bson.A
+	if filter.MinAge != nil {
+		ages = append(ages, bson.M{"$or": bson.A{
+			bson.M{"birth_date": nil, "age": bson.M{"$gte": *filter.MinAge}},
+			bson.M{"birth_date": bson.M{"$lte": model.LatestBirthDate(*filter.MinAge, time.Now())}},
+		}})
+	}
+	if filter.MaxAge != nil {
+		ages = append(ages, bson.M{"$or": bson.A{
+			bson.M{"birth_date": nil, "age": bson.M{"$lte": *filter.MaxAge}},
+			bson.M{"birth_date": bson.M{"$gt": model.LatestBirthDate(*filter.MaxAge+1, time.Now())}},
+		}})
+	}
+	if len(ages) > 0 {
+		document["$and"] = ages
+	}
+	if filter.NamePrefix != "" {
+		document["name"] = bson.M{"$regex": "^" + regexp.QuoteMeta(filter.NamePrefix)}
+	}
+	if filter.ShelterID != nil {
+		document["shelter_id"] = *filter.ShelterID
+	}
+	switch filter.Status {
+	case "":
+	case model.CatAvailable:
+		// cats stored before statuses appeared have no status
+		document["status"] = bson.M{"$in": bson.A{model.CatAvailable, nil}}
+	default:
+		document["status"] = filter.Status
+	}
+	if filter.InFoster != nil {
+		active := bson.M{"$elemMatch": bson.M{"ended_at": nil}}
+		if !*filter.InFoster {
+			active = bson.M{"$not": active}
+		}
+		document["placements"] = active
+	}
+	if filter.Sex != "" {
+		document["sex"] = filter.Sex
+	}
+	if filter.NotBadWithKids {
+		document["good_with_kids"] = bson.M{"$ne": false}
+	}
+
+	return document
+}
+
+// catProjection selects the fields of model.Cat, embedded records of the cat aren't read
+func catProjection() bson.M {
+	return bson.M{
+		"_id": 1, "name": 1, "age": 1, "vaccinated": 1, "shelter_id": 1, "status": 1,
+		"birth_date": 1, "birth_date_estimated": 1, "sex": 1, "breed": 1, "coat_color": 1,
+		"neutered": 1, "intake_date": 1, "microchip": 1, "good_with_kids": 1,
+		"version": 1, "deleted_at": 1,
+	}
+}
+
+// catProfileDocument returns the profile fields to set
+func catProfileDocument(profile *model.CatProfile) bson.M {
+	return bson.M{
+		"birth_date":           profile.BirthDate,
+		"birth_date_estimated": profile.BirthDateEstimated,
+		"sex":                  profile.Sex,
+		"breed":                profile.Breed,
+		"coat_color":           profile.CoatColor,
+		"neutered":             profile.Neutered,
+		"intake_date":          profile.IntakeDate,
+		"microchip":            profile.Microchip,
+		"good_with_kids":       profile.GoodWithKids,
+	}
+}
+
+// catSortField returns the document field for the sort field
+func catSortField(field string) string {
+	switch field {
+	case model.CatSortName, model.CatSortAge, model.CatSortVaccinated:
+		return field
+	default:
+		return "_id"
+	}
+}
+
+// mongoDuplicateIndex finds the index name in the duplicate key error message
+var mongoDuplicateIndex = regexp.MustCompile(`index: (\S+) dup key`)
+
+// mongoError translates mongo specific errors into domain errors
+func mongoError(err error) error {
+	if mongo.IsDuplicateKeyError(err) {
+		index := ""
+		if match := mongoDuplicateIndex.FindStringSubmatch(err.Error()); match != nil {
+			index = match[1]
+		}
+		return conflictError(index, conflictExists, err)
+	}
+
+	return err
+}
+
+// Stats counts not deleted cats and their finished stays with aggregation pipelines
+func (c *CatMongoRepository) Stats(ctx context.Context, filter *model.StatsFilter) (*model.CatStats, error) {
+	stats := model.NewCatStats()
+
+	match := bson.M{"deleted_at": nil}
+	if filter.ShelterID != nil {
+		match["shelter_id"] = *filter.ShelterID
+	}
+	if dates := statsDateRange(filter); len(dates) > 0 {
+		match["intake_date"] = dates
+	}
+	// buckets are checked from the oldest, the age of cats with birth date is compared by the date like in the filter
+	now := time.Now()
+	birthDate := bson.M{"$ifNull": bson.A{"$birth_date", nil}}
+	branches := make(bson.A, 0, len(stats.Ages))
+	for i := len(stats.Ages) - 1; i > 0; i-- {
+		branches = append(branches, bson.M{
+			"case": bson.M{"$or": bson.A{
+				bson.M{"$and": bson.A{bson.M{"$eq": bson.A{birthDate, nil}}, bson.M{"$gte": bson.A{"$age", stats.Ages[i].MinAge}}}},
+				bson.M{"$and": bson.A{bson.M{"$ne": bson.A{birthDate, nil}},
+					bson.M{"$lte": bson.A{"$birth_date", model.LatestBirthDate(stats.Ages[i].MinAge, now)}}}},
+			}},
+			"then": i,
+		})
+	}
+	cursor, err := c.db.Collection("cat").Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$group", Value: bson.M{
+			"_id": bson.M{
+				"status":     bson.M{"$ifNull": bson.A{"$status", ""}},
+				"vaccinated": "$vaccinated",
+				"bucket":     bson.M{"$switch": bson.M{"branches": branches, "default": 0}},
+			},
+			"count": bson.M{"$sum": 1},
+		}}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("stats method error %w", err)
+	}
+	var groups []struct {
+		ID struct {
+			Status     string `bson:"status"`
+			Vaccinated bool   `bson:"vaccinated"`
+			Bucket     int    `bson:"bucket"`
+		} `bson:"_id"`
+		Count int64 `bson:"count"`
+	}
+	if err := cursor.All(ctx, &groups); err != nil {
+		return nil, fmt.Errorf("stats method error %w", err)
+	}
+	for _, group := range groups {
+		stats.Add(group.ID.Status, group.ID.Vaccinated, group.ID.Bucket, group.Count)
+	}
+
+	if err := c.stayStats(ctx, filter, stats); err != nil {
+		return nil, fmt.Errorf("stats method error %w", err)
+	}
+
+	return stats, nil
+}
+
+// stayStats counts finished stays from the embedded intake records,
+// the median is taken from the sorted lengths like percentile_cont does in Postgres.
+// The lengths aren't collected into one document, the middle ones are read after the count is known
+func (c *CatMongoRepository) stayStats(ctx context.Context, filter *model.StatsFilter, stats *model.CatStats) error {
+	match := bson.M{"deleted_at": nil}
+	if filter.ShelterID != nil {
+		match["shelter_id"] = *filter.ShelterID
+	}
+	stayMatch := bson.M{"intakes.outcome": bson.M{"$ne": nil}}
+	if dates := statsDateRange(filter); len(dates) > 0 {
+		stayMatch["intakes.date"] = dates
+	}
+	const dayMilliseconds = 24 * 60 * 60 * 1000
+	stays := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$unwind", Value: "$intakes"}},
+		{{Key: "$match", Value: stayMatch}},
+		{{Key: "$project", Value: bson.M{
+			"days": bson.M{"$divide": bson.A{bson.M{"$subtract": bson.A{"$intakes.outcome.date", "$intakes.date"}}, dayMilliseconds}},
+		}}},
+	}
+
+	cursor, err := c.db.Collection("cat").Aggregate(ctx, append(stays, bson.D{{Key: "$group", Value: bson.M{
+		"_id":     nil,
+		"count":   bson.M{"$sum": 1},
+		"average": bson.M{"$avg": "$days"},
+	}}}))
+	if err != nil {
+		return err
+	}
+	var result []struct {
+		Count   int64   `bson:"count"`
+		Average float64 `bson:"average"`
+	}
+	if err := cursor.All(ctx, &result); err != nil {
+		return err
+	}
+	if len(result) == 0 || result[0].Count == 0 {
+		return nil
+	}
+	stats.Stays = result[0].Count
+	stats.AverageStayDays = result[0].Average
+
+	// one middle length for the odd count, two for the even one
+	middle := 2 - stats.Stays%2
+	cursor, err = c.db.Collection("cat").Aggregate(ctx, append(stays,
+		bson.D{{Key: "$sort", Value: bson.M{"days": 1}}},
+		bson.D{{Key: "$skip", Value: (stats.Stays - 1) / 2}},
+		bson.D{{Key: "$limit", Value: middle}},
+	), options.Aggregate().SetAllowDiskUse(true))
+	if err != nil {
+		return err
+	}
+	var lengths []struct {
+		Days float64 `bson:"days"`
+	}
+	if err := cursor.All(ctx, &lengths); err != nil {
+		return err
+	}
+	days := make([]float64, 0, len(lengths))
+	for _, length := range lengths {
+		days = append(days, length.Days)
+	}
+	stats.MedianStayDays = model.Median(days)
+
+	return nil
+}
+
+// statsDateRange returns the query document of the date range, empty without range
+func statsDateRange(filter *model.StatsFilter) bson.M {
+	dates := bson.M{}
+	if filter.From != nil {
+		dates["$gte"] = *filter.From
+	}
+	if filter.To != nil {
+		dates["$lt"] = *filter.To
+	}
+
+	return dates
+}
@@ -0,0 +1,266 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/catService/internal/model"
+
+	"github.com/google/uuid"
+	"github.com/ory/dockertest/v3"
+	"github.com/ory/dockertest/v3/docker"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// mongoClient is connected to the single node replica set started by TestMain, the repositories need transactions
+var mongoClient *mongo.Client
+
+// startMongo runs mongo as a single node replica set and connects mongoClient to it
+func startMongo(pool *dockertest.Pool) *dockertest.Resource {
+	resource, err := pool.RunWithOptions(&dockertest.RunOptions{
+		Repository: "mongo",
+		Tag:        "5.0",
+		Cmd:        []string{"--replSet", "rs0", "--bind_ip_all"},
+	}, func(config *docker.HostConfig) {
+		config.AutoRemove = true
+		config.RestartPolicy = docker.RestartPolicy{Name: "no"}
+	})
+	if err != nil {
+		logrus.Fatalf("Could not start resource: %s", err)
+	}
+
+	// the member address is known inside the container only, so the client connects to the node directly
+	databaseURL := fmt.Sprintf("mongodb://%s/?directConnection=true", resource.GetHostPort("27017/tcp"))
+	logrus.Info("Connecting to mongo on url: ", databaseURL)
+
+	resource.Expire(120) // Tell docker to hard kill the container in 120 seconds
+	if err = pool.Retry(func() error {
+		client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(databaseURL))
+		if err != nil {
+			return err
+		}
+		err = client.Database("admin").RunCommand(context.Background(), bson.D{{Key: "replSetInitiate", Value: bson.M{
+			"_id":     "rs0",
+			"members": bson.A{bson.M{"_id": 0, "host": "localhost:27017"}},
+		}}}).Err()
+		var commandErr mongo.CommandError
+		// the retry after the initiated replica set isn't ready yet
+		if err != nil && !(errors.As(err, &commandErr) && commandErr.Name == "AlreadyInitialized") {
+			_ = client.Disconnect(context.Background())
+			return err
+		}
+		// the transactions wait until the node becomes primary
+		if err := BootstrapMongo(context.Background(), client.Database("bootstrap")); err != nil {
+			_ = client.Disconnect(context.Background())
+			return err
+		}
+		mongoClient = client
+		return nil
+	}); err != nil {
+		logrus.Fatalf("Could not connect to docker: %s", err.Error())
+	}
+
+	return resource
+}
+
+// requireMongo skips the test if TestMain couldn't start mongo,
+// otherwise it returns the new bootstrapped database which is dropped after the test
+func requireMongo(t *testing.T) *mongo.Database {
+	t.Helper()
+	if mongoClient == nil {
+		t.Skip("mongo isn't available")
+	}
+
+	database := mongoClient.Database("cats_" + strings.ReplaceAll(uuid.NewString(), "-", "")[:16])
+	require.NoError(t, BootstrapMongo(context.Background(), database))
+	t.Cleanup(func() {
+		if err := database.Drop(context.Background()); err != nil {
+			t.Logf("drop mongo database: %s", err)
+		}
+	})
+
+	return database
+}
+
+func TestMongoCats(t *testing.T) {
+	database := requireMongo(t)
+	cats := NewMongoRepository(database)
+
+	chip := "900000000000001"
+	cat := &model.Cat{ID: uuid.New(), Name: "Cat 1", Age: 2, Status: model.CatAvailable, Version: 1, CatProfile: model.CatProfile{Microchip: &chip}}
+	require.NoError(t, cats.Create(context.Background(), cat))
+	other := &model.Cat{ID: uuid.New(), Name: "Cat 2", Age: 1, Status: model.CatAvailable, Version: 1, CatProfile: model.CatProfile{Microchip: &chip}}
+	err := cats.Create(context.Background(), other)
+	require.ErrorIs(t, err, model.ErrConflict)
+	require.Contains(t, err.Error(), "microchip already registered")
+	require.NotContains(t, err.Error(), chip)
+
+	found, err := cats.GetByMicrochip(context.Background(), chip)
+	require.NoError(t, err)
+	require.Equal(t, cat.ID, found.ID)
+
+	_, err = cats.Get(context.Background(), uuid.New())
+	require.ErrorIs(t, err, model.ErrCatNotFound)
+}
+
+func TestMongoList(t *testing.T) {
+	database := requireMongo(t)
+	cats := NewMongoRepository(database)
+
+	prefix := uuid.NewString()
+	for i := 0; i < 3; i++ {
+		created := &model.Cat{ID: uuid.New(), Name: fmt.Sprintf("%s %d", prefix, i), Age: i, Vaccinated: i > 0,
+			Status: model.CatAvailable, Version: 1}
+		require.NoError(t, cats.Create(context.Background(), created))
+	}
+	require.NoError(t, cats.Create(context.Background(), &model.Cat{ID: uuid.New(), Name: "Other", Age: 5,
+		Status: model.CatAvailable, Version: 1}))
+
+	query := &model.CatQuery{
+		CatFilter: model.CatFilter{NamePrefix: prefix},
+		SortBy:    model.CatSortAge,
+		Desc:      true,
+		Limit:     2,
+	}
+	page, err := cats.List(context.Background(), query, nil)
+	require.NoError(t, err)
+	require.Len(t, page, 2)
+	require.Equal(t, 2, page[0].Age)
+	require.Equal(t, 1, page[1].Age)
+
+	// the next page starts after the last cat of the previous one
+	page, err = cats.List(context.Background(), query, page[1])
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	require.Equal(t, 0, page[0].Age)
+
+	vaccinated := false
+	query = &model.CatQuery{CatFilter: model.CatFilter{NamePrefix: prefix, Vaccinated: &vaccinated}, SortBy: model.CatSortID, Limit: 10}
+	page, err = cats.List(context.Background(), query, nil)
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	require.Equal(t, 0, page[0].Age)
+
+	testCandidateFilter(t, NewMongoRepository(requireMongo(t)))
+}
+
+func TestMongoExport(t *testing.T) {
+	database := requireMongo(t)
+	cats := NewMongoRepository(database)
+
+	prefix := uuid.NewString()
+	for i := 0; i < 3; i++ {
+		created := &model.Cat{ID: uuid.New(), Name: fmt.Sprintf("%s %d", prefix, i), Age: i, Status: model.CatAvailable, Version: 1}
+		require.NoError(t, cats.Create(context.Background(), created))
+	}
+
+	var exported []*model.Cat
+	err := cats.Export(context.Background(), &model.CatFilter{NamePrefix: prefix}, func(cat *model.Cat) error {
+		exported = append(exported, cat)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, exported, 3)
+
+	stop := errors.New("stop")
+	err = cats.Export(context.Background(), &model.CatFilter{NamePrefix: prefix}, func(*model.Cat) error { return stop })
+	require.ErrorIs(t, err, stop)
+}
+
+func TestMongoVersionedPatch(t *testing.T) {
+	database := requireMongo(t)
+	cats := NewMongoRepository(database)
+
+	patched := &model.Cat{ID: uuid.New(), Name: "Cat 3", Age: 3, Status: model.CatAvailable, Version: 1}
+	require.NoError(t, cats.Create(context.Background(), patched))
+
+	vaccinated := true
+	version, err := cats.Patch(context.Background(), patched.ID, 1, &model.CatPatch{Vaccinated: &vaccinated})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), version)
+
+	stored, err := cats.Get(context.Background(), patched.ID)
+	require.NoError(t, err)
+	require.True(t, stored.Vaccinated)
+	require.Equal(t, patched.Name, stored.Name)
+	require.Equal(t, int64(2), stored.Version)
+
+	_, err = cats.Patch(context.Background(), patched.ID, 1, &model.CatPatch{Vaccinated: &vaccinated})
+	require.ErrorIs(t, err, model.ErrVersionMismatch)
+	stale := &model.Cat{ID: patched.ID, Name: "Cat 4", Age: 4, Version: 1}
+	require.ErrorIs(t, cats.Update(context.Background(), stale), model.ErrVersionMismatch)
+	require.ErrorIs(t, cats.Delete(context.Background(), patched.ID, 1), model.ErrVersionMismatch)
+
+	_, err = cats.Patch(context.Background(), uuid.New(), model.AnyVersion, &model.CatPatch{Vaccinated: &vaccinated})
+	require.ErrorIs(t, err, model.ErrCatNotFound)
+}
+
+func TestMongoStats(t *testing.T) {
+	database := requireMongo(t)
+	cats := NewMongoRepository(database)
+	intakes := NewIntakeMongoRepository(database)
+
+	shelter := &model.Shelter{ID: uuid.New(), Name: "Stats shelter"}
+	require.NoError(t, NewShelterMongoRepository(database).Create(context.Background(), shelter))
+	intakeDate := time.Date(2021, time.March, 10, 0, 0, 0, 0, time.UTC)
+	kittenBirth := time.Now().UTC().AddDate(0, -3, 0)
+	created := []*model.Cat{
+		{ID: uuid.New(), Name: "Stats 1", Age: 12, Vaccinated: true, ShelterID: &shelter.ID, Status: model.CatAvailable, Version: 1,
+			CatProfile: model.CatProfile{IntakeDate: &intakeDate}},
+		{ID: uuid.New(), Name: "Stats 2", Age: 5, ShelterID: &shelter.ID, Status: model.CatAdopted, Version: 1,
+			CatProfile: model.CatProfile{BirthDate: &kittenBirth, IntakeDate: &intakeDate}},
+		{ID: uuid.New(), Name: "Stats 3", Age: 4, ShelterID: &shelter.ID, Status: model.CatAvailable, Version: 1},
+	}
+	for _, cat := range created {
+		require.NoError(t, cats.Create(context.Background(), cat))
+	}
+	date := intakeDate.Add(time.Hour)
+	// three stays of the odd count and the middle one, the even count is checked after the filter
+	for i, days := range []int{4, 10, 13} {
+		intake := &model.Intake{ID: uuid.New(), Type: model.IntakeStray, Date: date}
+		require.NoError(t, intakes.AddIntake(context.Background(), created[i].ID, intake))
+		outcome := &model.Outcome{Type: model.OutcomeAdoption, Date: date.AddDate(0, 0, days)}
+		require.NoError(t, intakes.CloseIntake(context.Background(), created[i].ID, intake.ID, outcome))
+	}
+	later := &model.Intake{ID: uuid.New(), Type: model.IntakeStray, Date: date.AddDate(0, 1, 0)}
+	require.NoError(t, intakes.AddIntake(context.Background(), created[2].ID, later))
+	outcome := &model.Outcome{Type: model.OutcomeAdoption, Date: later.Date.AddDate(0, 0, 20)}
+	require.NoError(t, intakes.CloseIntake(context.Background(), created[2].ID, later.ID, outcome))
+
+	stats, err := cats.Stats(context.Background(), &model.StatsFilter{ShelterID: &shelter.ID})
+	require.NoError(t, err)
+	require.Equal(t, int64(3), stats.Total)
+	require.Equal(t, int64(2), stats.ByStatus[model.CatAvailable])
+	require.Equal(t, int64(1), stats.Vaccinated)
+	require.Equal(t, int64(1), stats.Ages[0].Count)
+	require.Equal(t, int64(1), stats.Ages[2].Count)
+	require.Equal(t, int64(1), stats.Ages[4].Count)
+	require.Equal(t, int64(4), stats.Stays)
+	require.InDelta(t, 11.75, stats.AverageStayDays, 0.001)
+	require.InDelta(t, 11.5, stats.MedianStayDays, 0.001)
+
+	from, to := intakeDate, intakeDate.AddDate(0, 0, 1)
+	stats, err = cats.Stats(context.Background(), &model.StatsFilter{ShelterID: &shelter.ID, From: &from, To: &to})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), stats.Total)
+	require.Equal(t, int64(3), stats.Stays)
+	require.InDelta(t, 10, stats.MedianStayDays, 0.001)
+
+	stats, err = cats.Stats(context.Background(), &model.StatsFilter{ShelterID: new(uuid.UUID)})
+	require.NoError(t, err)
+	require.Zero(t, stats.Stays)
+	require.Zero(t, stats.MedianStayDays)
+}
+
+func TestMongoPhotoLimit(t *testing.T) {
+	database := requireMongo(t)
+	testPhotoLimit(t, NewMongoRepository(database), NewPhotoMongoRepository(database))
+}
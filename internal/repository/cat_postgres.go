@@ -3,14 +3,34 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/catService/internal/model"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
 )
 
+// SQLSTATE codes of constraint violations
+const (
+	pgUniqueViolation     = "23505"
+	pgForeignKeyViolation = "23503"
+)
+
+// catColumns are selected in the order scanCat reads them
+const catColumns = "id, name, age, vaccinated, shelter_id, status, " + catProfileColumns + ", version, deleted_at"
+
+// catProfileColumns are the columns of model.CatProfile in the order of catProfileValues
+const catProfileColumns = "birth_date, birth_date_estimated, sex, breed, coat_color, neutered, intake_date, microchip, good_with_kids"
+
+// catInsertColumns are the columns written by Create and CreateMany in the order of catInsertValues
+const catInsertColumns = "id, name, age, vaccinated, shelter_id, status, version, deleted_at, " + catProfileColumns
+
 // CatPostgresRepository contains a link to the connection to db
 type CatPostgresRepository struct {
 	db *pgxpool.Pool
@@ -23,31 +43,88 @@ func NewCatPostgres(pool *pgxpool.Pool) *CatPostgresRepository {
 
 // Get returns cat
 func (r *CatPostgresRepository) Get(ctx context.Context, id uuid.UUID) (*model.Cat, error) {
-	cat := model.Cat{}
-	row := r.db.QueryRow(ctx, "SELECT * FROM cats WHERE id = $1", id)
+	row := r.db.QueryRow(ctx, "SELECT "+catColumns+" FROM cats WHERE id = $1 AND deleted_at IS NULL", id)
 
-	err := row.Scan(&cat.ID, &cat.Name, &cat.Age, &cat.Vaccinated)
+	cat, err := scanCat(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("get method error %w", model.ErrCatNotFound)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("get method error %w", err)
 	}
 
-	return &cat, nil
+	return cat, nil
+}
+
+// GetByMicrochip returns cat with the microchip number
+func (r *CatPostgresRepository) GetByMicrochip(ctx context.Context, chip string) (*model.Cat, error) {
+	row := r.db.QueryRow(ctx, "SELECT "+catColumns+" FROM cats WHERE microchip = $1 AND deleted_at IS NULL", chip)
+
+	cat, err := scanCat(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("get by microchip method error %w", model.ErrCatNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get by microchip method error %w", err)
+	}
+
+	return cat, nil
 }
 
 // Create new cat in db
 func (r *CatPostgresRepository) Create(ctx context.Context, cat *model.Cat) error {
-	_, err := r.db.Exec(ctx, "INSERT INTO cats(id, name, age, vaccinated) VALUES ($1,$2,$3,$4)",
-		cat.ID, cat.Name, cat.Age, cat.Vaccinated)
+	err := r.withOutbox(ctx, func(tx pgx.Tx) ([]*model.OutboxMessage, error) {
+		_, err := tx.Exec(ctx, `INSERT INTO cats(`+catInsertColumns+`) VALUES (`+placeholders(catInsertColumns)+`)`,
+			catInsertValues(cat)...)
+		if err != nil {
+			return nil, err
+		}
+		return model.NewCatMessages(cat)
+	})
+	if err != nil {
+		return fmt.Errorf("create method error %w", pgError(err))
+	}
+
+	return nil
+}
+
+// CreateMany copies cats into db in one statement
+func (r *CatPostgresRepository) CreateMany(ctx context.Context, cats []*model.Cat) error {
+	rows := make([][]interface{}, 0, len(cats))
+	for _, cat := range cats {
+		rows = append(rows, catInsertValues(cat))
+	}
+
+	columns := strings.Split(catInsertColumns, ", ")
+	err := r.withOutbox(ctx, func(tx pgx.Tx) ([]*model.OutboxMessage, error) {
+		if _, err := tx.CopyFrom(ctx, pgx.Identifier{"cats"}, columns, pgx.CopyFromRows(rows)); err != nil {
+			return nil, err
+		}
+		return model.NewCatMessages(cats...)
+	})
 	if err != nil {
-		return fmt.Errorf("create method error %w", err)
+		return fmt.Errorf("create many method error %w", pgError(err))
 	}
 
 	return nil
 }
 
-// Delete cat from db
-func (r *CatPostgresRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	_, err := r.db.Exec(ctx, "DELETE FROM cats WHERE id = $1", id)
+// Delete marks cat as deleted, it stays in db until purge
+func (r *CatPostgresRepository) Delete(ctx context.Context, id uuid.UUID, version int64) error {
+	err := r.withOutbox(ctx, func(tx pgx.Tx) ([]*model.OutboxMessage, error) {
+		tag, err := tx.Exec(ctx, `UPDATE cats SET deleted_at = now(), version = version+1
+			WHERE id = $1 AND deleted_at IS NULL AND ($2::bigint = 0 OR version = $2)`, id, version)
+		if err != nil {
+			return nil, err
+		}
+		if tag.RowsAffected() == 0 {
+			return nil, pgx.ErrNoRows
+		}
+		return []*model.OutboxMessage{model.NewDeleteMessage(id)}, nil
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("delete method error %w", r.missError(ctx, id))
+	}
 	if err != nil {
 		return fmt.Errorf("delete method error %w", err)
 	}
@@ -57,10 +134,442 @@ func (r *CatPostgresRepository) Delete(ctx context.Context, id uuid.UUID) error
 
 // Update states for cat
 func (r *CatPostgresRepository) Update(ctx context.Context, cat *model.Cat) error {
-	_, err := r.db.Exec(ctx, "UPDATE cats SET name=$1, age=$2, vaccinated=$3 WHERE id=$4", cat.Name, cat.Age, cat.Vaccinated, cat.ID)
+	args := append([]interface{}{cat.ID, cat.Version, cat.Name, cat.Age, cat.Vaccinated, cat.ShelterID},
+		catProfileValues(&cat.CatProfile)...)
+	var updated *model.Cat
+	err := r.withOutbox(ctx, func(tx pgx.Tx) ([]*model.OutboxMessage, error) {
+		row := tx.QueryRow(ctx, `UPDATE cats SET name=$3, age=$4, vaccinated=$5, shelter_id=$6, `+catProfileAssignments(7)+`,
+			version=version+1 WHERE id=$1 AND deleted_at IS NULL AND ($2::bigint = 0 OR version=$2) RETURNING `+catColumns, args...)
+		var err error
+		if updated, err = scanCat(row); err != nil {
+			return nil, err
+		}
+		return model.NewCatMessages(updated)
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("update method error %w", r.missError(ctx, cat.ID))
+	}
 	if err != nil {
-		return fmt.Errorf("update method error %w", err)
+		return fmt.Errorf("update method error %w", pgError(err))
 	}
+	cat.Version = updated.Version
 
 	return nil
 }
+
+// Patch updates only the columns set in the patch
+func (r *CatPostgresRepository) Patch(ctx context.Context, id uuid.UUID, version int64, patch *model.CatPatch) (int64, error) {
+	columns := []string{"version=version+1"}
+	args := []interface{}{id, version}
+	if patch.Name != nil {
+		args = append(args, *patch.Name)
+		columns = append(columns, fmt.Sprintf("name=$%d", len(args)))
+	}
+	if patch.Age != nil {
+		args = append(args, *patch.Age)
+		columns = append(columns, fmt.Sprintf("age=$%d", len(args)))
+	}
+	if patch.Vaccinated != nil {
+		args = append(args, *patch.Vaccinated)
+		columns = append(columns, fmt.Sprintf("vaccinated=$%d", len(args)))
+	}
+	if patch.ShelterID != nil {
+		args = append(args, patch.Shelter())
+		columns = append(columns, fmt.Sprintf("shelter_id=$%d", len(args)))
+	}
+	if patch.Status != nil {
+		args = append(args, *patch.Status)
+		columns = append(columns, fmt.Sprintf("status=$%d", len(args)))
+	}
+	if patch.Profile != nil {
+		columns = append(columns, catProfileAssignments(len(args)+1))
+		args = append(args, catProfileValues(patch.Profile)...)
+	}
+
+	sql := fmt.Sprintf("UPDATE cats SET %s WHERE id=$1 AND deleted_at IS NULL AND ($2::bigint = 0 OR version=$2) RETURNING %s",
+		strings.Join(columns, ", "), catColumns)
+	var patched *model.Cat
+	err := r.withOutbox(ctx, func(tx pgx.Tx) ([]*model.OutboxMessage, error) {
+		var err error
+		if patched, err = scanCat(tx.QueryRow(ctx, sql, args...)); err != nil {
+			return nil, err
+		}
+		message, err := model.NewCatMessage(model.PatchAction(patch), patched)
+		if err != nil {
+			return nil, err
+		}
+		return []*model.OutboxMessage{message}, nil
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, fmt.Errorf("patch method error %w", r.missError(ctx, id))
+	}
+	if err != nil {
+		return 0, fmt.Errorf("patch method error %w", pgError(err))
+	}
+
+	return patched.Version, nil
+}
+
+// Restore removes the deleted mark from cat and returns it
+func (r *CatPostgresRepository) Restore(ctx context.Context, id uuid.UUID, version int64) (*model.Cat, error) {
+	var cat *model.Cat
+	err := r.withOutbox(ctx, func(tx pgx.Tx) ([]*model.OutboxMessage, error) {
+		row := tx.QueryRow(ctx, `UPDATE cats SET deleted_at = NULL, version = version+1
+			WHERE id = $1 AND deleted_at IS NOT NULL AND ($2::bigint = 0 OR version = $2) RETURNING `+catColumns, id, version)
+		var err error
+		if cat, err = scanCat(row); err != nil {
+			return nil, err
+		}
+		return model.NewCatMessages(cat)
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("restore method error %w", r.restoreMissError(ctx, id))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("restore method error %w", err)
+	}
+
+	return cat, nil
+}
+
+// withOutbox runs the change of cats and writes the cache events it returns into the outbox in one transaction
+func (r *CatPostgresRepository) withOutbox(ctx context.Context, change func(tx pgx.Tx) ([]*model.OutboxMessage, error)) error {
+	return r.db.BeginFunc(ctx, func(tx pgx.Tx) error {
+		messages, err := change(tx)
+		if err != nil {
+			return err
+		}
+		return insertOutbox(ctx, tx, messages)
+	})
+}
+
+// Purge permanently removes cats deleted before the given time
+func (r *CatPostgresRepository) Purge(ctx context.Context, before time.Time) ([]uuid.UUID, error) {
+	rows, err := r.db.Query(ctx, "DELETE FROM cats WHERE deleted_at < $1 RETURNING id", before)
+	if err != nil {
+		return nil, fmt.Errorf("purge method error %w", err)
+	}
+	defer rows.Close()
+
+	var purged []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("purge method error %w", err)
+		}
+		purged = append(purged, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("purge method error %w", err)
+	}
+
+	return purged, nil
+}
+
+// missError explains why a conditional change of not deleted cat matched no rows
+func (r *CatPostgresRepository) missError(ctx context.Context, id uuid.UUID) error {
+	var exists bool
+	err := r.db.QueryRow(ctx, "SELECT EXISTS(SELECT 1 FROM cats WHERE id = $1 AND deleted_at IS NULL)", id).Scan(&exists)
+	switch {
+	case err != nil:
+		return err
+	case exists:
+		return model.ErrVersionMismatch
+	default:
+		return model.ErrCatNotFound
+	}
+}
+
+// restoreMissError explains why restore matched no rows
+func (r *CatPostgresRepository) restoreMissError(ctx context.Context, id uuid.UUID) error {
+	var deletedAt *time.Time
+	err := r.db.QueryRow(ctx, "SELECT deleted_at FROM cats WHERE id = $1", id).Scan(&deletedAt)
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return model.ErrCatNotFound
+	case err != nil:
+		return err
+	case deletedAt == nil:
+		return fmt.Errorf("%w: cat is not deleted", model.ErrConflict)
+	default:
+		return model.ErrVersionMismatch
+	}
+}
+
+// List returns cats matching the query
+func (r *CatPostgresRepository) List(ctx context.Context, query *model.CatQuery, after *model.Cat) ([]*model.Cat, error) {
+	conditions, args := catFilterConditions(&query.CatFilter)
+
+	column := catSortColumn(query.SortBy)
+	operator, direction := ">", "ASC"
+	if query.Desc {
+		operator, direction = "<", "DESC"
+	}
+	if after != nil {
+		if query.SortBy == model.CatSortID {
+			args = append(args, after.ID)
+			conditions = append(conditions, fmt.Sprintf("id %s $%d", operator, len(args)))
+		} else {
+			args = append(args, after.SortKey(query.SortBy), after.ID)
+			conditions = append(conditions, fmt.Sprintf("(%[1]s %[2]s $%[3]d OR (%[1]s = $%[3]d AND id %[2]s $%[4]d))",
+				column, operator, len(args)-1, len(args)))
+		}
+	}
+
+	sql := "SELECT " + catColumns + " FROM cats"
+	if len(conditions) > 0 {
+		sql += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	args = append(args, query.Limit)
+	sql += fmt.Sprintf(" ORDER BY %[1]s %[2]s, id %[2]s LIMIT $%[3]d", column, direction, len(args))
+
+	rows, err := r.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list method error %w", err)
+	}
+	defer rows.Close()
+
+	cats := make([]*model.Cat, 0, query.Limit)
+	for rows.Next() {
+		cat, err := scanCat(rows)
+		if err != nil {
+			return nil, fmt.Errorf("list method error %w", err)
+		}
+		cats = append(cats, cat)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list method error %w", err)
+	}
+
+	return cats, nil
+}
+
+// Export streams cats matching the filter to fn
+func (r *CatPostgresRepository) Export(ctx context.Context, filter *model.CatFilter, fn func(*model.Cat) error) error {
+	conditions, args := catFilterConditions(filter)
+	sql := "SELECT " + catColumns + " FROM cats"
+	if len(conditions) > 0 {
+		sql += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	sql += " ORDER BY id"
+
+	rows, err := r.db.Query(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("export method error %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		cat, err := scanCat(rows)
+		if err != nil {
+			return fmt.Errorf("export method error %w", err)
+		}
+		if err := fn(cat); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("export method error %w", err)
+	}
+
+	return nil
+}
+
+// scanCat reads cat from the row with catColumns
+func scanCat(row pgx.Row) (*model.Cat, error) {
+	cat := model.Cat{}
+	profile := &cat.CatProfile
+	err := row.Scan(&cat.ID, &cat.Name, &cat.Age, &cat.Vaccinated, &cat.ShelterID, &cat.Status,
+		&profile.BirthDate, &profile.BirthDateEstimated, &profile.Sex, &profile.Breed, &profile.CoatColor,
+		&profile.Neutered, &profile.IntakeDate, &profile.Microchip, &profile.GoodWithKids,
+		&cat.Version, &cat.DeletedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cat, nil
+}
+
+// catProfileValues returns the profile fields in the order of catProfileColumns
+func catProfileValues(profile *model.CatProfile) []interface{} {
+	return []interface{}{profile.BirthDate, profile.BirthDateEstimated, profile.Sex, profile.Breed, profile.CoatColor,
+		profile.Neutered, profile.IntakeDate, profile.Microchip, profile.GoodWithKids}
+}
+
+// catInsertValues returns the cat fields in the order of catInsertColumns
+func catInsertValues(cat *model.Cat) []interface{} {
+	return append([]interface{}{cat.ID, cat.Name, cat.Age, cat.Vaccinated, cat.ShelterID, cat.Status, cat.Version, cat.DeletedAt},
+		catProfileValues(&cat.CatProfile)...)
+}
+
+// placeholders returns VALUES list with a parameter for each of the columns
+func placeholders(columns string) string {
+	params := make([]string, len(strings.Split(columns, ", ")))
+	for i := range params {
+		params[i] = fmt.Sprintf("$%d", i+1)
+	}
+
+	return strings.Join(params, ",")
+}
+
+// catProfileAssignments returns SET list of the profile columns with parameters numbered from first
+func catProfileAssignments(first int) string {
+	columns := strings.Split(catProfileColumns, ", ")
+	assignments := make([]string, len(columns))
+	for i, column := range columns {
+		assignments[i] = fmt.Sprintf("%s=$%d", column, first+i)
+	}
+
+	return strings.Join(assignments, ", ")
+}
+
+// catFilterConditions builds WHERE conditions with their arguments
+func catFilterConditions(filter *model.CatFilter) (conditions []string, args []interface{}) {
+	switch filter.Deleted {
+	case model.DeletedInclude:
+	case model.DeletedOnly:
+		conditions = append(conditions, "deleted_at IS NOT NULL")
+	default:
+		conditions = append(conditions, "deleted_at IS NULL")
+	}
+	if filter.Vaccinated != nil {
+		args = append(args, *filter.Vaccinated)
+		conditions = append(conditions, fmt.Sprintf("vaccinated = $%d", len(args)))
+	}
+	// the age of cats with birth date is compared by the date, the stored age may be outdated
+	if filter.MinAge != nil {
+		args = append(args, *filter.MinAge, model.LatestBirthDate(*filter.MinAge, time.Now()))
+		conditions = append(conditions, fmt.Sprintf("(birth_date IS NULL AND age >= $%d OR birth_date <= $%d)", len(args)-1, len(args)))
+	}
+	if filter.MaxAge != nil {
+		args = append(args, *filter.MaxAge, model.LatestBirthDate(*filter.MaxAge+1, time.Now()))
+		conditions = append(conditions, fmt.Sprintf("(birth_date IS NULL AND age <= $%d OR birth_date > $%d)", len(args)-1, len(args)))
+	}
+	if filter.NamePrefix != "" {
+		args = append(args, likePrefix(filter.NamePrefix))
+		conditions = append(conditions, fmt.Sprintf("name LIKE $%d", len(args)))
+	}
+	if filter.ShelterID != nil {
+		args = append(args, *filter.ShelterID)
+		conditions = append(conditions, fmt.Sprintf("shelter_id = $%d", len(args)))
+	}
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
+	}
+	if filter.InFoster != nil {
+		inFoster := "EXISTS (SELECT 1 FROM cat_placements p WHERE p.cat_id = cats.id AND p.ended_at IS NULL)"
+		if !*filter.InFoster {
+			inFoster = "NOT " + inFoster
+		}
+		conditions = append(conditions, inFoster)
+	}
+	if filter.Sex != "" {
+		args = append(args, filter.Sex)
+		conditions = append(conditions, fmt.Sprintf("sex = $%d", len(args)))
+	}
+	if filter.NotBadWithKids {
+		conditions = append(conditions, "good_with_kids IS NOT FALSE")
+	}
+
+	return conditions, args
+}
+
+// catSortColumn returns the column for the sort field.
+// Names are compared bytewise to keep the order the same as in mongo
+func catSortColumn(field string) string {
+	switch field {
+	case model.CatSortName:
+		return `name COLLATE "C"`
+	case model.CatSortAge:
+		return "age"
+	case model.CatSortVaccinated:
+		return "vaccinated"
+	default:
+		return "id"
+	}
+}
+
+// likePrefix escapes LIKE wildcards and returns pattern for the prefix search
+func likePrefix(prefix string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(prefix) + "%"
+}
+
+// pgError translates postgres specific errors into domain errors
+func pgError(err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return err
+	}
+	switch pgErr.Code {
+	case pgUniqueViolation:
+		return conflictError(pgErr.ConstraintName, conflictExists, err)
+	case pgForeignKeyViolation:
+		return conflictError(pgErr.ConstraintName, conflictReferenced, err)
+	default:
+		return err
+	}
+}
+
+// Stats counts not deleted cats and their finished stays with SQL aggregates
+func (r *CatPostgresRepository) Stats(ctx context.Context, filter *model.StatsFilter) (*model.CatStats, error) {
+	stats := model.NewCatStats()
+
+	conditions, args := statsConditions(filter, "shelter_id", "intake_date", []string{"deleted_at IS NULL"})
+	// buckets are checked from the oldest, the age of cats with birth date is compared by the date like in the filter
+	now := time.Now()
+	buckets := make([]string, 0, len(stats.Ages))
+	for i := len(stats.Ages) - 1; i > 0; i-- {
+		args = append(args, stats.Ages[i].MinAge, model.LatestBirthDate(stats.Ages[i].MinAge, now))
+		buckets = append(buckets, fmt.Sprintf("WHEN birth_date IS NULL AND age >= $%d OR birth_date <= $%d THEN %d",
+			len(args)-1, len(args), i))
+	}
+	rows, err := r.db.Query(ctx, fmt.Sprintf(`SELECT status, vaccinated, CASE %s ELSE 0 END AS bucket, count(*)
+		FROM cats WHERE %s GROUP BY status, vaccinated, bucket`, strings.Join(buckets, " "), strings.Join(conditions, " AND ")), args...)
+	if err != nil {
+		return nil, fmt.Errorf("stats method error %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var status string
+		var vaccinated bool
+		var bucket int
+		var count int64
+		if err := rows.Scan(&status, &vaccinated, &bucket, &count); err != nil {
+			return nil, fmt.Errorf("stats method error %w", err)
+		}
+		stats.Add(status, vaccinated, bucket, count)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("stats method error %w", err)
+	}
+
+	conditions, args = statsConditions(filter, "c.shelter_id", "i.date", []string{"c.deleted_at IS NULL", "i.outcome_type IS NOT NULL"})
+	row := r.db.QueryRow(ctx, `SELECT count(*), COALESCE(avg(days), 0), COALESCE(percentile_cont(0.5) WITHIN GROUP (ORDER BY days), 0)
+		FROM (SELECT EXTRACT(EPOCH FROM i.outcome_date - i.date)::float8 / 86400 AS days
+		FROM cat_intakes i JOIN cats c ON c.id = i.cat_id WHERE `+strings.Join(conditions, " AND ")+`) stays`, args...)
+	if err := row.Scan(&stats.Stays, &stats.AverageStayDays, &stats.MedianStayDays); err != nil {
+		return nil, fmt.Errorf("stats method error %w", err)
+	}
+
+	return stats, nil
+}
+
+// statsConditions appends the conditions of the stats filter on the given columns
+func statsConditions(filter *model.StatsFilter, shelterColumn, dateColumn string, conditions []string) ([]string, []interface{}) {
+	var args []interface{}
+	if filter.ShelterID != nil {
+		args = append(args, *filter.ShelterID)
+		conditions = append(conditions, fmt.Sprintf("%s = $%d", shelterColumn, len(args)))
+	}
+	if filter.From != nil {
+		args = append(args, *filter.From)
+		conditions = append(conditions, fmt.Sprintf("%s >= $%d", dateColumn, len(args)))
+	}
+	if filter.To != nil {
+		args = append(args, *filter.To)
+		conditions = append(conditions, fmt.Sprintf("%s < $%d", dateColumn, len(args)))
+	}
+
+	return conditions, args
+}
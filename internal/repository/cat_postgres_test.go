@@ -2,12 +2,15 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
-	"os/exec"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/catService/internal/model"
+	"github.com/catService/migrations"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v4/pgxpool"
@@ -18,7 +21,13 @@ import (
 )
 
 var (
-	repository SheltersCatRepository
+	repository   SheltersCatRepository
+	events       CatEventRepository
+	shelters     ShelterRepository
+	vaccinations VaccinationRepository
+	intakes      IntakeRepository
+	fosters      FosterRepository
+	adopters     AdopterRepository
 )
 
 var cat = &model.Cat{
@@ -30,8 +39,13 @@ var cat = &model.Cat{
 
 func TestMain(m *testing.M) {
 	pool, err := dockertest.NewPool("")
+	if err == nil {
+		err = pool.Client.Ping()
+	}
 	if err != nil {
-		logrus.Fatalf("Could not connect to docker: %s", err)
+		// memory, sqlite and blob store tests run without docker
+		logrus.Warnf("Docker isn't available, postgres and mongo tests are skipped: %s", err)
+		os.Exit(m.Run())
 	}
 
 	// pulls an image, creates a container based on it and runs it
@@ -59,37 +73,88 @@ func TestMain(m *testing.M) {
 
 	resource.Expire(120) // Tell docker to hard kill the container in 120 seconds
 	if err = pool.Retry(func() error {
-		url := fmt.Sprintf(`-url=jdbc:postgresql://%s/catsdb`, hostAndPort)
-		cmd := exec.Command("flyway", url, "-user=postgres", "-password=qwerty", "-locations=filesystem:../../migrations", "migrate")
-		logrus.Info(cmd)
-		flywayError := cmd.Run()
-		if flywayError != nil {
-			logrus.Fatalf("Flyway error: %v", flywayError.Error())
-			return flywayError
+		poolPgx, err := pgxpool.Connect(context.Background(), databaseURL)
+		if err != nil {
+			return err
+		}
+		scripts, err := LoadMigrations(migrations.FS)
+		if err != nil {
+			logrus.Fatalf("Migrations error: %v", err)
+		}
+		if _, err := NewPostgresMigrator(poolPgx, scripts).Up(context.Background()); err != nil {
+			poolPgx.Close()
+			return err
 		}
 
-		poolPgx, _ := pgxpool.Connect(context.Background(), databaseURL)
 		repository = NewPostgresRepository(poolPgx)
+		events = NewEventPostgresRepository(poolPgx)
+		shelters = NewShelterPostgresRepository(poolPgx)
+		vaccinations = NewVaccinationPostgresRepository(poolPgx)
+		intakes = NewIntakePostgresRepository(poolPgx)
+		fosters = NewFosterPostgresRepository(poolPgx)
+		adopters = NewAdopterPostgresRepository(poolPgx)
 		return nil
 	}); err != nil {
 		logrus.Fatalf("Could not connect to docker: %s", err.Error())
 	}
 
+	mongoResource := startMongo(pool)
+
 	code := m.Run()
 
-	if err := pool.Purge(resource); err != nil {
-		logrus.Fatalf("Could not purge resource: %s", err)
+	if err := mongoClient.Disconnect(context.Background()); err != nil {
+		logrus.Errorf("Could not disconnect from mongo: %s", err)
+	}
+	for _, resource := range []*dockertest.Resource{resource, mongoResource} {
+		if err := pool.Purge(resource); err != nil {
+			logrus.Fatalf("Could not purge resource: %s", err)
+		}
 	}
 
 	os.Exit(code)
 }
 
+// requirePostgres skips the test if TestMain couldn't start postgres
+func requirePostgres(t *testing.T) {
+	t.Helper()
+	if repository == nil {
+		t.Skip("postgres isn't available")
+	}
+}
+
 func TestCreate(t *testing.T) {
+	requirePostgres(t)
 	err := repository.Create(context.Background(), cat)
 	require.NoError(t, err)
 }
 
+func TestCreateWithProfile(t *testing.T) {
+	requirePostgres(t)
+	birthDate := time.Date(2019, time.April, 2, 0, 0, 0, 0, time.UTC)
+	intakeDate := time.Date(2021, time.May, 3, 0, 0, 0, 0, time.UTC)
+	neutered, goodWithKids := true, true
+	microchip := uuid.NewString()[:15]
+	created := &model.Cat{ID: uuid.New(), Name: "Cat 16", Age: 3, Vaccinated: true, Status: model.CatAvailable, Version: 1,
+		CatProfile: model.CatProfile{BirthDate: &birthDate, BirthDateEstimated: true, Sex: model.CatFemale, Breed: "maine coon",
+			CoatColor: "tabby", Neutered: &neutered, IntakeDate: &intakeDate, Microchip: &microchip, GoodWithKids: &goodWithKids}}
+	require.NoError(t, repository.Create(context.Background(), created))
+
+	stored, err := repository.Get(context.Background(), created.ID)
+	require.NoError(t, err)
+	require.Equal(t, created.Name, stored.Name)
+	require.Equal(t, created.Status, stored.Status)
+	require.Equal(t, created.Version, stored.Version)
+	require.True(t, created.CatProfile.Equal(&stored.CatProfile))
+}
+
+func TestCatInsertValues(t *testing.T) {
+	columns := strings.Split(catInsertColumns, ", ")
+	require.Len(t, catInsertValues(cat), len(columns))
+	require.Equal(t, len(columns), strings.Count(placeholders(catInsertColumns), "$"))
+}
+
 func TestGet(t *testing.T) {
+	requirePostgres(t)
 	repository.Create(context.Background(), cat)
 	testCat, err := repository.Get(context.Background(), cat.ID)
 	require.NotEmpty(t, testCat)
@@ -101,6 +166,7 @@ func TestGet(t *testing.T) {
 }
 
 func TestGetNonExistingCat(t *testing.T) {
+	requirePostgres(t)
 	var cats = &model.Cat{
 		Name: "Cat 1",
 	}
@@ -110,13 +176,274 @@ func TestGetNonExistingCat(t *testing.T) {
 }
 
 func TestDelete(t *testing.T) {
+	requirePostgres(t)
 	repository.Create(context.Background(), cat)
-	err := repository.Delete(context.Background(), cat.ID)
+	err := repository.Delete(context.Background(), cat.ID, model.AnyVersion)
 	require.NoError(t, err)
 }
 
 func TestUpdate(t *testing.T) {
-	repository.Create(context.Background(), cat)
-	err := repository.Update(context.Background(), cat)
+	requirePostgres(t)
+	// the shared cat may be already deleted, deleted cats can't be updated
+	updated := &model.Cat{ID: uuid.New(), Name: "Cat 1", Age: 2, Version: 1}
+	repository.Create(context.Background(), updated)
+	err := repository.Update(context.Background(), updated)
+	require.NoError(t, err)
+}
+
+func TestGetNonExistingCatNotFound(t *testing.T) {
+	requirePostgres(t)
+	_, err := repository.Get(context.Background(), uuid.New())
+	require.ErrorIs(t, err, model.ErrCatNotFound)
+}
+
+func TestUpdateNonExistingCat(t *testing.T) {
+	requirePostgres(t)
+	err := repository.Update(context.Background(), &model.Cat{ID: uuid.New(), Name: "Cat 2", Age: 1})
+	require.ErrorIs(t, err, model.ErrCatNotFound)
+}
+
+func TestDeleteNonExistingCat(t *testing.T) {
+	requirePostgres(t)
+	err := repository.Delete(context.Background(), uuid.New(), model.AnyVersion)
+	require.ErrorIs(t, err, model.ErrCatNotFound)
+}
+
+func TestList(t *testing.T) {
+	requirePostgres(t)
+	prefix := uuid.NewString()
+	for i := 0; i < 3; i++ {
+		err := repository.Create(context.Background(), &model.Cat{ID: uuid.New(), Name: fmt.Sprintf("%s %d", prefix, i), Age: i})
+		require.NoError(t, err)
+	}
+
+	query := &model.CatQuery{
+		CatFilter: model.CatFilter{NamePrefix: prefix},
+		SortBy:    model.CatSortAge,
+		Desc:      true,
+		Limit:     2,
+	}
+	cats, err := repository.List(context.Background(), query, nil)
+	require.NoError(t, err)
+	require.Len(t, cats, 2)
+	require.Equal(t, 2, cats[0].Age)
+	require.Equal(t, 1, cats[1].Age)
+
+	cats, err = repository.List(context.Background(), query, cats[1])
+	require.NoError(t, err)
+	require.Len(t, cats, 1)
+	require.Equal(t, 0, cats[0].Age)
+}
+
+func TestPatch(t *testing.T) {
+	requirePostgres(t)
+	patched := &model.Cat{ID: uuid.New(), Name: "Cat 3", Age: 3, Version: 1}
+	require.NoError(t, repository.Create(context.Background(), patched))
+
+	vaccinated := true
+	version, err := repository.Patch(context.Background(), patched.ID, model.AnyVersion, &model.CatPatch{Vaccinated: &vaccinated})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), version)
+
+	testCat, err := repository.Get(context.Background(), patched.ID)
+	require.NoError(t, err)
+	require.True(t, testCat.Vaccinated)
+	require.Equal(t, patched.Name, testCat.Name)
+	require.Equal(t, patched.Age, testCat.Age)
+
+	_, err = repository.Patch(context.Background(), uuid.New(), model.AnyVersion, &model.CatPatch{Vaccinated: &vaccinated})
+	require.ErrorIs(t, err, model.ErrCatNotFound)
+}
+
+func TestVersionMismatch(t *testing.T) {
+	requirePostgres(t)
+	versioned := &model.Cat{ID: uuid.New(), Name: "Cat 4", Age: 4, Version: 1}
+	require.NoError(t, repository.Create(context.Background(), versioned))
+
+	require.NoError(t, repository.Update(context.Background(), versioned))
+	require.Equal(t, int64(2), versioned.Version)
+
+	stale := &model.Cat{ID: versioned.ID, Name: "Cat 5", Age: 5, Version: 1}
+	err := repository.Update(context.Background(), stale)
+	require.ErrorIs(t, err, model.ErrVersionMismatch)
+
+	vaccinated := true
+	_, err = repository.Patch(context.Background(), versioned.ID, 1, &model.CatPatch{Vaccinated: &vaccinated})
+	require.ErrorIs(t, err, model.ErrVersionMismatch)
+
+	err = repository.Delete(context.Background(), versioned.ID, 1)
+	require.ErrorIs(t, err, model.ErrVersionMismatch)
+
+	err = repository.Delete(context.Background(), versioned.ID, versioned.Version)
+	require.NoError(t, err)
+}
+
+func TestSoftDeleteAndRestore(t *testing.T) {
+	requirePostgres(t)
+	deleted := &model.Cat{ID: uuid.New(), Name: "Cat 6", Age: 6, Version: 1}
+	require.NoError(t, repository.Create(context.Background(), deleted))
+
+	_, err := repository.Restore(context.Background(), deleted.ID, model.AnyVersion)
+	require.ErrorIs(t, err, model.ErrConflict)
+
+	require.NoError(t, repository.Delete(context.Background(), deleted.ID, model.AnyVersion))
+	_, err = repository.Get(context.Background(), deleted.ID)
+	require.ErrorIs(t, err, model.ErrCatNotFound)
+
+	query := &model.CatQuery{CatFilter: model.CatFilter{NamePrefix: "Cat 6", Deleted: model.DeletedOnly}, SortBy: model.CatSortID, Limit: 10}
+	cats, err := repository.List(context.Background(), query, nil)
+	require.NoError(t, err)
+	require.Len(t, cats, 1)
+	require.NotNil(t, cats[0].DeletedAt)
+
+	restored, err := repository.Restore(context.Background(), deleted.ID, model.AnyVersion)
+	require.NoError(t, err)
+	require.Nil(t, restored.DeletedAt)
+	require.Equal(t, int64(3), restored.Version)
+}
+
+func TestPurge(t *testing.T) {
+	requirePostgres(t)
+	purged := &model.Cat{ID: uuid.New(), Name: "Cat 7", Age: 7, Version: 1}
+	require.NoError(t, repository.Create(context.Background(), purged))
+	require.NoError(t, repository.Delete(context.Background(), purged.ID, model.AnyVersion))
+
+	ids, err := repository.Purge(context.Background(), time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	require.Contains(t, ids, purged.ID)
+
+	_, err = repository.Restore(context.Background(), purged.ID, model.AnyVersion)
+	require.ErrorIs(t, err, model.ErrCatNotFound)
+}
+
+func TestCreateMany(t *testing.T) {
+	requirePostgres(t)
+	prefix := uuid.NewString()
+	cats := []*model.Cat{
+		{ID: uuid.New(), Name: prefix + " 1", Age: 1, Version: 1},
+		{ID: uuid.New(), Name: prefix + " 2", Age: 2, Vaccinated: true, Version: 1},
+	}
+	require.NoError(t, repository.CreateMany(context.Background(), cats))
+
+	query := &model.CatQuery{CatFilter: model.CatFilter{NamePrefix: prefix}, SortBy: model.CatSortAge, Limit: 10}
+	stored, err := repository.List(context.Background(), query, nil)
+	require.NoError(t, err)
+	require.Len(t, stored, 2)
+	require.True(t, stored[1].Vaccinated)
+
+	err = repository.CreateMany(context.Background(), cats[:1])
+	require.ErrorIs(t, err, model.ErrConflict)
+}
+
+func TestExport(t *testing.T) {
+	requirePostgres(t)
+	prefix := uuid.NewString()
+	for i := 0; i < 3; i++ {
+		err := repository.Create(context.Background(), &model.Cat{ID: uuid.New(), Name: fmt.Sprintf("%s %d", prefix, i), Age: i, Version: 1})
+		require.NoError(t, err)
+	}
+
+	var exported []*model.Cat
+	err := repository.Export(context.Background(), &model.CatFilter{NamePrefix: prefix}, func(cat *model.Cat) error {
+		exported = append(exported, cat)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, exported, 3)
+	require.True(t, exported[0].ID.String() < exported[1].ID.String())
+
+	stop := errors.New("stop")
+	err = repository.Export(context.Background(), &model.CatFilter{NamePrefix: prefix}, func(*model.Cat) error { return stop })
+	require.ErrorIs(t, err, stop)
+}
+
+func TestStatus(t *testing.T) {
+	requirePostgres(t)
+	adopted := &model.Cat{ID: uuid.New(), Name: uuid.NewString(), Age: 3, Status: model.CatAvailable, Version: 1}
+	require.NoError(t, repository.Create(context.Background(), adopted))
+
+	status := model.CatAdopted
+	_, err := repository.Patch(context.Background(), adopted.ID, model.AnyVersion, &model.CatPatch{Status: &status})
+	require.NoError(t, err)
+
+	query := &model.CatQuery{CatFilter: model.CatFilter{NamePrefix: adopted.Name, Status: model.CatAdopted}, SortBy: model.CatSortID, Limit: 10}
+	cats, err := repository.List(context.Background(), query, nil)
+	require.NoError(t, err)
+	require.Len(t, cats, 1)
+	require.Equal(t, model.CatAdopted, cats[0].Status)
+}
+
+func TestProfile(t *testing.T) {
+	requirePostgres(t)
+	birthDate := time.Date(2015, time.June, 1, 0, 0, 0, 0, time.UTC)
+	neutered := true
+	microchip := uuid.NewString()[:15]
+	profiled := &model.Cat{ID: uuid.New(), Name: uuid.NewString(), Age: 1, Version: 1,
+		CatProfile: model.CatProfile{BirthDate: &birthDate, Sex: model.CatMale, Breed: "siamese", Neutered: &neutered, Microchip: &microchip}}
+	require.NoError(t, repository.Create(context.Background(), profiled))
+
+	testCat, err := repository.Get(context.Background(), profiled.ID)
+	require.NoError(t, err)
+	require.True(t, profiled.CatProfile.Equal(&testCat.CatProfile))
+
+	// the filter uses the birth date instead of the stored age
+	minAge := 5
+	query := &model.CatQuery{CatFilter: model.CatFilter{NamePrefix: profiled.Name, MinAge: &minAge}, SortBy: model.CatSortID, Limit: 10}
+	cats, err := repository.List(context.Background(), query, nil)
+	require.NoError(t, err)
+	require.Len(t, cats, 1)
+
+	testCat, err = repository.GetByMicrochip(context.Background(), microchip)
+	require.NoError(t, err)
+	require.Equal(t, profiled.ID, testCat.ID)
+
+	duplicate := &model.Cat{ID: uuid.New(), Name: "Cat 6", Age: 1, Version: 1, CatProfile: model.CatProfile{Microchip: &microchip}}
+	require.ErrorIs(t, repository.Create(context.Background(), duplicate), model.ErrConflict)
+
+	require.NoError(t, repository.Delete(context.Background(), profiled.ID, model.AnyVersion))
+	_, err = repository.GetByMicrochip(context.Background(), microchip)
+	require.ErrorIs(t, err, model.ErrCatNotFound)
+}
+
+func TestStats(t *testing.T) {
+	requirePostgres(t)
+	shelter := &model.Shelter{ID: uuid.New(), Name: "Stats shelter"}
+	require.NoError(t, shelters.Create(context.Background(), shelter))
+	intakeDate := time.Date(2021, time.March, 10, 0, 0, 0, 0, time.UTC)
+	kittenBirth := time.Now().UTC().AddDate(0, -3, 0)
+	cats := []*model.Cat{
+		{ID: uuid.New(), Name: "Stats 1", Age: 12, Vaccinated: true, ShelterID: &shelter.ID, Status: model.CatAvailable, Version: 1,
+			CatProfile: model.CatProfile{IntakeDate: &intakeDate}},
+		{ID: uuid.New(), Name: "Stats 2", Age: 5, ShelterID: &shelter.ID, Status: model.CatAdopted, Version: 1,
+			CatProfile: model.CatProfile{BirthDate: &kittenBirth, IntakeDate: &intakeDate}},
+		{ID: uuid.New(), Name: "Stats 3", Age: 4, ShelterID: &shelter.ID, Status: model.CatAvailable, Version: 1},
+	}
+	for _, cat := range cats {
+		require.NoError(t, repository.Create(context.Background(), cat))
+	}
+	date := intakeDate.Add(time.Hour)
+	for i, days := range []int{4, 10} {
+		intake := &model.Intake{ID: uuid.New(), Type: model.IntakeStray, Date: date}
+		require.NoError(t, intakes.AddIntake(context.Background(), cats[i].ID, intake))
+		outcome := &model.Outcome{Type: model.OutcomeAdoption, Date: date.AddDate(0, 0, days)}
+		require.NoError(t, intakes.CloseIntake(context.Background(), cats[i].ID, intake.ID, outcome))
+	}
+
+	stats, err := repository.Stats(context.Background(), &model.StatsFilter{ShelterID: &shelter.ID})
+	require.NoError(t, err)
+	require.Equal(t, int64(3), stats.Total)
+	require.Equal(t, int64(2), stats.ByStatus[model.CatAvailable])
+	require.Equal(t, int64(1), stats.Vaccinated)
+	require.Equal(t, int64(1), stats.Ages[0].Count)
+	require.Equal(t, int64(1), stats.Ages[2].Count)
+	require.Equal(t, int64(1), stats.Ages[4].Count)
+	require.Equal(t, int64(2), stats.Stays)
+	require.InDelta(t, 7, stats.AverageStayDays, 0.001)
+	require.InDelta(t, 7, stats.MedianStayDays, 0.001)
+
+	from, to := intakeDate, intakeDate.AddDate(0, 0, 1)
+	stats, err = repository.Stats(context.Background(), &model.StatsFilter{ShelterID: &shelter.ID, From: &from, To: &to})
 	require.NoError(t, err)
+	require.Equal(t, int64(2), stats.Total)
+	require.Equal(t, int64(2), stats.Stays)
 }
@@ -0,0 +1,535 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/catService/internal/model"
+
+	"github.com/google/uuid"
+)
+
+// CatSQLiteRepository contains a link to the SQLite database
+type CatSQLiteRepository struct {
+	db *SQLiteDB
+}
+
+// NewCatSQLite create new instance
+func NewCatSQLite(db *SQLiteDB) *CatSQLiteRepository {
+	return &CatSQLiteRepository{db: db}
+}
+
+// Get returns cat
+func (r *CatSQLiteRepository) Get(ctx context.Context, id uuid.UUID) (*model.Cat, error) {
+	row := r.db.QueryRow(ctx, "SELECT "+catColumns+" FROM cats WHERE id = ?1 AND deleted_at IS NULL", id)
+
+	cat, err := scanCat(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("get method error %w", model.ErrCatNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get method error %w", err)
+	}
+
+	return cat, nil
+}
+
+// GetByMicrochip returns cat with the microchip number
+func (r *CatSQLiteRepository) GetByMicrochip(ctx context.Context, chip string) (*model.Cat, error) {
+	row := r.db.QueryRow(ctx, "SELECT "+catColumns+" FROM cats WHERE microchip = ?1 AND deleted_at IS NULL", chip)
+
+	cat, err := scanCat(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("get by microchip method error %w", model.ErrCatNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get by microchip method error %w", err)
+	}
+
+	return cat, nil
+}
+
+// catInsert inserts the cat with the arguments of catInsertValues
+var catInsert = `INSERT INTO cats(` + catInsertColumns + `) VALUES (` +
+	strings.ReplaceAll(placeholders(catInsertColumns), "$", "?") + `)`
+
+// Create new cat in db
+func (r *CatSQLiteRepository) Create(ctx context.Context, cat *model.Cat) error {
+	err := r.withOutbox(ctx, func(tx *sql.Tx) ([]*model.OutboxMessage, error) {
+		if _, err := tx.ExecContext(ctx, catInsert, sqliteArgs(catInsertValues(cat))...); err != nil {
+			return nil, err
+		}
+		return model.NewCatMessages(cat)
+	})
+	if err != nil {
+		return fmt.Errorf("create method error %w", sqliteError(err))
+	}
+
+	return nil
+}
+
+// CreateMany inserts cats into db in one transaction, none of them is saved if one fails
+func (r *CatSQLiteRepository) CreateMany(ctx context.Context, cats []*model.Cat) error {
+	err := r.withOutbox(ctx, func(tx *sql.Tx) ([]*model.OutboxMessage, error) {
+		statement, err := tx.PrepareContext(ctx, catInsert)
+		if err != nil {
+			return nil, err
+		}
+		defer statement.Close()
+		for _, cat := range cats {
+			if _, err := statement.ExecContext(ctx, sqliteArgs(catInsertValues(cat))...); err != nil {
+				return nil, err
+			}
+		}
+		return model.NewCatMessages(cats...)
+	})
+	if err != nil {
+		return fmt.Errorf("create many method error %w", sqliteError(err))
+	}
+
+	return nil
+}
+
+// Delete marks cat as deleted, it stays in db until purge
+func (r *CatSQLiteRepository) Delete(ctx context.Context, id uuid.UUID, version int64) error {
+	err := r.withOutbox(ctx, func(tx *sql.Tx) ([]*model.OutboxMessage, error) {
+		result, err := tx.ExecContext(ctx, `UPDATE cats SET deleted_at = ?3, version = version+1
+			WHERE id = ?1 AND deleted_at IS NULL AND (?2 = 0 OR version = ?2)`, sqliteArgs([]interface{}{id, version, time.Now()})...)
+		if err != nil {
+			return nil, err
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return nil, err
+		}
+		if affected == 0 {
+			return nil, sql.ErrNoRows
+		}
+		return []*model.OutboxMessage{model.NewDeleteMessage(id)}, nil
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("delete method error %w", r.missError(ctx, id))
+	}
+	if err != nil {
+		return fmt.Errorf("delete method error %w", err)
+	}
+
+	return nil
+}
+
+// Update states for cat
+func (r *CatSQLiteRepository) Update(ctx context.Context, cat *model.Cat) error {
+	args := append([]interface{}{cat.ID, cat.Version, cat.Name, cat.Age, cat.Vaccinated, cat.ShelterID},
+		catProfileValues(&cat.CatProfile)...)
+	var updated *model.Cat
+	err := r.withOutbox(ctx, func(tx *sql.Tx) ([]*model.OutboxMessage, error) {
+		row := tx.QueryRowContext(ctx, `UPDATE cats SET name=?3, age=?4, vaccinated=?5, shelter_id=?6, `+catSQLiteProfileAssignments(7)+`,
+			version=version+1 WHERE id=?1 AND deleted_at IS NULL AND (?2 = 0 OR version=?2) RETURNING `+catColumns, sqliteArgs(args)...)
+		var err error
+		if updated, err = scanCat(row); err != nil {
+			return nil, err
+		}
+		return model.NewCatMessages(updated)
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("update method error %w", r.missError(ctx, cat.ID))
+	}
+	if err != nil {
+		return fmt.Errorf("update method error %w", sqliteError(err))
+	}
+	cat.Version = updated.Version
+
+	return nil
+}
+
+// Patch updates only the columns set in the patch
+func (r *CatSQLiteRepository) Patch(ctx context.Context, id uuid.UUID, version int64, patch *model.CatPatch) (int64, error) {
+	columns := []string{"version=version+1"}
+	args := []interface{}{id, version}
+	if patch.Name != nil {
+		args = append(args, *patch.Name)
+		columns = append(columns, fmt.Sprintf("name=?%d", len(args)))
+	}
+	if patch.Age != nil {
+		args = append(args, *patch.Age)
+		columns = append(columns, fmt.Sprintf("age=?%d", len(args)))
+	}
+	if patch.Vaccinated != nil {
+		args = append(args, *patch.Vaccinated)
+		columns = append(columns, fmt.Sprintf("vaccinated=?%d", len(args)))
+	}
+	if patch.ShelterID != nil {
+		args = append(args, patch.Shelter())
+		columns = append(columns, fmt.Sprintf("shelter_id=?%d", len(args)))
+	}
+	if patch.Status != nil {
+		args = append(args, *patch.Status)
+		columns = append(columns, fmt.Sprintf("status=?%d", len(args)))
+	}
+	if patch.Profile != nil {
+		columns = append(columns, catSQLiteProfileAssignments(len(args)+1))
+		args = append(args, catProfileValues(patch.Profile)...)
+	}
+
+	query := fmt.Sprintf("UPDATE cats SET %s WHERE id=?1 AND deleted_at IS NULL AND (?2 = 0 OR version=?2) RETURNING %s",
+		strings.Join(columns, ", "), catColumns)
+	var patched *model.Cat
+	err := r.withOutbox(ctx, func(tx *sql.Tx) ([]*model.OutboxMessage, error) {
+		var err error
+		if patched, err = scanCat(tx.QueryRowContext(ctx, query, sqliteArgs(args)...)); err != nil {
+			return nil, err
+		}
+		message, err := model.NewCatMessage(model.PatchAction(patch), patched)
+		if err != nil {
+			return nil, err
+		}
+		return []*model.OutboxMessage{message}, nil
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, fmt.Errorf("patch method error %w", r.missError(ctx, id))
+	}
+	if err != nil {
+		return 0, fmt.Errorf("patch method error %w", sqliteError(err))
+	}
+
+	return patched.Version, nil
+}
+
+// Restore removes the deleted mark from cat and returns it
+func (r *CatSQLiteRepository) Restore(ctx context.Context, id uuid.UUID, version int64) (*model.Cat, error) {
+	var cat *model.Cat
+	err := r.withOutbox(ctx, func(tx *sql.Tx) ([]*model.OutboxMessage, error) {
+		row := tx.QueryRowContext(ctx, `UPDATE cats SET deleted_at = NULL, version = version+1
+			WHERE id = ?1 AND deleted_at IS NOT NULL AND (?2 = 0 OR version = ?2) RETURNING `+catColumns, id, version)
+		var err error
+		if cat, err = scanCat(row); err != nil {
+			return nil, err
+		}
+		return model.NewCatMessages(cat)
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("restore method error %w", r.restoreMissError(ctx, id))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("restore method error %w", err)
+	}
+
+	return cat, nil
+}
+
+// withOutbox runs the change of cats and writes the cache events it returns into the outbox in one transaction
+func (r *CatSQLiteRepository) withOutbox(ctx context.Context, change func(tx *sql.Tx) ([]*model.OutboxMessage, error)) error {
+	tx, err := r.db.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	// rollback after commit does nothing
+	defer tx.Rollback()
+
+	messages, err := change(tx)
+	if err != nil {
+		return err
+	}
+	if err := insertSQLiteOutbox(ctx, tx, messages); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Purge permanently removes cats deleted before the given time, their records are removed by cascade
+func (r *CatSQLiteRepository) Purge(ctx context.Context, before time.Time) ([]uuid.UUID, error) {
+	rows, err := r.db.Query(ctx, "DELETE FROM cats WHERE deleted_at < ?1 RETURNING id", before)
+	if err != nil {
+		return nil, fmt.Errorf("purge method error %w", err)
+	}
+	defer rows.Close()
+
+	var purged []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("purge method error %w", err)
+		}
+		purged = append(purged, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("purge method error %w", err)
+	}
+
+	return purged, nil
+}
+
+// missError explains why a conditional change of not deleted cat matched no rows
+func (r *CatSQLiteRepository) missError(ctx context.Context, id uuid.UUID) error {
+	var exists bool
+	err := r.db.QueryRow(ctx, "SELECT EXISTS(SELECT 1 FROM cats WHERE id = ?1 AND deleted_at IS NULL)", id).Scan(&exists)
+	switch {
+	case err != nil:
+		return err
+	case exists:
+		return model.ErrVersionMismatch
+	default:
+		return model.ErrCatNotFound
+	}
+}
+
+// restoreMissError explains why restore matched no rows
+func (r *CatSQLiteRepository) restoreMissError(ctx context.Context, id uuid.UUID) error {
+	var deletedAt *time.Time
+	err := r.db.QueryRow(ctx, "SELECT deleted_at FROM cats WHERE id = ?1", id).Scan(&deletedAt)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return model.ErrCatNotFound
+	case err != nil:
+		return err
+	case deletedAt == nil:
+		return fmt.Errorf("%w: cat is not deleted", model.ErrConflict)
+	default:
+		return model.ErrVersionMismatch
+	}
+}
+
+// List returns cats matching the query
+func (r *CatSQLiteRepository) List(ctx context.Context, query *model.CatQuery, after *model.Cat) ([]*model.Cat, error) {
+	conditions, args := catSQLiteFilterConditions(&query.CatFilter)
+
+	column := catSQLiteSortColumn(query.SortBy)
+	operator, direction := ">", "ASC"
+	if query.Desc {
+		operator, direction = "<", "DESC"
+	}
+	if after != nil {
+		if query.SortBy == model.CatSortID {
+			args = append(args, after.ID)
+			conditions = append(conditions, fmt.Sprintf("id %s ?%d", operator, len(args)))
+		} else {
+			args = append(args, after.SortKey(query.SortBy), after.ID)
+			conditions = append(conditions, fmt.Sprintf("(%[1]s %[2]s ?%[3]d OR (%[1]s = ?%[3]d AND id %[2]s ?%[4]d))",
+				column, operator, len(args)-1, len(args)))
+		}
+	}
+
+	sqlQuery := "SELECT " + catColumns + " FROM cats"
+	if len(conditions) > 0 {
+		sqlQuery += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	args = append(args, query.Limit)
+	sqlQuery += fmt.Sprintf(" ORDER BY %[1]s %[2]s, id %[2]s LIMIT ?%[3]d", column, direction, len(args))
+
+	rows, err := r.db.Query(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list method error %w", err)
+	}
+	defer rows.Close()
+
+	cats := make([]*model.Cat, 0, query.Limit)
+	for rows.Next() {
+		cat, err := scanCat(rows)
+		if err != nil {
+			return nil, fmt.Errorf("list method error %w", err)
+		}
+		cats = append(cats, cat)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list method error %w", err)
+	}
+
+	return cats, nil
+}
+
+// Export streams cats matching the filter to fn
+func (r *CatSQLiteRepository) Export(ctx context.Context, filter *model.CatFilter, fn func(*model.Cat) error) error {
+	conditions, args := catSQLiteFilterConditions(filter)
+	query := "SELECT " + catColumns + " FROM cats"
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY id"
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("export method error %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		cat, err := scanCat(rows)
+		if err != nil {
+			return fmt.Errorf("export method error %w", err)
+		}
+		if err := fn(cat); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("export method error %w", err)
+	}
+
+	return nil
+}
+
+// catSQLiteProfileAssignments returns SET list of the profile columns with parameters numbered from first
+func catSQLiteProfileAssignments(first int) string {
+	columns := strings.Split(catProfileColumns, ", ")
+	assignments := make([]string, len(columns))
+	for i, column := range columns {
+		assignments[i] = fmt.Sprintf("%s=?%d", column, first+i)
+	}
+
+	return strings.Join(assignments, ", ")
+}
+
+// catSQLiteFilterConditions builds WHERE conditions with their arguments like catFilterConditions does for Postgres
+func catSQLiteFilterConditions(filter *model.CatFilter) (conditions []string, args []interface{}) {
+	switch filter.Deleted {
+	case model.DeletedInclude:
+	case model.DeletedOnly:
+		conditions = append(conditions, "deleted_at IS NOT NULL")
+	default:
+		conditions = append(conditions, "deleted_at IS NULL")
+	}
+	if filter.Vaccinated != nil {
+		args = append(args, *filter.Vaccinated)
+		conditions = append(conditions, fmt.Sprintf("vaccinated = ?%d", len(args)))
+	}
+	// the age of cats with birth date is compared by the date, the stored age may be outdated
+	if filter.MinAge != nil {
+		args = append(args, *filter.MinAge, model.LatestBirthDate(*filter.MinAge, time.Now()))
+		conditions = append(conditions, fmt.Sprintf("(birth_date IS NULL AND age >= ?%d OR birth_date <= ?%d)", len(args)-1, len(args)))
+	}
+	if filter.MaxAge != nil {
+		args = append(args, *filter.MaxAge, model.LatestBirthDate(*filter.MaxAge+1, time.Now()))
+		conditions = append(conditions, fmt.Sprintf("(birth_date IS NULL AND age <= ?%d OR birth_date > ?%d)", len(args)-1, len(args)))
+	}
+	if filter.NamePrefix != "" {
+		// LIKE is case sensitive in the connection, SQLite has no default escape character
+		args = append(args, likePrefix(filter.NamePrefix))
+		conditions = append(conditions, fmt.Sprintf(`name LIKE ?%d ESCAPE '\'`, len(args)))
+	}
+	if filter.ShelterID != nil {
+		args = append(args, *filter.ShelterID)
+		conditions = append(conditions, fmt.Sprintf("shelter_id = ?%d", len(args)))
+	}
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		conditions = append(conditions, fmt.Sprintf("status = ?%d", len(args)))
+	}
+	if filter.InFoster != nil {
+		inFoster := "EXISTS (SELECT 1 FROM cat_placements p WHERE p.cat_id = cats.id AND p.ended_at IS NULL)"
+		if !*filter.InFoster {
+			inFoster = "NOT " + inFoster
+		}
+		conditions = append(conditions, inFoster)
+	}
+	if filter.Sex != "" {
+		args = append(args, filter.Sex)
+		conditions = append(conditions, fmt.Sprintf("sex = ?%d", len(args)))
+	}
+	if filter.NotBadWithKids {
+		conditions = append(conditions, "good_with_kids IS NOT 0")
+	}
+
+	return conditions, args
+}
+
+// catSQLiteSortColumn returns the column for the sort field, the default collation compares names bytewise
+func catSQLiteSortColumn(field string) string {
+	switch field {
+	case model.CatSortName:
+		return "name"
+	case model.CatSortAge:
+		return "age"
+	case model.CatSortVaccinated:
+		return "vaccinated"
+	default:
+		return "id"
+	}
+}
+
+// Stats counts not deleted cats and their finished stays with SQL aggregates,
+// the median is taken from the sorted stay lengths since SQLite has no percentile
+func (r *CatSQLiteRepository) Stats(ctx context.Context, filter *model.StatsFilter) (*model.CatStats, error) {
+	stats := model.NewCatStats()
+
+	conditions, args := statsSQLiteConditions(filter, "shelter_id", "intake_date", []string{"deleted_at IS NULL"})
+	// buckets are checked from the oldest, the age of cats with birth date is compared by the date like in the filter
+	now := time.Now()
+	buckets := make([]string, 0, len(stats.Ages))
+	for i := len(stats.Ages) - 1; i > 0; i-- {
+		args = append(args, stats.Ages[i].MinAge, model.LatestBirthDate(stats.Ages[i].MinAge, now))
+		buckets = append(buckets, fmt.Sprintf("WHEN birth_date IS NULL AND age >= ?%d OR birth_date <= ?%d THEN %d",
+			len(args)-1, len(args), i))
+	}
+	rows, err := r.db.Query(ctx, fmt.Sprintf(`SELECT status, vaccinated, CASE %s ELSE 0 END AS bucket, count(*)
+		FROM cats WHERE %s GROUP BY status, vaccinated, bucket`, strings.Join(buckets, " "), strings.Join(conditions, " AND ")), args...)
+	if err != nil {
+		return nil, fmt.Errorf("stats method error %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var status string
+		var vaccinated bool
+		var bucket int
+		var count int64
+		if err := rows.Scan(&status, &vaccinated, &bucket, &count); err != nil {
+			return nil, fmt.Errorf("stats method error %w", err)
+		}
+		stats.Add(status, vaccinated, bucket, count)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("stats method error %w", err)
+	}
+	rows.Close()
+
+	conditions, args = statsSQLiteConditions(filter, "c.shelter_id", "i.date", []string{"c.deleted_at IS NULL", "i.outcome_type IS NOT NULL"})
+	rows, err = r.db.Query(ctx, `SELECT julianday(i.outcome_date) - julianday(i.date) AS days
+		FROM cat_intakes i JOIN cats c ON c.id = i.cat_id WHERE `+strings.Join(conditions, " AND ")+` ORDER BY days`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("stats method error %w", err)
+	}
+	defer rows.Close()
+	var days []float64
+	var total float64
+	for rows.Next() {
+		var stay float64
+		if err := rows.Scan(&stay); err != nil {
+			return nil, fmt.Errorf("stats method error %w", err)
+		}
+		days = append(days, stay)
+		total += stay
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("stats method error %w", err)
+	}
+	if len(days) > 0 {
+		stats.Stays = int64(len(days))
+		stats.AverageStayDays = total / float64(len(days))
+		stats.MedianStayDays = model.Median(days)
+	}
+
+	return stats, nil
+}
+
+// statsSQLiteConditions appends the conditions of the stats filter on the given columns
+func statsSQLiteConditions(filter *model.StatsFilter, shelterColumn, dateColumn string, conditions []string) ([]string, []interface{}) {
+	var args []interface{}
+	if filter.ShelterID != nil {
+		args = append(args, *filter.ShelterID)
+		conditions = append(conditions, fmt.Sprintf("%s = ?%d", shelterColumn, len(args)))
+	}
+	if filter.From != nil {
+		args = append(args, *filter.From)
+		conditions = append(conditions, fmt.Sprintf("%s >= ?%d", dateColumn, len(args)))
+	}
+	if filter.To != nil {
+		args = append(args, *filter.To)
+		conditions = append(conditions, fmt.Sprintf("%s < ?%d", dateColumn, len(args)))
+	}
+
+	return conditions, args
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/catService/internal/model"
+)
+
+// CheckpointFileRepository keeps the copy checkpoint in the JSON file
+type CheckpointFileRepository struct {
+	path string
+}
+
+// NewCheckpointFile create new instance
+func NewCheckpointFile(path string) *CheckpointFileRepository {
+	return &CheckpointFileRepository{path: path}
+}
+
+// Load reads the checkpoint, nil is returned when the file doesn't exist
+func (r *CheckpointFileRepository) Load(_ context.Context) (*model.CopyCheckpoint, error) {
+	content, err := os.ReadFile(r.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("load checkpoint error %w", err)
+	}
+
+	var checkpoint model.CopyCheckpoint
+	if err := json.Unmarshal(content, &checkpoint); err != nil {
+		return nil, fmt.Errorf("load checkpoint error %w", err)
+	}
+
+	return &checkpoint, nil
+}
+
+// Save writes the checkpoint, the file is replaced by rename so the previous checkpoint survives a failed save
+func (r *CheckpointFileRepository) Save(_ context.Context, checkpoint *model.CopyCheckpoint) error {
+	content, err := json.Marshal(checkpoint)
+	if err != nil {
+		return fmt.Errorf("save checkpoint error %w", err)
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(r.path), filepath.Base(r.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("save checkpoint error %w", err)
+	}
+	defer os.Remove(file.Name())
+	if _, err := file.Write(content); err != nil {
+		file.Close()
+		return fmt.Errorf("save checkpoint error %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("save checkpoint error %w", err)
+	}
+	if err := os.Rename(file.Name(), r.path); err != nil {
+		return fmt.Errorf("save checkpoint error %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/catService/internal/model"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Conflict messages of the constraints without a known name
+const (
+	conflictExists     = "record already exists"
+	conflictReferenced = "referenced record doesn't exist or is still in use"
+)
+
+// constraintConflicts are the messages of the known constraints shown to the clients.
+// Keys are postgres constraint names, SQLite unique columns and mongo index names
+var constraintConflicts = map[string]string{
+	"cats_microchip_key":            "microchip already registered",
+	"cats.microchip":                "microchip already registered",
+	"microchip_unique":              "microchip already registered",
+	"cat_intakes_open_idx":          "cat has open intake",
+	"cat_intakes.cat_id":            "cat has open intake",
+	"cat_placements_active_idx":     "cat has active placement",
+	"cat_placements.cat_id":         "cat has active placement",
+	"cats_shelter_id_fkey":          "shelter has cats or doesn't exist",
+	"cat_placements_foster_id_fkey": "foster has placements or doesn't exist",
+}
+
+// conflictError returns model.ErrConflict with the message of the violated constraint.
+// The driver error names the tables, the indexes and the values, so it's only logged
+func conflictError(constraint, fallback string, err error) error {
+	logrus.Warnf("constraint %q violated: %s", constraint, err)
+	message, ok := constraintConflicts[constraint]
+	if !ok {
+		message = fallback
+	}
+
+	return fmt.Errorf("%w: %s", model.ErrConflict, message)
+}
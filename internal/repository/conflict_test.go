@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/catService/internal/model"
+
+	"github.com/jackc/pgconn"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestConflictMessages(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		message string
+	}{
+		{
+			name: "postgres unique",
+			err: pgError(&pgconn.PgError{Code: pgUniqueViolation, ConstraintName: "cats_microchip_key",
+				Message: `duplicate key value violates unique constraint "cats_microchip_key"`}),
+			message: "conflict: microchip already registered",
+		},
+		{
+			name: "postgres unknown foreign key",
+			err: pgError(&pgconn.PgError{Code: pgForeignKeyViolation, ConstraintName: "cat_photos_cat_id_fkey",
+				Message: `insert or update on table "cat_photos" violates foreign key constraint "cat_photos_cat_id_fkey"`}),
+			message: "conflict: " + conflictReferenced,
+		},
+		{
+			name: "mongo duplicate key",
+			err: mongoError(mongo.WriteException{WriteErrors: []mongo.WriteError{{Code: 11000,
+				Message: `E11000 duplicate key error collection: cats.cat index: microchip_unique dup key: { microchip: "900000000000001" }`}}}),
+			message: "conflict: microchip already registered",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.ErrorIs(t, tt.err, model.ErrConflict)
+			require.Equal(t, tt.message, tt.err.Error())
+		})
+	}
+}
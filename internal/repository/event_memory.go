@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"sort"
+
+	"github.com/catService/internal/model"
+
+	"github.com/google/uuid"
+)
+
+// CatEventMemoryRepository keeps the cat history in the memory store
+type CatEventMemoryRepository struct {
+	store *MemoryStore
+}
+
+// NewCatEventMemory create new instance
+func NewCatEventMemory(store *MemoryStore) *CatEventMemoryRepository {
+	return &CatEventMemoryRepository{store: store}
+}
+
+// AddEvent saves event into cat history
+func (r *CatEventMemoryRepository) AddEvent(ctx context.Context, event *model.CatEvent) error {
+	r.store.mutex.Lock()
+	defer r.store.mutex.Unlock()
+
+	copied := *event
+	r.store.data.Events = append(r.store.data.Events, &copied)
+
+	return nil
+}
+
+// ListEvents returns cat history in chronological order starting after the given event
+func (r *CatEventMemoryRepository) ListEvents(ctx context.Context, catID uuid.UUID, after *model.CatEvent, limit int) ([]*model.CatEvent, error) {
+	less := func(a, b *model.CatEvent) bool {
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.Before(b.CreatedAt)
+		}
+		return compareIDs(a.ID, b.ID) < 0
+	}
+
+	r.store.mutex.RLock()
+	events := make([]*model.CatEvent, 0, limit)
+	for _, event := range r.store.data.Events {
+		if event.CatID == catID && (after == nil || less(after, event)) {
+			copied := *event
+			events = append(events, &copied)
+		}
+	}
+	r.store.mutex.RUnlock()
+
+	sort.Slice(events, func(i, j int) bool {
+		return less(events[i], events[j])
+	})
+	if len(events) > limit {
+		events = events[:limit]
+	}
+
+	return events, nil
+}
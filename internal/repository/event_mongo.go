@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/catService/internal/model"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// CatEventMongoRepository contains a link to the connection to db
+type CatEventMongoRepository struct {
+	db *mongo.Database
+}
+
+// NewCatEventMongo create new instance
+func NewCatEventMongo(database *mongo.Database) *CatEventMongoRepository {
+	return &CatEventMongoRepository{db: database}
+}
+
+// CreateCatEventMongoIndexes creates the index the cat history is read by if it doesn't exist
+func CreateCatEventMongoIndexes(ctx context.Context, database *mongo.Database) error {
+	_, err := database.Collection("cat_events").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "cat_id", Value: 1}, {Key: "created_at", Value: 1}, {Key: "_id", Value: 1}},
+		Options: options.Index().SetName("cat_id_created_at_id"),
+	})
+	if err != nil {
+		return fmt.Errorf("create cat event indexes error %w", err)
+	}
+
+	return nil
+}
+
+// AddEvent saves event into cat history
+func (c *CatEventMongoRepository) AddEvent(ctx context.Context, event *model.CatEvent) error {
+	_, err := c.db.Collection("cat_events").InsertOne(ctx, event)
+	if err != nil {
+		return fmt.Errorf("add event method error %w", err)
+	}
+
+	return nil
+}
+
+// ListEvents returns cat history in chronological order starting after the given event
+func (c *CatEventMongoRepository) ListEvents(ctx context.Context, catID uuid.UUID, after *model.CatEvent, limit int) ([]*model.CatEvent, error) {
+	filter := bson.M{"cat_id": catID}
+	if after != nil {
+		filter["$or"] = bson.A{
+			bson.M{"created_at": bson.M{"$gt": after.CreatedAt}},
+			bson.M{"created_at": after.CreatedAt, "_id": bson.M{"$gt": after.ID}},
+		}
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := c.db.Collection("cat_events").Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("list events method error %w", err)
+	}
+	events := make([]*model.CatEvent, 0, limit)
+	if err := cursor.All(ctx, &events); err != nil {
+		return nil, fmt.Errorf("failed decode events from DB %w", err)
+	}
+
+	return events, nil
+}
@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/catService/internal/model"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// CatEventPostgresRepository contains a link to the connection to db
+type CatEventPostgresRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewCatEventPostgres create new instance
+func NewCatEventPostgres(pool *pgxpool.Pool) *CatEventPostgresRepository {
+	return &CatEventPostgresRepository{db: pool}
+}
+
+// AddEvent saves event into cat history
+func (r *CatEventPostgresRepository) AddEvent(ctx context.Context, event *model.CatEvent) error {
+	before, err := marshalSnapshot(event.Before)
+	if err != nil {
+		return fmt.Errorf("add event method error %w", err)
+	}
+	after, err := marshalSnapshot(event.After)
+	if err != nil {
+		return fmt.Errorf("add event method error %w", err)
+	}
+
+	_, err = r.db.Exec(ctx, `INSERT INTO cat_events(id, cat_id, action, before, after, actor, created_at)
+		VALUES ($1,$2,$3,$4,$5,$6,$7)`,
+		event.ID, event.CatID, event.Action, before, after, event.Actor, event.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("add event method error %w", err)
+	}
+
+	return nil
+}
+
+// ListEvents returns cat history in chronological order starting after the given event
+func (r *CatEventPostgresRepository) ListEvents(ctx context.Context, catID uuid.UUID, after *model.CatEvent, limit int) ([]*model.CatEvent, error) {
+	sql := "SELECT id, cat_id, action, before, after, actor, created_at FROM cat_events WHERE cat_id = $1"
+	args := []interface{}{catID}
+	if after != nil {
+		args = append(args, after.CreatedAt, after.ID)
+		sql += " AND (created_at > $2 OR (created_at = $2 AND id > $3))"
+	}
+	args = append(args, limit)
+	sql += fmt.Sprintf(" ORDER BY created_at, id LIMIT $%d", len(args))
+
+	rows, err := r.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list events method error %w", err)
+	}
+	defer rows.Close()
+
+	events := make([]*model.CatEvent, 0, limit)
+	for rows.Next() {
+		var event model.CatEvent
+		var before, after []byte
+		if err := rows.Scan(&event.ID, &event.CatID, &event.Action, &before, &after, &event.Actor, &event.CreatedAt); err != nil {
+			return nil, fmt.Errorf("list events method error %w", err)
+		}
+		if event.Before, err = unmarshalSnapshot(before); err != nil {
+			return nil, fmt.Errorf("list events method error %w", err)
+		}
+		if event.After, err = unmarshalSnapshot(after); err != nil {
+			return nil, fmt.Errorf("list events method error %w", err)
+		}
+		events = append(events, &event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list events method error %w", err)
+	}
+
+	return events, nil
+}
+
+// marshalSnapshot returns JSON of the cat state, nil for absent state
+func marshalSnapshot(cat *model.Cat) ([]byte, error) {
+	if cat == nil {
+		return nil, nil
+	}
+
+	return json.Marshal(cat)
+}
+
+// unmarshalSnapshot returns cat state from JSON, nil for absent state
+func unmarshalSnapshot(data []byte) (*model.Cat, error) {
+	if data == nil {
+		return nil, nil
+	}
+	cat := model.Cat{}
+	if err := json.Unmarshal(data, &cat); err != nil {
+		return nil, err
+	}
+
+	return &cat, nil
+}
@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/catService/internal/model"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvents(t *testing.T) {
+	requirePostgres(t)
+	catID := uuid.New()
+	created := time.Now().UTC().Truncate(time.Millisecond)
+	before := &model.Cat{ID: catID, Name: "Cat 8", Age: 8, Version: 1}
+	after := &model.Cat{ID: catID, Name: "Cat 9", Age: 8, Version: 2}
+	history := []*model.CatEvent{
+		{ID: uuid.New(), CatID: catID, Action: model.CatEventCreate, After: before, Actor: "volunteer", CreatedAt: created},
+		{ID: uuid.New(), CatID: catID, Action: model.CatEventUpdate, Before: before, After: after, Actor: "volunteer", CreatedAt: created.Add(time.Second)},
+		{ID: uuid.New(), CatID: catID, Action: model.CatEventDelete, Before: after, Actor: "volunteer", CreatedAt: created.Add(2 * time.Second)},
+	}
+	for _, event := range history {
+		require.NoError(t, events.AddEvent(context.Background(), event))
+	}
+
+	page, err := events.ListEvents(context.Background(), catID, nil, 2)
+	require.NoError(t, err)
+	require.Len(t, page, 2)
+	require.Equal(t, history[0].ID, page[0].ID)
+	require.Nil(t, page[0].Before)
+	require.Equal(t, before.Name, page[1].Before.Name)
+	require.Equal(t, after.Name, page[1].After.Name)
+
+	page, err = events.ListEvents(context.Background(), catID, page[1], 2)
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	require.Equal(t, model.CatEventDelete, page[0].Action)
+	require.Nil(t, page[0].After)
+}
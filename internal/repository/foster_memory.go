@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/catService/internal/model"
+
+	"github.com/google/uuid"
+)
+
+// FosterMemoryRepository keeps fosters in the memory store
+type FosterMemoryRepository struct {
+	store *MemoryStore
+}
+
+// NewFosterMemory create new instance
+func NewFosterMemory(store *MemoryStore) *FosterMemoryRepository {
+	return &FosterMemoryRepository{store: store}
+}
+
+// Get returns foster
+func (r *FosterMemoryRepository) Get(ctx context.Context, id uuid.UUID) (*model.Foster, error) {
+	r.store.mutex.RLock()
+	defer r.store.mutex.RUnlock()
+
+	foster, exist := r.store.data.Fosters[id]
+	if !exist {
+		return nil, fmt.Errorf("get method error %w", model.ErrFosterNotFound)
+	}
+	copied := *foster
+
+	return &copied, nil
+}
+
+// Create new foster in the store
+func (r *FosterMemoryRepository) Create(ctx context.Context, foster *model.Foster) error {
+	r.store.mutex.Lock()
+	defer r.store.mutex.Unlock()
+
+	if _, exist := r.store.data.Fosters[foster.ID]; exist {
+		return fmt.Errorf("create method error %w: foster %s already exists", model.ErrConflict, foster.ID)
+	}
+	copied := *foster
+	r.store.data.Fosters[foster.ID] = &copied
+
+	return nil
+}
+
+// Update states for foster
+func (r *FosterMemoryRepository) Update(ctx context.Context, foster *model.Foster) error {
+	r.store.mutex.Lock()
+	defer r.store.mutex.Unlock()
+
+	if _, exist := r.store.data.Fosters[foster.ID]; !exist {
+		return fmt.Errorf("update method error %w", model.ErrFosterNotFound)
+	}
+	copied := *foster
+	r.store.data.Fosters[foster.ID] = &copied
+
+	return nil
+}
+
+// Delete removes foster which has no placements, the cats of deleted placements count until they are purged
+func (r *FosterMemoryRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.store.mutex.Lock()
+	defer r.store.mutex.Unlock()
+
+	if _, exist := r.store.data.Fosters[id]; !exist {
+		return fmt.Errorf("delete method error %w", model.ErrFosterNotFound)
+	}
+	for _, entry := range r.store.data.Cats {
+		for _, placement := range entry.Placements {
+			if placement.FosterID == id {
+				return fmt.Errorf("delete method error %w: foster has placements", model.ErrConflict)
+			}
+		}
+	}
+	delete(r.store.data.Fosters, id)
+
+	return nil
+}
+
+// List returns fosters in id order starting after the given one
+func (r *FosterMemoryRepository) List(ctx context.Context, after *uuid.UUID, limit int) ([]*model.Foster, error) {
+	r.store.mutex.RLock()
+	defer r.store.mutex.RUnlock()
+
+	ids := make([]uuid.UUID, 0, len(r.store.data.Fosters))
+	for id := range r.store.data.Fosters {
+		ids = append(ids, id)
+	}
+	ids = pageIDs(ids, after, limit)
+	fosters := make([]*model.Foster, 0, len(ids))
+	for _, id := range ids {
+		copied := *r.store.data.Fosters[id]
+		fosters = append(fosters, &copied)
+	}
+
+	return fosters, nil
+}
+
+// ListPlacements returns placements of the cat in the order they started
+func (r *FosterMemoryRepository) ListPlacements(ctx context.Context, catID uuid.UUID) ([]*model.Placement, error) {
+	r.store.mutex.RLock()
+	defer r.store.mutex.RUnlock()
+
+	entry, exist := r.store.data.Cats[catID]
+	if !exist {
+		return nil, fmt.Errorf("list placements method error %w", model.ErrCatNotFound)
+	}
+	placements := make([]*model.Placement, 0, len(entry.Placements))
+	for _, placement := range entry.Placements {
+		copied := *placement
+		placements = append(placements, &copied)
+	}
+	sort.SliceStable(placements, func(i, j int) bool {
+		return placements[i].StartedAt.Before(placements[j].StartedAt)
+	})
+
+	return placements, nil
+}
+
+// AddPlacement saves new active placement of the cat, the cat with active placement gets no second one
+func (r *FosterMemoryRepository) AddPlacement(ctx context.Context, placement *model.Placement) error {
+	r.store.mutex.Lock()
+	defer r.store.mutex.Unlock()
+
+	entry, exist := r.store.data.Cats[placement.CatID]
+	switch {
+	case !exist:
+		return fmt.Errorf("add placement method error %w", model.ErrCatNotFound)
+	case activePlacement(entry.Placements):
+		return fmt.Errorf("add placement method error %w: cat has active placement", model.ErrConflict)
+	}
+	if _, exist := r.store.data.Fosters[placement.FosterID]; !exist {
+		return fmt.Errorf("add placement method error %w: foster %s doesn't exist", model.ErrConflict, placement.FosterID)
+	}
+	copied := *placement
+	entry.Placements = append(entry.Placements, &copied)
+
+	return nil
+}
+
+// EndPlacement marks the active placement of the cat as ended
+func (r *FosterMemoryRepository) EndPlacement(ctx context.Context, catID, id uuid.UUID, endedAt time.Time, notes string) error {
+	r.store.mutex.Lock()
+	defer r.store.mutex.Unlock()
+
+	if entry, exist := r.store.data.Cats[catID]; exist {
+		for i, placement := range entry.Placements {
+			if placement.ID == id && placement.Active() {
+				ended := *placement
+				ended.EndedAt = &endedAt
+				ended.Notes = notes
+				entry.Placements[i] = &ended
+				return nil
+			}
+		}
+	}
+
+	return fmt.Errorf("end placement method error %w: placement is not active", model.ErrConflict)
+}
@@ -0,0 +1,167 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/catService/internal/model"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// FosterMongoRepository keeps fosters in their collection and placements embedded into the cat document
+type FosterMongoRepository struct {
+	db *mongo.Database
+}
+
+// NewFosterMongo create new instance
+func NewFosterMongo(database *mongo.Database) *FosterMongoRepository {
+	return &FosterMongoRepository{db: database}
+}
+
+// Get returns foster
+func (c *FosterMongoRepository) Get(ctx context.Context, id uuid.UUID) (*model.Foster, error) {
+	foster := model.Foster{}
+	err := c.db.Collection("foster").FindOne(ctx, bson.M{"_id": id}).Decode(&foster)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, fmt.Errorf("get method error %w", model.ErrFosterNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get method error %w", err)
+	}
+
+	return &foster, nil
+}
+
+// Create new foster in db
+func (c *FosterMongoRepository) Create(ctx context.Context, foster *model.Foster) error {
+	_, err := c.db.Collection("foster").InsertOne(ctx, foster)
+	if err != nil {
+		return fmt.Errorf("create method error %w", mongoError(err))
+	}
+
+	return nil
+}
+
+// Update states for foster
+func (c *FosterMongoRepository) Update(ctx context.Context, foster *model.Foster) error {
+	result, err := c.db.Collection("foster").ReplaceOne(ctx, bson.M{"_id": foster.ID}, foster)
+	if err != nil {
+		return fmt.Errorf("update method error %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("update method error %w", model.ErrFosterNotFound)
+	}
+
+	return nil
+}
+
+// Delete removes foster which has no placements, the cats of deleted placements count until they are purged
+func (c *FosterMongoRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	count, err := c.db.Collection("cat").CountDocuments(ctx, bson.M{"placements.foster_id": id})
+	if err != nil {
+		return fmt.Errorf("delete method error %w", err)
+	}
+	if count > 0 {
+		return fmt.Errorf("delete method error %w: foster has placements", model.ErrConflict)
+	}
+
+	result, err := c.db.Collection("foster").DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return fmt.Errorf("delete method error %w", err)
+	}
+	if result.DeletedCount == 0 {
+		return fmt.Errorf("delete method error %w", model.ErrFosterNotFound)
+	}
+
+	return nil
+}
+
+// List returns fosters in id order starting after the given one
+func (c *FosterMongoRepository) List(ctx context.Context, after *uuid.UUID, limit int) ([]*model.Foster, error) {
+	filter := bson.M{}
+	if after != nil {
+		filter["_id"] = bson.M{"$gt": *after}
+	}
+
+	cursor, err := c.db.Collection("foster").Find(ctx, filter,
+		options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}).SetLimit(int64(limit)))
+	if err != nil {
+		return nil, fmt.Errorf("list method error %w", err)
+	}
+	fosters := make([]*model.Foster, 0, limit)
+	if err := cursor.All(ctx, &fosters); err != nil {
+		return nil, fmt.Errorf("failed decode fosters from DB %w", err)
+	}
+
+	return fosters, nil
+}
+
+// ListPlacements returns placements of the cat in the order they started
+func (c *FosterMongoRepository) ListPlacements(ctx context.Context, catID uuid.UUID) ([]*model.Placement, error) {
+	var document struct {
+		Placements []*model.Placement `bson:"placements"`
+	}
+	opts := options.FindOne().SetProjection(bson.M{"placements": 1})
+	err := c.db.Collection("cat").FindOne(ctx, bson.M{"_id": catID}, opts).Decode(&document)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, fmt.Errorf("list placements method error %w", model.ErrCatNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("list placements method error %w", err)
+	}
+
+	placements := document.Placements
+	if placements == nil {
+		placements = make([]*model.Placement, 0)
+	}
+	sort.SliceStable(placements, func(i, j int) bool {
+		return placements[i].StartedAt.Before(placements[j].StartedAt)
+	})
+
+	return placements, nil
+}
+
+// AddPlacement saves new active placement of the cat, the filter keeps the second active placement out
+func (c *FosterMongoRepository) AddPlacement(ctx context.Context, placement *model.Placement) error {
+	result, err := c.db.Collection("cat").UpdateOne(ctx,
+		bson.M{"_id": placement.CatID, "placements": bson.M{"$not": bson.M{"$elemMatch": bson.M{"ended_at": nil}}}},
+		bson.M{"$push": bson.M{"placements": placement}})
+	if err != nil {
+		return fmt.Errorf("add placement method error %w", err)
+	}
+	if result.MatchedCount > 0 {
+		return nil
+	}
+
+	count, err := c.db.Collection("cat").CountDocuments(ctx, bson.M{"_id": placement.CatID})
+	if err != nil {
+		return fmt.Errorf("add placement method error %w", err)
+	}
+	if count == 0 {
+		return fmt.Errorf("add placement method error %w", model.ErrCatNotFound)
+	}
+
+	return fmt.Errorf("add placement method error %w: cat has active placement", model.ErrConflict)
+}
+
+// EndPlacement marks the active placement of the cat as ended
+func (c *FosterMongoRepository) EndPlacement(ctx context.Context, catID, id uuid.UUID, endedAt time.Time, notes string) error {
+	result, err := c.db.Collection("cat").UpdateOne(ctx,
+		bson.M{"_id": catID, "placements": bson.M{"$elemMatch": bson.M{"_id": id, "ended_at": nil}}},
+		bson.M{"$set": bson.M{"placements.$.ended_at": endedAt, "placements.$.notes": notes}})
+	if err != nil {
+		return fmt.Errorf("end placement method error %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("end placement method error %w: placement is not active", model.ErrConflict)
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/catService/internal/model"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlacements(t *testing.T) {
+	requirePostgres(t)
+	cat := &model.Cat{ID: uuid.New(), Name: "Cat 14", Age: 2, Version: 1}
+	require.NoError(t, repository.Create(context.Background(), cat))
+	foster := &model.Foster{ID: uuid.New(), Name: "Anna", Contact: "+375291111111"}
+	require.NoError(t, fosters.Create(context.Background(), foster))
+
+	started := time.Now().UTC().Truncate(time.Millisecond).AddDate(0, -1, 0)
+	placement := &model.Placement{ID: uuid.New(), CatID: cat.ID, FosterID: foster.ID, StartedAt: started, Notes: "shy"}
+	require.NoError(t, fosters.AddPlacement(context.Background(), placement))
+	second := &model.Placement{ID: uuid.New(), CatID: cat.ID, FosterID: foster.ID, StartedAt: started}
+	require.ErrorIs(t, fosters.AddPlacement(context.Background(), second), model.ErrConflict)
+
+	inFoster := true
+	cats, err := repository.List(context.Background(), &model.CatQuery{CatFilter: model.CatFilter{InFoster: &inFoster}, Limit: 100}, nil)
+	require.NoError(t, err)
+	require.Contains(t, catIDs(cats), cat.ID)
+
+	require.ErrorIs(t, fosters.Delete(context.Background(), foster.ID), model.ErrConflict)
+
+	ended := started.AddDate(0, 0, 14)
+	require.NoError(t, fosters.EndPlacement(context.Background(), cat.ID, placement.ID, ended, "gained weight"))
+	err = fosters.EndPlacement(context.Background(), cat.ID, placement.ID, ended, "")
+	require.ErrorIs(t, err, model.ErrConflict)
+
+	placements, err := fosters.ListPlacements(context.Background(), cat.ID)
+	require.NoError(t, err)
+	require.Len(t, placements, 1)
+	require.Equal(t, "gained weight", placements[0].Notes)
+	require.True(t, ended.Equal(*placements[0].EndedAt))
+
+	cats, err = repository.List(context.Background(), &model.CatQuery{CatFilter: model.CatFilter{InFoster: &inFoster}, Limit: 100}, nil)
+	require.NoError(t, err)
+	require.NotContains(t, catIDs(cats), cat.ID)
+}
+
+func catIDs(cats []*model.Cat) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(cats))
+	for _, cat := range cats {
+		ids = append(ids, cat.ID)
+	}
+
+	return ids
+}
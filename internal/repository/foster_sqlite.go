@@ -0,0 +1,150 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/catService/internal/model"
+
+	"github.com/google/uuid"
+)
+
+// FosterSQLiteRepository contains a link to the SQLite database
+type FosterSQLiteRepository struct {
+	db *SQLiteDB
+}
+
+// NewFosterSQLite create new instance
+func NewFosterSQLite(db *SQLiteDB) *FosterSQLiteRepository {
+	return &FosterSQLiteRepository{db: db}
+}
+
+// Get returns foster
+func (r *FosterSQLiteRepository) Get(ctx context.Context, id uuid.UUID) (*model.Foster, error) {
+	row := r.db.QueryRow(ctx, "SELECT "+fosterColumns+" FROM fosters WHERE id = ?1", id)
+
+	foster, err := scanFoster(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("get method error %w", model.ErrFosterNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get method error %w", err)
+	}
+
+	return foster, nil
+}
+
+// Create new foster in db
+func (r *FosterSQLiteRepository) Create(ctx context.Context, foster *model.Foster) error {
+	_, err := r.db.Exec(ctx, "INSERT INTO fosters("+fosterColumns+") VALUES (?1,?2,?3,?4,?5)",
+		foster.ID, foster.Name, foster.Address, foster.Contact, foster.Notes)
+	if err != nil {
+		return fmt.Errorf("create method error %w", sqliteError(err))
+	}
+
+	return nil
+}
+
+// Update states for foster
+func (r *FosterSQLiteRepository) Update(ctx context.Context, foster *model.Foster) error {
+	affected, err := r.db.Exec(ctx, "UPDATE fosters SET name=?1, address=?2, contact=?3, notes=?4 WHERE id=?5",
+		foster.Name, foster.Address, foster.Contact, foster.Notes, foster.ID)
+	if err != nil {
+		return fmt.Errorf("update method error %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("update method error %w", model.ErrFosterNotFound)
+	}
+
+	return nil
+}
+
+// Delete removes foster, fosters with placements are protected by the foreign key
+func (r *FosterSQLiteRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	affected, err := r.db.Exec(ctx, "DELETE FROM fosters WHERE id = ?1", id)
+	if err != nil {
+		return fmt.Errorf("delete method error %w", sqliteError(err))
+	}
+	if affected == 0 {
+		return fmt.Errorf("delete method error %w", model.ErrFosterNotFound)
+	}
+
+	return nil
+}
+
+// List returns fosters in id order starting after the given one
+func (r *FosterSQLiteRepository) List(ctx context.Context, after *uuid.UUID, limit int) ([]*model.Foster, error) {
+	rows, err := r.db.Query(ctx, "SELECT "+fosterColumns+` FROM fosters
+		WHERE (?1 IS NULL OR id > ?1) ORDER BY id LIMIT ?2`, after, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list method error %w", err)
+	}
+	defer rows.Close()
+
+	fosters := make([]*model.Foster, 0, limit)
+	for rows.Next() {
+		foster, err := scanFoster(rows)
+		if err != nil {
+			return nil, fmt.Errorf("list method error %w", err)
+		}
+		fosters = append(fosters, foster)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list method error %w", err)
+	}
+
+	return fosters, nil
+}
+
+// ListPlacements returns placements of the cat in the order they started
+func (r *FosterSQLiteRepository) ListPlacements(ctx context.Context, catID uuid.UUID) ([]*model.Placement, error) {
+	rows, err := r.db.Query(ctx, `SELECT id, cat_id, foster_id, started_at, ended_at, notes FROM cat_placements
+		WHERE cat_id = ?1 ORDER BY started_at, id`, catID)
+	if err != nil {
+		return nil, fmt.Errorf("list placements method error %w", err)
+	}
+	defer rows.Close()
+
+	placements := make([]*model.Placement, 0)
+	for rows.Next() {
+		var placement model.Placement
+		err := rows.Scan(&placement.ID, &placement.CatID, &placement.FosterID, &placement.StartedAt, &placement.EndedAt, &placement.Notes)
+		if err != nil {
+			return nil, fmt.Errorf("list placements method error %w", err)
+		}
+		placements = append(placements, &placement)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list placements method error %w", err)
+	}
+
+	return placements, nil
+}
+
+// AddPlacement saves new active placement of the cat
+func (r *FosterSQLiteRepository) AddPlacement(ctx context.Context, placement *model.Placement) error {
+	_, err := r.db.Exec(ctx, `INSERT INTO cat_placements(id, cat_id, foster_id, started_at, notes) VALUES (?1,?2,?3,?4,?5)`,
+		placement.ID, placement.CatID, placement.FosterID, placement.StartedAt, placement.Notes)
+	if err != nil {
+		return fmt.Errorf("add placement method error %w", sqliteError(err))
+	}
+
+	return nil
+}
+
+// EndPlacement marks the active placement of the cat as ended
+func (r *FosterSQLiteRepository) EndPlacement(ctx context.Context, catID, id uuid.UUID, endedAt time.Time, notes string) error {
+	affected, err := r.db.Exec(ctx, `UPDATE cat_placements SET ended_at = ?3, notes = ?4
+		WHERE cat_id = ?1 AND id = ?2 AND ended_at IS NULL`, catID, id, endedAt, notes)
+	if err != nil {
+		return fmt.Errorf("end placement method error %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("end placement method error %w: placement is not active", model.ErrConflict)
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/catService/internal/model"
+
+	"github.com/google/uuid"
+)
+
+// IntakeMemoryRepository keeps intake records with the cat in the memory store
+type IntakeMemoryRepository struct {
+	store *MemoryStore
+}
+
+// NewIntakeMemory create new instance
+func NewIntakeMemory(store *MemoryStore) *IntakeMemoryRepository {
+	return &IntakeMemoryRepository{store: store}
+}
+
+// ListIntakes returns intake records of the cat with their outcomes in the order of intake
+func (r *IntakeMemoryRepository) ListIntakes(ctx context.Context, catID uuid.UUID) ([]*model.Intake, error) {
+	r.store.mutex.RLock()
+	defer r.store.mutex.RUnlock()
+
+	entry, exist := r.store.data.Cats[catID]
+	if !exist {
+		return nil, fmt.Errorf("list intakes method error %w", model.ErrCatNotFound)
+	}
+	intakes := make([]*model.Intake, 0, len(entry.Intakes))
+	for _, intake := range entry.Intakes {
+		copied := *intake
+		intakes = append(intakes, &copied)
+	}
+	sort.SliceStable(intakes, func(i, j int) bool {
+		return intakes[i].Date.Before(intakes[j].Date)
+	})
+
+	return intakes, nil
+}
+
+// AddIntake saves new open intake of the cat, the cat with open intake gets no second one
+func (r *IntakeMemoryRepository) AddIntake(ctx context.Context, catID uuid.UUID, intake *model.Intake) error {
+	r.store.mutex.Lock()
+	defer r.store.mutex.Unlock()
+
+	entry, exist := r.store.data.Cats[catID]
+	switch {
+	case !exist:
+		return fmt.Errorf("add intake method error %w", model.ErrCatNotFound)
+	case model.OpenIntake(entry.Intakes) != nil:
+		return fmt.Errorf("add intake method error %w: cat has open intake", model.ErrConflict)
+	}
+	copied := *intake
+	entry.Intakes = append(entry.Intakes, &copied)
+
+	return nil
+}
+
+// CloseIntake records the outcome of the open intake
+func (r *IntakeMemoryRepository) CloseIntake(ctx context.Context, catID, id uuid.UUID, outcome *model.Outcome) error {
+	r.store.mutex.Lock()
+	defer r.store.mutex.Unlock()
+
+	if entry, exist := r.store.data.Cats[catID]; exist {
+		for i, intake := range entry.Intakes {
+			if intake.ID == id && intake.Open() {
+				closed, copiedOutcome := *intake, *outcome
+				closed.Outcome = &copiedOutcome
+				entry.Intakes[i] = &closed
+				return nil
+			}
+		}
+	}
+
+	return fmt.Errorf("close intake method error %w: intake is not open", model.ErrConflict)
+}
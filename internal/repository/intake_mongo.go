@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/catService/internal/model"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// IntakeMongoRepository keeps intake records embedded into the cat document
+type IntakeMongoRepository struct {
+	db *mongo.Database
+}
+
+// NewIntakeMongo create new instance
+func NewIntakeMongo(database *mongo.Database) *IntakeMongoRepository {
+	return &IntakeMongoRepository{db: database}
+}
+
+// ListIntakes returns intake records of the cat with their outcomes in the order of intake
+func (c *IntakeMongoRepository) ListIntakes(ctx context.Context, catID uuid.UUID) ([]*model.Intake, error) {
+	var document struct {
+		Intakes []*model.Intake `bson:"intakes"`
+	}
+	opts := options.FindOne().SetProjection(bson.M{"intakes": 1})
+	err := c.db.Collection("cat").FindOne(ctx, bson.M{"_id": catID}, opts).Decode(&document)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, fmt.Errorf("list intakes method error %w", model.ErrCatNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("list intakes method error %w", err)
+	}
+
+	intakes := document.Intakes
+	if intakes == nil {
+		intakes = make([]*model.Intake, 0)
+	}
+	sort.SliceStable(intakes, func(i, j int) bool {
+		return intakes[i].Date.Before(intakes[j].Date)
+	})
+
+	return intakes, nil
+}
+
+// AddIntake saves new open intake of the cat, the filter keeps the second open intake out
+func (c *IntakeMongoRepository) AddIntake(ctx context.Context, catID uuid.UUID, intake *model.Intake) error {
+	result, err := c.db.Collection("cat").UpdateOne(ctx,
+		bson.M{"_id": catID, "intakes": bson.M{"$not": bson.M{"$elemMatch": bson.M{"outcome": nil}}}},
+		bson.M{"$push": bson.M{"intakes": intake}})
+	if err != nil {
+		return fmt.Errorf("add intake method error %w", err)
+	}
+	if result.MatchedCount > 0 {
+		return nil
+	}
+
+	count, err := c.db.Collection("cat").CountDocuments(ctx, bson.M{"_id": catID})
+	if err != nil {
+		return fmt.Errorf("add intake method error %w", err)
+	}
+	if count == 0 {
+		return fmt.Errorf("add intake method error %w", model.ErrCatNotFound)
+	}
+
+	return fmt.Errorf("add intake method error %w: cat has open intake", model.ErrConflict)
+}
+
+// CloseIntake records the outcome of the open intake
+func (c *IntakeMongoRepository) CloseIntake(ctx context.Context, catID, id uuid.UUID, outcome *model.Outcome) error {
+	result, err := c.db.Collection("cat").UpdateOne(ctx,
+		bson.M{"_id": catID, "intakes": bson.M{"$elemMatch": bson.M{"_id": id, "outcome": nil}}},
+		bson.M{"$set": bson.M{"intakes.$.outcome": outcome}})
+	if err != nil {
+		return fmt.Errorf("close intake method error %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("close intake method error %w: intake is not open", model.ErrConflict)
+	}
+
+	return nil
+}
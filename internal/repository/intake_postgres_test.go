@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/catService/internal/model"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntakes(t *testing.T) {
+	requirePostgres(t)
+	cat := &model.Cat{ID: uuid.New(), Name: "Cat 12", Age: 3, Version: 1}
+	require.NoError(t, repository.Create(context.Background(), cat))
+
+	date := time.Now().UTC().Truncate(time.Millisecond).AddDate(0, -3, 0)
+	first := &model.Intake{ID: uuid.New(), Type: model.IntakeStray, Date: date, Location: "Main street", Notes: "thin"}
+	require.NoError(t, intakes.AddIntake(context.Background(), cat.ID, first))
+	err := intakes.AddIntake(context.Background(), cat.ID, &model.Intake{ID: uuid.New(), Type: model.IntakeTransfer, Date: date})
+	require.ErrorIs(t, err, model.ErrConflict)
+
+	outcome := &model.Outcome{Type: model.OutcomeReturnToOwner, Date: date.AddDate(0, 1, 0), Notes: "owner found"}
+	require.NoError(t, intakes.CloseIntake(context.Background(), cat.ID, first.ID, outcome))
+	err = intakes.CloseIntake(context.Background(), cat.ID, first.ID, outcome)
+	require.ErrorIs(t, err, model.ErrConflict)
+
+	second := &model.Intake{ID: uuid.New(), Type: model.IntakeOwnerSurrender, Date: date.AddDate(0, 2, 0)}
+	require.NoError(t, intakes.AddIntake(context.Background(), cat.ID, second))
+
+	records, err := intakes.ListIntakes(context.Background(), cat.ID)
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	require.Equal(t, first.ID, records[0].ID)
+	require.Equal(t, "thin", records[0].Notes)
+	require.Equal(t, model.OutcomeReturnToOwner, records[0].Outcome.Type)
+	require.True(t, outcome.Date.Equal(records[0].Outcome.Date))
+	require.True(t, records[1].Open())
+}
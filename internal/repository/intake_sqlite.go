@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/catService/internal/model"
+
+	"github.com/google/uuid"
+)
+
+// IntakeSQLiteRepository contains a link to the SQLite database
+type IntakeSQLiteRepository struct {
+	db *SQLiteDB
+}
+
+// NewIntakeSQLite create new instance
+func NewIntakeSQLite(db *SQLiteDB) *IntakeSQLiteRepository {
+	return &IntakeSQLiteRepository{db: db}
+}
+
+// ListIntakes returns intake records of the cat with their outcomes in the order of intake
+func (r *IntakeSQLiteRepository) ListIntakes(ctx context.Context, catID uuid.UUID) ([]*model.Intake, error) {
+	rows, err := r.db.Query(ctx, `SELECT id, type, date, location, notes,
+		outcome_type, outcome_date, outcome_location, outcome_notes FROM cat_intakes
+		WHERE cat_id = ?1 ORDER BY date, id`, catID)
+	if err != nil {
+		return nil, fmt.Errorf("list intakes method error %w", err)
+	}
+	defer rows.Close()
+
+	intakes := make([]*model.Intake, 0)
+	for rows.Next() {
+		var intake model.Intake
+		var outcomeType, outcomeLocation, outcomeNotes *string
+		var outcomeDate *time.Time
+		err := rows.Scan(&intake.ID, &intake.Type, &intake.Date, &intake.Location, &intake.Notes,
+			&outcomeType, &outcomeDate, &outcomeLocation, &outcomeNotes)
+		if err != nil {
+			return nil, fmt.Errorf("list intakes method error %w", err)
+		}
+		if outcomeType != nil {
+			intake.Outcome = &model.Outcome{Type: *outcomeType, Date: *outcomeDate, Location: *outcomeLocation, Notes: *outcomeNotes}
+		}
+		intakes = append(intakes, &intake)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list intakes method error %w", err)
+	}
+
+	return intakes, nil
+}
+
+// AddIntake saves new open intake of the cat
+func (r *IntakeSQLiteRepository) AddIntake(ctx context.Context, catID uuid.UUID, intake *model.Intake) error {
+	_, err := r.db.Exec(ctx, `INSERT INTO cat_intakes(id, cat_id, type, date, location, notes) VALUES (?1,?2,?3,?4,?5,?6)`,
+		intake.ID, catID, intake.Type, intake.Date, intake.Location, intake.Notes)
+	if err != nil {
+		return fmt.Errorf("add intake method error %w", sqliteError(err))
+	}
+
+	return nil
+}
+
+// CloseIntake records the outcome of the open intake
+func (r *IntakeSQLiteRepository) CloseIntake(ctx context.Context, catID, id uuid.UUID, outcome *model.Outcome) error {
+	affected, err := r.db.Exec(ctx, `UPDATE cat_intakes SET outcome_type = ?3, outcome_date = ?4, outcome_location = ?5, outcome_notes = ?6
+		WHERE cat_id = ?1 AND id = ?2 AND outcome_type IS NULL`,
+		catID, id, outcome.Type, outcome.Date, outcome.Location, outcome.Notes)
+	if err != nil {
+		return fmt.Errorf("close intake method error %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("close intake method error %w: intake is not open", model.ErrConflict)
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/catService/internal/model"
+)
+
+// memoryLock is the lock held by the owner until expiry
+type memoryLock struct {
+	owner  string
+	expiry time.Time
+}
+
+// JobMemoryRepository keeps job locks and runs in the process, it serves the single replica without redis
+type JobMemoryRepository struct {
+	mutex sync.Mutex
+	locks map[string]memoryLock
+	runs  map[string][]*model.JobRun
+}
+
+// NewJobMemory create new instance
+func NewJobMemory() *JobMemoryRepository {
+	return &JobMemoryRepository{
+		locks: make(map[string]memoryLock),
+		runs:  make(map[string][]*model.JobRun),
+	}
+}
+
+// Lock takes the lock for the owner unless somebody holds it, the lock is released after ttl
+func (r *JobMemoryRepository) Lock(ctx context.Context, key, owner string, ttl time.Duration) (bool, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	now := time.Now()
+	if lock, exist := r.locks[key]; exist && now.Before(lock.expiry) {
+		return false, nil
+	}
+	r.locks[key] = memoryLock{owner: owner, expiry: now.Add(ttl)}
+
+	return true, nil
+}
+
+// Renew extends the lock held by the owner to ttl from now, false means the owner lost the lock
+func (r *JobMemoryRepository) Renew(ctx context.Context, key, owner string, ttl time.Duration) (bool, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	now := time.Now()
+	lock, exist := r.locks[key]
+	if !exist || lock.owner != owner || !now.Before(lock.expiry) {
+		return false, nil
+	}
+	r.locks[key] = memoryLock{owner: owner, expiry: now.Add(ttl)}
+
+	return true, nil
+}
+
+// AddRun saves the run of the job, only the latest keep runs of the job are kept
+func (r *JobMemoryRepository) AddRun(ctx context.Context, run *model.JobRun, keep int) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	copied := *run
+	runs := append([]*model.JobRun{&copied}, r.runs[run.Job]...)
+	if len(runs) > keep {
+		runs = runs[:keep]
+	}
+	r.runs[run.Job] = runs
+
+	return nil
+}
+
+// ListRuns returns the latest runs of the job, the last run goes first
+func (r *JobMemoryRepository) ListRuns(ctx context.Context, job string, limit int) ([]*model.JobRun, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	stored := r.runs[job]
+	if len(stored) > limit {
+		stored = stored[:limit]
+	}
+	runs := make([]*model.JobRun, 0, len(stored))
+	for _, run := range stored {
+		copied := *run
+		runs = append(runs, &copied)
+	}
+
+	return runs, nil
+}
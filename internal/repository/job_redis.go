@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/catService/internal/model"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// Redis key prefixes of the scheduled jobs
+const (
+	jobLockPrefix = "jobs:lock:"
+	jobRunsPrefix = "jobs:runs:"
+)
+
+// renewScript extends the lock only if the owner still holds it
+var renewScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0`)
+
+// JobRedisRepository keeps job locks and runs in redis, so all replicas see them
+type JobRedisRepository struct {
+	client *redis.Client
+}
+
+// NewJobRedis create new instance
+func NewJobRedis(client *redis.Client) *JobRedisRepository {
+	return &JobRedisRepository{client: client}
+}
+
+// Lock takes the lock for the owner unless somebody holds it, the lock is released after ttl
+func (r *JobRedisRepository) Lock(ctx context.Context, key, owner string, ttl time.Duration) (bool, error) {
+	locked, err := r.client.SetNX(ctx, jobLockPrefix+key, owner, ttl).Result()
+	if err != nil {
+		return false, fmt.Errorf("lock method error %w", err)
+	}
+
+	return locked, nil
+}
+
+// Renew extends the lock held by the owner to ttl from now, false means the owner lost the lock
+func (r *JobRedisRepository) Renew(ctx context.Context, key, owner string, ttl time.Duration) (bool, error) {
+	renewed, err := renewScript.Run(ctx, r.client, []string{jobLockPrefix + key}, owner, ttl.Milliseconds()).Int()
+	if err != nil {
+		return false, fmt.Errorf("renew method error %w", err)
+	}
+
+	return renewed == 1, nil
+}
+
+// AddRun saves the run of the job, only the latest keep runs of the job are kept
+func (r *JobRedisRepository) AddRun(ctx context.Context, run *model.JobRun, keep int) error {
+	data, err := json.Marshal(run)
+	if err != nil {
+		return fmt.Errorf("add run method error %w", err)
+	}
+
+	key := jobRunsPrefix + run.Job
+	_, err = r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.LPush(ctx, key, data)
+		pipe.LTrim(ctx, key, 0, int64(keep-1))
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("add run method error %w", err)
+	}
+
+	return nil
+}
+
+// ListRuns returns the latest runs of the job, the last run goes first
+func (r *JobRedisRepository) ListRuns(ctx context.Context, job string, limit int) ([]*model.JobRun, error) {
+	values, err := r.client.LRange(ctx, jobRunsPrefix+job, 0, int64(limit-1)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("list runs method error %w", err)
+	}
+
+	runs := make([]*model.JobRun, 0, len(values))
+	for _, value := range values {
+		var run model.JobRun
+		if err := json.Unmarshal([]byte(value), &run); err != nil {
+			return nil, fmt.Errorf("list runs method error %w", err)
+		}
+		runs = append(runs, &run)
+	}
+
+	return runs, nil
+}
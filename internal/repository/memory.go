@@ -0,0 +1,150 @@
+package repository
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/catService/internal/model"
+
+	"github.com/google/uuid"
+)
+
+// MemoryStore keeps the data of all memory repositories behind one lock,
+// so the whole state can be saved to the JSON snapshot and loaded from it.
+// Values are copied on the way in and out, the values they point to are never changed in place
+type MemoryStore struct {
+	mutex sync.RWMutex
+	data  memoryData
+}
+
+// memoryData is the content of the store and of its snapshot
+type memoryData struct {
+	Cats     map[uuid.UUID]*memoryCat
+	Events   []*model.CatEvent
+	Shelters map[uuid.UUID]*model.Shelter
+	Fosters  map[uuid.UUID]*model.Foster
+	Adopters map[uuid.UUID]*model.Adopter
+	// Outbox keeps the messages in the order they were written
+	Outbox []*model.OutboxMessage
+}
+
+// memoryCat is the cat with its records, they go away with the cat like the embedded arrays of the mongo document
+type memoryCat struct {
+	Cat          *model.Cat
+	Vaccinations []*model.Vaccination
+	Photos       []*model.Photo
+	Intakes      []*model.Intake
+	Placements   []*model.Placement
+}
+
+// NewMemoryStore creates empty store
+func NewMemoryStore() *MemoryStore {
+	store := &MemoryStore{}
+	store.data.init()
+
+	return store
+}
+
+// init creates the missing maps
+func (d *memoryData) init() {
+	if d.Cats == nil {
+		d.Cats = make(map[uuid.UUID]*memoryCat)
+	}
+	if d.Shelters == nil {
+		d.Shelters = make(map[uuid.UUID]*model.Shelter)
+	}
+	if d.Fosters == nil {
+		d.Fosters = make(map[uuid.UUID]*model.Foster)
+	}
+	if d.Adopters == nil {
+		d.Adopters = make(map[uuid.UUID]*model.Adopter)
+	}
+}
+
+// Load replaces the content of the store with the snapshot file, missing file leaves the store empty
+func (s *MemoryStore) Load(path string) error {
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("load snapshot error %w", err)
+	}
+
+	var data memoryData
+	if err := json.Unmarshal(content, &data); err != nil {
+		return fmt.Errorf("load snapshot error %w", err)
+	}
+	data.init()
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.data = data
+
+	return nil
+}
+
+// Save writes the content of the store to the snapshot file.
+// The file is replaced by rename, so the previous snapshot survives a failed save
+func (s *MemoryStore) Save(path string) error {
+	s.mutex.RLock()
+	content, err := json.Marshal(&s.data)
+	s.mutex.RUnlock()
+	if err != nil {
+		return fmt.Errorf("save snapshot error %w", err)
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("save snapshot error %w", err)
+	}
+	defer os.Remove(file.Name())
+	if _, err := file.Write(content); err != nil {
+		file.Close()
+		return fmt.Errorf("save snapshot error %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("save snapshot error %w", err)
+	}
+	if err := os.Rename(file.Name(), path); err != nil {
+		return fmt.Errorf("save snapshot error %w", err)
+	}
+
+	return nil
+}
+
+// copyCat returns the copy of the cat without photos, they are kept apart
+func copyCat(cat *model.Cat) *model.Cat {
+	copied := *cat
+	copied.Photos = nil
+
+	return &copied
+}
+
+// compareIDs orders IDs by their bytes like both databases do
+func compareIDs(a, b uuid.UUID) int {
+	return bytes.Compare(a[:], b[:])
+}
+
+// pageIDs sorts the IDs and returns at most limit of them which go after the given one, nil starts from the beginning
+func pageIDs(ids []uuid.UUID, after *uuid.UUID, limit int) []uuid.UUID {
+	sort.Slice(ids, func(i, j int) bool {
+		return compareIDs(ids[i], ids[j]) < 0
+	})
+	if after != nil {
+		ids = ids[sort.Search(len(ids), func(i int) bool {
+			return compareIDs(ids[i], *after) > 0
+		}):]
+	}
+	if len(ids) > limit {
+		ids = ids[:limit]
+	}
+
+	return ids
+}
@@ -0,0 +1,202 @@
+package repository
+
+import (
+	"context"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/catService/internal/model"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryCats(t *testing.T) {
+	store := NewMemoryStore()
+	cats := NewMemoryRepository(store)
+
+	chip := "900000000000001"
+	cat := &model.Cat{ID: uuid.New(), Name: "Cat 1", Age: 2, Version: 1, CatProfile: model.CatProfile{Microchip: &chip}}
+	require.NoError(t, cats.Create(context.Background(), cat))
+	other := &model.Cat{ID: uuid.New(), Name: "Cat 2", Age: 1, Version: 1, CatProfile: model.CatProfile{Microchip: &chip}}
+	require.ErrorIs(t, cats.Create(context.Background(), other), model.ErrConflict)
+	other.Microchip = nil
+	require.NoError(t, cats.CreateMany(context.Background(), []*model.Cat{other}))
+
+	found, err := cats.GetByMicrochip(context.Background(), chip)
+	require.NoError(t, err)
+	require.Equal(t, cat.ID, found.ID)
+	found.Name = "Changed"
+	found, err = cats.Get(context.Background(), cat.ID)
+	require.NoError(t, err)
+	require.Equal(t, "Cat 1", found.Name)
+
+	vaccinated := true
+	version, err := cats.Patch(context.Background(), cat.ID, 1, &model.CatPatch{Vaccinated: &vaccinated})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), version)
+	_, err = cats.Patch(context.Background(), cat.ID, 1, &model.CatPatch{Vaccinated: &vaccinated})
+	require.ErrorIs(t, err, model.ErrVersionMismatch)
+
+	query := &model.CatQuery{SortBy: model.CatSortAge, Desc: true, Limit: 1}
+	page, err := cats.List(context.Background(), query, nil)
+	require.NoError(t, err)
+	require.Equal(t, []uuid.UUID{cat.ID}, catIDs(page))
+	page, err = cats.List(context.Background(), query, page[0])
+	require.NoError(t, err)
+	require.Equal(t, []uuid.UUID{other.ID}, catIDs(page))
+
+	require.NoError(t, cats.Delete(context.Background(), cat.ID, version))
+	_, err = cats.Get(context.Background(), cat.ID)
+	require.ErrorIs(t, err, model.ErrCatNotFound)
+	restored, err := cats.Restore(context.Background(), cat.ID, model.AnyVersion)
+	require.NoError(t, err)
+	require.Nil(t, restored.DeletedAt)
+	require.Equal(t, int64(4), restored.Version)
+
+	require.NoError(t, cats.Delete(context.Background(), other.ID, model.AnyVersion))
+	purged, err := cats.Purge(context.Background(), time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, []uuid.UUID{other.ID}, purged)
+}
+
+func TestMemorySnapshot(t *testing.T) {
+	store := NewMemoryStore()
+	shelter := &model.Shelter{ID: uuid.New(), Name: "Shelter 1", Capacity: 10}
+	require.NoError(t, NewShelterMemoryRepository(store).Create(context.Background(), shelter))
+	cat := &model.Cat{ID: uuid.New(), Name: "Cat 1", Age: 2, Version: 1, ShelterID: &shelter.ID}
+	require.NoError(t, NewMemoryRepository(store).Create(context.Background(), cat))
+	intake := &model.Intake{ID: uuid.New(), Type: model.IntakeStray, Date: time.Now().UTC().AddDate(0, 0, -3)}
+	require.NoError(t, NewIntakeMemoryRepository(store).AddIntake(context.Background(), cat.ID, intake))
+
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+	require.NoError(t, store.Save(path))
+
+	loaded := NewMemoryStore()
+	require.NoError(t, loaded.Load(path))
+	found, err := NewMemoryRepository(loaded).Get(context.Background(), cat.ID)
+	require.NoError(t, err)
+	require.Equal(t, cat.Name, found.Name)
+	require.Equal(t, shelter.ID, *found.ShelterID)
+	intakes, err := NewIntakeMemoryRepository(loaded).ListIntakes(context.Background(), cat.ID)
+	require.NoError(t, err)
+	require.Len(t, intakes, 1)
+	require.True(t, intake.Date.Equal(intakes[0].Date))
+	err = NewShelterMemoryRepository(loaded).Delete(context.Background(), shelter.ID)
+	require.ErrorIs(t, err, model.ErrConflict)
+
+	empty := NewMemoryStore()
+	require.NoError(t, empty.Load(filepath.Join(t.TempDir(), "missing.json")))
+}
+
+func TestJobMemory(t *testing.T) {
+	jobs := NewJobMemoryRepository()
+
+	locked, err := jobs.Lock(context.Background(), "relay", "replica-1", time.Hour)
+	require.NoError(t, err)
+	require.True(t, locked)
+	locked, err = jobs.Lock(context.Background(), "relay", "replica-2", time.Hour)
+	require.NoError(t, err)
+	require.False(t, locked)
+	renewed, err := jobs.Renew(context.Background(), "relay", "replica-2", time.Hour)
+	require.NoError(t, err)
+	require.False(t, renewed)
+	renewed, err = jobs.Renew(context.Background(), "relay", "replica-1", -time.Second)
+	require.NoError(t, err)
+	require.True(t, renewed)
+	locked, err = jobs.Lock(context.Background(), "relay", "replica-2", time.Hour)
+	require.NoError(t, err)
+	require.True(t, locked)
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, jobs.AddRun(context.Background(), &model.JobRun{ID: uuid.New(), Job: "purge", Result: strconv.Itoa(i)}, 2))
+	}
+	runs, err := jobs.ListRuns(context.Background(), "purge", 10)
+	require.NoError(t, err)
+	require.Len(t, runs, 2)
+	require.Equal(t, "2", runs[0].Result)
+}
+
+func TestProcessCache(t *testing.T) {
+	cache := NewProcessCacheRepository()
+	cat := &model.Cat{ID: uuid.New(), Name: "Cat 1", Age: 2}
+	message, err := model.NewCatMessage(model.OutboxCreate, cat)
+	require.NoError(t, err)
+	require.NoError(t, cache.Publish(context.Background(), message))
+	cached, err := cache.Get(cat.ID)
+	require.NoError(t, err)
+	require.Equal(t, cat.Name, cached.Name)
+
+	require.NoError(t, cache.Publish(context.Background(), model.NewDeleteMessage(cat.ID)))
+	_, err = cache.Get(cat.ID)
+	require.Error(t, err)
+}
+
+func TestCacheApply(t *testing.T) {
+	cache := NewProcessCache()
+	cat := &model.Cat{ID: uuid.New(), Name: "Cat 1", Age: 2}
+	message, err := model.NewCatMessage(model.OutboxCreate, cat)
+	require.NoError(t, err)
+
+	lastID := cache.apply([]redis.XMessage{
+		{ID: "1-0", Values: map[string]interface{}{message.Action: message.Payload}},
+		{ID: "2-0", Values: map[string]interface{}{model.OutboxDelete: uuid.NewString()}},
+	}, "0-0")
+	require.Equal(t, "2-0", lastID)
+	_, err = cache.Get(cat.ID)
+	require.NoError(t, err)
+	require.Equal(t, "2-0", cache.apply(nil, lastID))
+}
+
+func TestMemoryPhotoLimit(t *testing.T) {
+	store := NewMemoryStore()
+	testPhotoLimit(t, NewMemoryRepository(store), NewPhotoMemoryRepository(store))
+}
+
+// testPhotoLimit checks the photo over the limit and the photo of the missing cat aren't saved
+func testPhotoLimit(t *testing.T, cats SheltersCatRepository, photos PhotoRepository) {
+	cat := &model.Cat{ID: uuid.New(), Name: "Cat 1", Age: 2, Status: model.CatAvailable, Version: 1}
+	require.NoError(t, cats.Create(context.Background(), cat))
+	newPhoto := func() *model.Photo {
+		return &model.Photo{ID: uuid.New(), ContentType: "image/png", Size: 10, Width: 1, Height: 1,
+			CreatedAt: time.Now().UTC().Truncate(time.Millisecond)}
+	}
+
+	for i := 0; i < 2; i++ {
+		require.NoError(t, photos.AddPhoto(context.Background(), cat.ID, newPhoto(), 2))
+	}
+	err := photos.AddPhoto(context.Background(), cat.ID, newPhoto(), 2)
+	require.ErrorIs(t, err, model.ErrConflict)
+	listed, err := photos.ListPhotos(context.Background(), cat.ID)
+	require.NoError(t, err)
+	require.Len(t, listed, 2)
+
+	err = photos.AddPhoto(context.Background(), uuid.New(), newPhoto(), 2)
+	require.ErrorIs(t, err, model.ErrCatNotFound)
+}
+
+func TestMemoryCandidateFilter(t *testing.T) {
+	testCandidateFilter(t, NewMemoryRepository(NewMemoryStore()))
+}
+
+// testCandidateFilter checks the filter of the cats suggested to adopters
+func testCandidateFilter(t *testing.T, cats SheltersCatRepository) {
+	yes, no := true, false
+	good := &model.Cat{ID: uuid.New(), Name: "Good", Age: 2, Status: model.CatAvailable, Version: 1,
+		CatProfile: model.CatProfile{Sex: model.CatFemale, GoodWithKids: &yes}}
+	unknown := &model.Cat{ID: uuid.New(), Name: "Unknown", Age: 2, Status: model.CatAvailable, Version: 1,
+		CatProfile: model.CatProfile{Sex: model.CatFemale}}
+	bad := &model.Cat{ID: uuid.New(), Name: "Bad", Age: 2, Status: model.CatAvailable, Version: 1,
+		CatProfile: model.CatProfile{Sex: model.CatFemale, GoodWithKids: &no}}
+	male := &model.Cat{ID: uuid.New(), Name: "Male", Age: 2, Status: model.CatAvailable, Version: 1,
+		CatProfile: model.CatProfile{Sex: model.CatMale}}
+	require.NoError(t, cats.CreateMany(context.Background(), []*model.Cat{good, unknown, bad, male}))
+
+	query := &model.CatQuery{CatFilter: model.CatFilter{Sex: model.CatFemale, NotBadWithKids: true}, SortBy: model.CatSortName, Limit: 10}
+	page, err := cats.List(context.Background(), query, nil)
+	require.NoError(t, err)
+	require.Equal(t, []uuid.UUID{good.ID, unknown.ID}, catIDs(page))
+}
@@ -0,0 +1,270 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/catService/internal/model"
+
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// mongoNamespaceExists is the code of the error creating collection which exists
+const mongoNamespaceExists = 48
+
+// Mongo migration lease settings
+const (
+	// mongoMigrationLease is how long the migration may run before another replica retries it
+	mongoMigrationLease = 10 * time.Minute
+	// mongoMigrationPoll is how often the replica checks the migration running on another replica
+	mongoMigrationPoll = time.Second
+)
+
+// mongoMigration is the versioned change of the stored documents.
+// It must be safe to run again, the migration failed halfway is retried on the next start
+type mongoMigration struct {
+	version     int
+	description string
+	up          func(ctx context.Context, database *mongo.Database) error
+}
+
+// mongoMigrations are applied in the version order, the applied ones are recorded in the migrations collection
+var mongoMigrations = []*mongoMigration{
+	{
+		version:     1,
+		description: "Backfill cat status",
+		up: func(ctx context.Context, database *mongo.Database) error {
+			// cats stored before statuses appeared have no status
+			_, err := database.Collection("cat").UpdateMany(ctx, bson.M{"status": nil},
+				bson.M{"$set": bson.M{"status": model.CatAvailable}})
+			return err
+		},
+	},
+	{
+		version:     2,
+		description: "Backfill cat version",
+		up: func(ctx context.Context, database *mongo.Database) error {
+			// cats stored before versions appeared start with the first one like in Postgres
+			_, err := database.Collection("cat").UpdateMany(ctx, bson.M{"version": nil},
+				bson.M{"$set": bson.M{"version": 1}})
+			return err
+		},
+	},
+}
+
+// mongoMigrationRecord is the document of the migrations collection
+type mongoMigrationRecord struct {
+	Version     int        `bson:"_id"`
+	Description string     `bson:"description"`
+	StartedAt   time.Time  `bson:"started_at"`
+	AppliedAt   *time.Time `bson:"applied_at"`
+}
+
+// BootstrapMongo prepares the database for the mongo repositories: it checks the server runs transactions,
+// creates the cat collection with the validator of the cat documents, applies the pending migrations
+// and creates the indexes and the outbox collection
+func BootstrapMongo(ctx context.Context, database *mongo.Database) error {
+	if err := requireMongoTransactions(ctx, database); err != nil {
+		return fmt.Errorf("bootstrap mongo error %w", err)
+	}
+	if err := ensureMongoCollection(ctx, database, "cat", catValidator()); err != nil {
+		return fmt.Errorf("bootstrap mongo error %w", err)
+	}
+	if err := applyMongoMigrations(ctx, database, mongoMigrations); err != nil {
+		return fmt.Errorf("bootstrap mongo error %w", err)
+	}
+	if err := CreateCatMongoIndexes(ctx, database); err != nil {
+		return fmt.Errorf("bootstrap mongo error %w", err)
+	}
+	if err := CreateCatEventMongoIndexes(ctx, database); err != nil {
+		return fmt.Errorf("bootstrap mongo error %w", err)
+	}
+	if err := CreateOutboxMongoIndexes(ctx, database); err != nil {
+		return fmt.Errorf("bootstrap mongo error %w", err)
+	}
+
+	return nil
+}
+
+// requireMongoTransactions fails unless the server is a replica set member or a sharded cluster router.
+// Every cat change writes the outbox in a transaction, a standalone server rejects them
+func requireMongoTransactions(ctx context.Context, database *mongo.Database) error {
+	var reply struct {
+		SetName string `bson:"setName"`
+		Msg     string `bson:"msg"`
+	}
+	if err := database.RunCommand(ctx, bson.D{{Key: "isMaster", Value: 1}}).Decode(&reply); err != nil {
+		return err
+	}
+	if reply.SetName == "" && reply.Msg != "isdbgrid" {
+		return errors.New("mongo transactions need a replica set or a sharded cluster, " +
+			"start the standalone server as a single node replica set with --replSet and rs.initiate()")
+	}
+
+	return nil
+}
+
+// ensureMongoCollection creates the collection with the validator or replaces the validator of the existing one.
+// Moderate validation checks inserts and updates of valid documents, so old documents don't block updates
+func ensureMongoCollection(ctx context.Context, database *mongo.Database, name string, validator bson.M) error {
+	names, err := database.ListCollectionNames(ctx, bson.M{"name": name})
+	if err != nil {
+		return err
+	}
+	if len(names) == 0 {
+		err := database.CreateCollection(ctx, name, options.CreateCollection().
+			SetValidator(validator).SetValidationLevel("moderate").SetValidationAction("error"))
+		var commandErr mongo.CommandError
+		// another replica may create the collection meanwhile, its validator is updated then
+		if !errors.As(err, &commandErr) || commandErr.Code != mongoNamespaceExists {
+			return err
+		}
+	}
+
+	return database.RunCommand(ctx, bson.D{
+		{Key: "collMod", Value: name},
+		{Key: "validator", Value: validator},
+		{Key: "validationLevel", Value: "moderate"},
+		{Key: "validationAction", Value: "error"},
+	}).Err()
+}
+
+// applyMongoMigrations applies the migrations which aren't recorded as applied in the migrations collection.
+// The replica which runs the migration holds its record as the lease, the other replicas wait until
+// the migration is applied. The lease of the replica stopped halfway expires, so the migration is retried then.
+// The record of the failed migration is removed to retry it on the next start
+func applyMongoMigrations(ctx context.Context, database *mongo.Database, migrations []*mongoMigration) error {
+	records := database.Collection("migrations")
+	for _, migration := range migrations {
+		startedAt, err := claimMongoMigration(ctx, records, migration)
+		if err != nil {
+			return fmt.Errorf("migration %d error %w", migration.version, err)
+		}
+		if startedAt.IsZero() {
+			continue
+		}
+
+		if err := migration.up(ctx, database); err != nil {
+			_, deleteErr := records.DeleteOne(context.Background(), bson.M{"_id": migration.version, "started_at": startedAt})
+			if deleteErr != nil {
+				return fmt.Errorf("migration %d error %v, its record wasn't removed: %w", migration.version, err, deleteErr)
+			}
+			return fmt.Errorf("migration %d error %w", migration.version, err)
+		}
+		_, err = records.UpdateOne(ctx, bson.M{"_id": migration.version}, bson.M{"$set": bson.M{"applied_at": time.Now()}})
+		if err != nil {
+			return fmt.Errorf("migration %d error %w", migration.version, err)
+		}
+	}
+
+	return nil
+}
+
+// claimMongoMigration takes the lease of the migration and returns its start time,
+// the zero time means the migration is already applied. It waits while another replica runs the migration
+func claimMongoMigration(ctx context.Context, records *mongo.Collection, migration *mongoMigration) (time.Time, error) {
+	for {
+		// mongo keeps milliseconds, the start time identifies the lease of this replica
+		startedAt := time.Now().UTC().Truncate(time.Millisecond)
+		filter := bson.M{"_id": migration.version, "applied_at": nil, "started_at": bson.M{"$lt": startedAt.Add(-mongoMigrationLease)}}
+		update := bson.M{"$set": bson.M{"description": migration.description, "started_at": startedAt}}
+		_, err := records.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+		if err == nil {
+			return startedAt, nil
+		}
+		// the record exists, but it's applied or leased to another replica
+		if !mongo.IsDuplicateKeyError(err) {
+			return time.Time{}, err
+		}
+
+		var record mongoMigrationRecord
+		if err := records.FindOne(ctx, bson.M{"_id": migration.version}).Decode(&record); err != nil {
+			return time.Time{}, err
+		}
+		if record.AppliedAt != nil {
+			return time.Time{}, nil
+		}
+		logrus.Infof("migration %d runs on another replica since %s, waiting", migration.version, record.StartedAt)
+		select {
+		case <-ctx.Done():
+			return time.Time{}, ctx.Err()
+		case <-time.After(mongoMigrationPoll):
+		}
+	}
+}
+
+// catValidator is the JSON schema of the cat documents, it follows model.Cat and the records kept in the document
+func catValidator() bson.M {
+	integer := bsonType("int", "long")
+	date, optionalDate := bsonType("date"), bsonType("date", "null")
+	text, flag := bsonType("string"), bsonType("bool")
+
+	return bson.M{"$jsonSchema": bson.M{
+		"bsonType": "object",
+		"required": bson.A{"_id", "name", "age", "vaccinated", "status", "version"},
+		"properties": bson.M{
+			"_id":                  bsonType("binData"),
+			"name":                 text,
+			"age":                  bson.M{"bsonType": bson.A{"int", "long"}, "minimum": 0},
+			"vaccinated":           flag,
+			"shelter_id":           bsonType("binData", "null"),
+			"status":               bson.M{"enum": bson.A{model.CatAvailable, model.CatReserved, model.CatAdopted, model.CatReturned}},
+			"birth_date":           optionalDate,
+			"birth_date_estimated": flag,
+			"sex":                  bson.M{"enum": bson.A{"", model.CatMale, model.CatFemale}},
+			"breed":                text,
+			"coat_color":           text,
+			"neutered":             bsonType("bool", "null"),
+			"intake_date":          optionalDate,
+			"microchip":            bsonType("string", "null"),
+			"good_with_kids":       bsonType("bool", "null"),
+			"version":              integer,
+			"deleted_at":           optionalDate,
+			"vaccinations": documents(bson.A{"_id", "vaccine", "administered_at"}, bson.M{
+				"vaccine":         text,
+				"administered_at": date,
+				"expires_at":      optionalDate,
+			}),
+			"photos": documents(bson.A{"_id", "content_type", "created_at"}, bson.M{
+				"content_type": text,
+				"size":         integer,
+				"created_at":   date,
+			}),
+			"intakes": documents(bson.A{"_id", "type", "date"}, bson.M{
+				"type": text,
+				"date": date,
+				"outcome": bson.M{"bsonType": bson.A{"object", "null"}, "required": bson.A{"type", "date"},
+					"properties": bson.M{"type": text, "date": date}},
+			}),
+			"placements": documents(bson.A{"_id", "foster_id", "started_at"}, bson.M{
+				"foster_id":  bsonType("binData"),
+				"started_at": date,
+				"ended_at":   optionalDate,
+			}),
+		},
+	}}
+}
+
+// bsonType is the schema of the value of one of the BSON types
+func bsonType(types ...string) bson.M {
+	if len(types) == 1 {
+		return bson.M{"bsonType": types[0]}
+	}
+
+	return bson.M{"bsonType": types}
+}
+
+// documents is the schema of the array of documents with binary IDs
+func documents(required bson.A, properties bson.M) bson.M {
+	properties["_id"] = bsonType("binData")
+
+	return bson.M{
+		"bsonType": "array",
+		"items":    bson.M{"bsonType": "object", "required": required, "properties": properties},
+	}
+}
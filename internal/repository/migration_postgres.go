@@ -0,0 +1,392 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"hash/crc32"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/catService/internal/model"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// migrationLockID is the advisory lock taken while migrations run, so replicas starting together don't race
+const migrationLockID = 7312604
+
+// migrationName matches the flyway names of versioned migrations and their undo scripts
+var migrationName = regexp.MustCompile(`^([VU])(\d+)__(.+)\.sql$`)
+
+// flywayHistory is the flyway schema history table, flyway and the migrator share it
+const flywayHistory = `CREATE TABLE IF NOT EXISTS flyway_schema_history
+(
+    installed_rank integer       NOT NULL,
+    version        varchar(50),
+    description    varchar(200)  NOT NULL,
+    type           varchar(20)   NOT NULL,
+    script         varchar(1000) NOT NULL,
+    checksum       integer,
+    installed_by   varchar(100)  NOT NULL,
+    installed_on   timestamp     NOT NULL DEFAULT now(),
+    execution_time integer       NOT NULL,
+    success        boolean       NOT NULL,
+    CONSTRAINT flyway_schema_history_pk PRIMARY KEY (installed_rank)
+);
+CREATE INDEX IF NOT EXISTS flyway_schema_history_s_idx ON flyway_schema_history (success);`
+
+// appliedMigration is the row of the flyway schema history
+type appliedMigration struct {
+	rank        int
+	version     *string
+	description string
+	kind        string
+	checksum    *int32
+	installedOn time.Time
+	success     bool
+}
+
+// LoadMigrations reads V<version>__<description>.sql migrations with their U<version>__<description>.sql undo scripts
+// and returns them ordered by version, other files are skipped
+func LoadMigrations(fsys fs.FS) ([]*model.Migration, error) {
+	files, err := fs.Glob(fsys, "*.sql")
+	if err != nil {
+		return nil, fmt.Errorf("load migrations error %w", err)
+	}
+
+	versions := make(map[int]*model.Migration)
+	undo := make(map[int]string)
+	for _, file := range files {
+		match := migrationName.FindStringSubmatch(file)
+		if match == nil {
+			continue
+		}
+		version, err := strconv.Atoi(match[2])
+		if err != nil {
+			return nil, fmt.Errorf("load migrations error: bad version of %s", file)
+		}
+		content, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, fmt.Errorf("load migrations error %w", err)
+		}
+
+		if match[1] == "U" {
+			undo[version] = string(content)
+			continue
+		}
+		if _, ok := versions[version]; ok {
+			return nil, fmt.Errorf("load migrations error: duplicate version %d", version)
+		}
+		versions[version] = &model.Migration{
+			Version:     version,
+			Description: strings.ReplaceAll(match[3], "_", " "),
+			Script:      file,
+			Checksum:    flywayChecksum(content),
+			SQL:         string(content),
+		}
+	}
+
+	migrations := make([]*model.Migration, 0, len(versions))
+	for version, script := range undo {
+		if _, ok := versions[version]; !ok {
+			return nil, fmt.Errorf("load migrations error: undo script of unknown version %d", version)
+		}
+		versions[version].UndoSQL = script
+	}
+	for _, migration := range versions {
+		migrations = append(migrations, migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// flywayChecksum is the CRC32 of the script lines without line breaks the way flyway computes it,
+// so the history stays valid for flyway
+func flywayChecksum(content []byte) int32 {
+	content = bytes.TrimPrefix(content, []byte("\xef\xbb\xbf"))
+	content = bytes.ReplaceAll(content, []byte("\r\n"), []byte("\n"))
+	content = bytes.ReplaceAll(content, []byte("\r"), []byte("\n"))
+	content = bytes.TrimSuffix(content, []byte("\n"))
+
+	checksum := crc32.NewIEEE()
+	if len(content) > 0 {
+		for _, line := range bytes.Split(content, []byte("\n")) {
+			checksum.Write(line)
+		}
+	}
+
+	return int32(checksum.Sum32())
+}
+
+// PostgresMigrator applies the migrations to the Postgres database and records them in the flyway schema history
+type PostgresMigrator struct {
+	db         *pgxpool.Pool
+	migrations []*model.Migration
+}
+
+// NewPostgresMigrator create new instance
+func NewPostgresMigrator(pool *pgxpool.Pool, migrations []*model.Migration) *PostgresMigrator {
+	return &PostgresMigrator{db: pool, migrations: migrations}
+}
+
+// Up applies the pending migrations in the version order and returns them.
+// It fails without changes when the history has a failed migration, an applied script was changed
+// or a pending migration is older than the applied ones
+func (m *PostgresMigrator) Up(ctx context.Context) ([]*model.Migration, error) {
+	var applied []*model.Migration
+	err := m.locked(ctx, func(conn *pgxpool.Conn) error {
+		history, err := m.history(ctx, conn)
+		if err != nil {
+			return err
+		}
+		versions, baseline, err := appliedVersions(history)
+		if err != nil {
+			return err
+		}
+
+		latest := baseline
+		for version := range versions {
+			if version > latest {
+				latest = version
+			}
+		}
+		var pending []*model.Migration
+		for _, migration := range m.migrations {
+			if row, ok := versions[migration.Version]; ok {
+				if row.checksum != nil && *row.checksum != migration.Checksum {
+					return fmt.Errorf("migration %s was changed after it was applied", migration.Script)
+				}
+				continue
+			}
+			if migration.Version <= baseline {
+				continue
+			}
+			if migration.Version < latest {
+				return fmt.Errorf("migration %s is older than the applied version %d", migration.Script, latest)
+			}
+			pending = append(pending, migration)
+		}
+
+		for _, migration := range pending {
+			if err := m.apply(ctx, conn, migration); err != nil {
+				return fmt.Errorf("migration %s error %w", migration.Script, err)
+			}
+			applied = append(applied, migration)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return applied, fmt.Errorf("migrate up error %w", err)
+	}
+
+	return applied, nil
+}
+
+// Down reverts the given number of latest applied migrations with their undo scripts and returns them.
+// Baseline can't be reverted
+func (m *PostgresMigrator) Down(ctx context.Context, steps int) ([]*model.Migration, error) {
+	var reverted []*model.Migration
+	err := m.locked(ctx, func(conn *pgxpool.Conn) error {
+		history, err := m.history(ctx, conn)
+		if err != nil {
+			return err
+		}
+		versions, _, err := appliedVersions(history)
+		if err != nil {
+			return err
+		}
+
+		order := make([]int, 0, len(versions))
+		for version := range versions {
+			order = append(order, version)
+		}
+		sort.Sort(sort.Reverse(sort.IntSlice(order)))
+		if steps > len(order) {
+			steps = len(order)
+		}
+
+		for _, version := range order[:steps] {
+			migration := m.migration(version)
+			if migration == nil || migration.UndoSQL == "" {
+				return fmt.Errorf("migration V%d has no undo script", version)
+			}
+			if err := m.revert(ctx, conn, migration, versions[version].rank); err != nil {
+				return fmt.Errorf("undo of %s error %w", migration.Script, err)
+			}
+			reverted = append(reverted, migration)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return reverted, fmt.Errorf("migrate down error %w", err)
+	}
+
+	return reverted, nil
+}
+
+// Status returns the state of known and applied migrations ordered by version
+func (m *PostgresMigrator) Status(ctx context.Context) ([]*model.MigrationStatus, error) {
+	conn, err := m.db.Acquire(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("migrate status error %w", err)
+	}
+	defer conn.Release()
+
+	history, err := m.history(ctx, conn)
+	if err != nil {
+		return nil, fmt.Errorf("migrate status error %w", err)
+	}
+	versions, baseline, err := appliedVersions(history)
+	if err != nil {
+		return nil, fmt.Errorf("migrate status error %w", err)
+	}
+
+	statuses := make([]*model.MigrationStatus, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := &model.MigrationStatus{Version: migration.Version, Description: migration.Description, State: model.MigrationPending}
+		if row, ok := versions[migration.Version]; ok {
+			status.State = model.MigrationApplied
+			status.InstalledOn = &row.installedOn
+		} else if migration.Version <= baseline {
+			status.State = model.MigrationBaseline
+		}
+		statuses = append(statuses, status)
+	}
+	for version, row := range versions {
+		if m.migration(version) == nil {
+			statuses = append(statuses, &model.MigrationStatus{Version: version, Description: row.description,
+				State: model.MigrationMissing, InstalledOn: &row.installedOn})
+		}
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Version < statuses[j].Version
+	})
+
+	return statuses, nil
+}
+
+// locked runs the function on the connection holding the migration lock, the history table is created before
+func (m *PostgresMigrator) locked(ctx context.Context, fn func(conn *pgxpool.Conn) error) error {
+	conn, err := m.db.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, "SELECT pg_advisory_lock($1)", migrationLockID); err != nil {
+		return err
+	}
+	// the lock belongs to the session, it's released even if the context is done
+	defer conn.Exec(context.Background(), "SELECT pg_advisory_unlock($1)", migrationLockID)
+
+	if _, err := conn.Exec(ctx, flywayHistory); err != nil {
+		return err
+	}
+
+	return fn(conn)
+}
+
+// history reads the flyway schema history, missing table means nothing is applied
+func (m *PostgresMigrator) history(ctx context.Context, conn *pgxpool.Conn) ([]*appliedMigration, error) {
+	var exists bool
+	if err := conn.QueryRow(ctx, "SELECT to_regclass('flyway_schema_history') IS NOT NULL").Scan(&exists); err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, nil
+	}
+
+	rows, err := conn.Query(ctx, `SELECT installed_rank, version, description, type, checksum, installed_on, success
+		FROM flyway_schema_history ORDER BY installed_rank`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var history []*appliedMigration
+	for rows.Next() {
+		row := &appliedMigration{}
+		err := rows.Scan(&row.rank, &row.version, &row.description, &row.kind, &row.checksum, &row.installedOn, &row.success)
+		if err != nil {
+			return nil, err
+		}
+		history = append(history, row)
+	}
+
+	return history, rows.Err()
+}
+
+// apply runs the migration and records it in the history in one transaction
+func (m *PostgresMigrator) apply(ctx context.Context, conn *pgxpool.Conn, migration *model.Migration) error {
+	return conn.BeginFunc(ctx, func(tx pgx.Tx) error {
+		started := time.Now()
+		if _, err := tx.Exec(ctx, migration.SQL); err != nil {
+			return err
+		}
+		_, err := tx.Exec(ctx, `INSERT INTO flyway_schema_history
+			(installed_rank, version, description, type, script, checksum, installed_by, execution_time, success)
+			VALUES ((SELECT COALESCE(MAX(installed_rank), 0) + 1 FROM flyway_schema_history), $1, $2, 'SQL', $3, $4, current_user, $5, true)`,
+			strconv.Itoa(migration.Version), migration.Description, migration.Script, migration.Checksum,
+			time.Since(started).Milliseconds())
+		return err
+	})
+}
+
+// revert runs the undo script and removes the migration from the history in one transaction
+func (m *PostgresMigrator) revert(ctx context.Context, conn *pgxpool.Conn, migration *model.Migration, rank int) error {
+	return conn.BeginFunc(ctx, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, migration.UndoSQL); err != nil {
+			return err
+		}
+		_, err := tx.Exec(ctx, "DELETE FROM flyway_schema_history WHERE installed_rank = $1", rank)
+		return err
+	})
+}
+
+// migration returns the known migration of the version, nil when the binary doesn't have it
+func (m *PostgresMigrator) migration(version int) *model.Migration {
+	for _, migration := range m.migrations {
+		if migration.Version == version {
+			return migration
+		}
+	}
+
+	return nil
+}
+
+// appliedVersions returns the applied migrations by version and the baseline version, zero without baseline.
+// Rows without version, like the schema creation by flyway, are skipped
+func appliedVersions(history []*appliedMigration) (map[int]*appliedMigration, int, error) {
+	versions := make(map[int]*appliedMigration)
+	baseline := 0
+	for _, row := range history {
+		if row.version == nil {
+			continue
+		}
+		version, err := strconv.Atoi(*row.version)
+		if err != nil {
+			return nil, 0, fmt.Errorf("unsupported migration version %s", *row.version)
+		}
+		if !row.success {
+			return nil, 0, fmt.Errorf("migration V%d failed, fix the schema and remove it from flyway_schema_history", version)
+		}
+		if row.kind == "BASELINE" {
+			baseline = version
+			continue
+		}
+		versions[version] = row
+	}
+
+	return versions, baseline, nil
+}
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+
+	"github.com/catService/internal/model"
+	"github.com/catService/migrations"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadMigrations(t *testing.T) {
+	scripts, err := LoadMigrations(fstest.MapFS{
+		"V2__Add_cats_version.sql":  {Data: []byte("ALTER TABLE CATS\r\n    ADD COLUMN version bigint;\r\n")},
+		"V10__Create_table.sql":     {Data: []byte("CREATE TABLE t (id int);")},
+		"U2__Add_cats_version.sql":  {Data: []byte("ALTER TABLE CATS DROP COLUMN version;")},
+		"V1__Create_cats_table.sql": {Data: []byte("\xef\xbb\xbfCREATE TABLE CATS (id uuid);\n")},
+		"README.md":                 {Data: []byte("not a migration")},
+	})
+	require.NoError(t, err)
+	require.Len(t, scripts, 3)
+	require.Equal(t, []int{1, 2, 10}, []int{scripts[0].Version, scripts[1].Version, scripts[2].Version})
+	require.Equal(t, "Add cats version", scripts[1].Description)
+	require.NotEmpty(t, scripts[1].UndoSQL)
+	require.Empty(t, scripts[2].UndoSQL)
+	// line breaks and byte order mark don't change the checksum
+	require.Equal(t, flywayChecksum([]byte("ALTER TABLE CATS\n    ADD COLUMN version bigint;")), scripts[1].Checksum)
+	require.Equal(t, flywayChecksum([]byte("CREATE TABLE CATS (id uuid);")), scripts[0].Checksum)
+
+	_, err = LoadMigrations(fstest.MapFS{"U3__Drop.sql": {Data: []byte("DROP TABLE t;")}})
+	require.Error(t, err)
+}
+
+func TestMigrateDownAndUp(t *testing.T) {
+	requirePostgres(t)
+	scripts, err := LoadMigrations(migrations.FS)
+	require.NoError(t, err)
+	latest := scripts[len(scripts)-1]
+	migrator := NewPostgresMigrator(repository.(*CatPostgresRepository).db, scripts)
+
+	reverted, err := migrator.Down(context.Background(), 1)
+	require.NoError(t, err)
+	require.Equal(t, []*model.Migration{latest}, reverted)
+	statuses, err := migrator.Status(context.Background())
+	require.NoError(t, err)
+	require.Len(t, statuses, len(scripts))
+	require.Equal(t, model.MigrationApplied, statuses[0].State)
+	require.Equal(t, model.MigrationPending, statuses[len(statuses)-1].State)
+
+	applied, err := migrator.Up(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []*model.Migration{latest}, applied)
+	applied, err = migrator.Up(context.Background())
+	require.NoError(t, err)
+	require.Empty(t, applied)
+}
@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/catService/internal/model"
+
+	"github.com/google/uuid"
+)
+
+// OutboxMemoryRepository reads the outbox of the memory store the cat repository writes
+type OutboxMemoryRepository struct {
+	store *MemoryStore
+}
+
+// NewOutboxMemory create new instance
+func NewOutboxMemory(store *MemoryStore) *OutboxMemoryRepository {
+	return &OutboxMemoryRepository{store: store}
+}
+
+// Pending returns the oldest not sent and not given up messages
+func (r *OutboxMemoryRepository) Pending(ctx context.Context, limit int) ([]*model.OutboxMessage, error) {
+	r.store.mutex.RLock()
+	defer r.store.mutex.RUnlock()
+
+	messages := make([]*model.OutboxMessage, 0, limit)
+	for _, message := range r.store.data.Outbox {
+		if len(messages) == limit {
+			break
+		}
+		if message.SentAt == nil && message.DeadAt == nil {
+			copied := *message
+			messages = append(messages, &copied)
+		}
+	}
+
+	return messages, nil
+}
+
+// MarkSent marks the message as sent, the missing message is ignored
+func (r *OutboxMemoryRepository) MarkSent(ctx context.Context, id uuid.UUID) error {
+	now := time.Now().UTC()
+	r.update(id, func(message *model.OutboxMessage) {
+		message.SentAt = &now
+	})
+
+	return nil
+}
+
+// MarkFailed counts the failed attempt, the missing message is ignored
+func (r *OutboxMemoryRepository) MarkFailed(ctx context.Context, id uuid.UUID, reason string) error {
+	r.update(id, func(message *model.OutboxMessage) {
+		message.Attempts++
+		message.LastError = reason
+	})
+
+	return nil
+}
+
+// MarkDead counts the last failed attempt and gives the message up, the missing message is ignored
+func (r *OutboxMemoryRepository) MarkDead(ctx context.Context, id uuid.UUID, reason string) error {
+	now := time.Now().UTC()
+	r.update(id, func(message *model.OutboxMessage) {
+		message.Attempts++
+		message.LastError = reason
+		message.DeadAt = &now
+	})
+
+	return nil
+}
+
+// update replaces the message with its changed copy
+func (r *OutboxMemoryRepository) update(id uuid.UUID, change func(*model.OutboxMessage)) {
+	r.store.mutex.Lock()
+	defer r.store.mutex.Unlock()
+
+	for i, message := range r.store.data.Outbox {
+		if message.ID == id {
+			copied := *message
+			change(&copied)
+			r.store.data.Outbox[i] = &copied
+			return
+		}
+	}
+}
+
+// PurgeSent removes the messages sent before the given time
+func (r *OutboxMemoryRepository) PurgeSent(ctx context.Context, before time.Time) (int64, error) {
+	r.store.mutex.Lock()
+	defer r.store.mutex.Unlock()
+
+	kept := make([]*model.OutboxMessage, 0, len(r.store.data.Outbox))
+	for _, message := range r.store.data.Outbox {
+		if message.SentAt == nil || !message.SentAt.Before(before) {
+			kept = append(kept, message)
+		}
+	}
+	purged := int64(len(r.store.data.Outbox) - len(kept))
+	r.store.data.Outbox = kept
+
+	return purged, nil
+}
+
+// addOutbox appends the messages of the cat change to the outbox. The caller holds the lock
+func (s *MemoryStore) addOutbox(messages ...*model.OutboxMessage) {
+	now := time.Now().UTC()
+	for _, message := range messages {
+		message.CreatedAt = now
+		s.data.Outbox = append(s.data.Outbox, message)
+	}
+}
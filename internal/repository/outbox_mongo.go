@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/catService/internal/model"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// mongoOutboxCounter is the document of the counters collection numbering the outbox messages
+const mongoOutboxCounter = "outbox"
+
+// mongoOutboxMessage is the document of the outbox collection. Seq numbers the messages in the commit order:
+// the counter is incremented in the transaction writing them, so the concurrent transactions conflict on it
+// and commit one after another. Messages written before seq appeared have none and go first
+type mongoOutboxMessage struct {
+	model.OutboxMessage `bson:",inline"`
+	Seq                 int64 `bson:"seq"`
+}
+
+// OutboxMongoRepository reads the outbox collection the cat repository writes
+type OutboxMongoRepository struct {
+	db *mongo.Database
+}
+
+// NewOutboxMongo create new instance
+func NewOutboxMongo(database *mongo.Database) *OutboxMongoRepository {
+	return &OutboxMongoRepository{db: database}
+}
+
+// CreateOutboxMongoIndexes creates the indexes of the outbox collection if they don't exist and the counter
+// of the messages. The collections are created with them, transactions of the older servers can't create collections
+func CreateOutboxMongoIndexes(ctx context.Context, database *mongo.Database) error {
+	_, err := database.Collection("outbox").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "sent_at", Value: 1}, {Key: "seq", Value: 1},
+				{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}},
+			Options: options.Index().SetName("sent_at_seq_created_at_id"),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("create outbox indexes error %w", err)
+	}
+	_, err = database.Collection("counters").UpdateOne(ctx, bson.M{"_id": mongoOutboxCounter},
+		bson.M{"$setOnInsert": bson.M{"seq": int64(0)}}, options.Update().SetUpsert(true))
+	if err != nil {
+		return fmt.Errorf("create outbox counter error %w", err)
+	}
+
+	return nil
+}
+
+// Pending returns the oldest not sent and not given up messages in the commit order
+func (c *OutboxMongoRepository) Pending(ctx context.Context, limit int) ([]*model.OutboxMessage, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "seq", Value: 1}, {Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}).
+		SetLimit(int64(limit))
+	cursor, err := c.db.Collection("outbox").Find(ctx, bson.M{"sent_at": nil, "dead_at": nil}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("pending method error %w", err)
+	}
+
+	messages := make([]*model.OutboxMessage, 0, limit)
+	if err := cursor.All(ctx, &messages); err != nil {
+		return nil, fmt.Errorf("pending method error %w", err)
+	}
+
+	return messages, nil
+}
+
+// MarkSent marks the message as sent, the missing message is ignored
+func (c *OutboxMongoRepository) MarkSent(ctx context.Context, id uuid.UUID) error {
+	_, err := c.db.Collection("outbox").UpdateByID(ctx, id, bson.M{"$currentDate": bson.M{"sent_at": true}})
+	if err != nil {
+		return fmt.Errorf("mark sent method error %w", err)
+	}
+
+	return nil
+}
+
+// MarkFailed counts the failed attempt, the missing message is ignored
+func (c *OutboxMongoRepository) MarkFailed(ctx context.Context, id uuid.UUID, reason string) error {
+	_, err := c.db.Collection("outbox").UpdateByID(ctx, id,
+		bson.M{"$inc": bson.M{"attempts": 1}, "$set": bson.M{"last_error": reason}})
+	if err != nil {
+		return fmt.Errorf("mark failed method error %w", err)
+	}
+
+	return nil
+}
+
+// MarkDead counts the last failed attempt and gives the message up, the missing message is ignored
+func (c *OutboxMongoRepository) MarkDead(ctx context.Context, id uuid.UUID, reason string) error {
+	_, err := c.db.Collection("outbox").UpdateByID(ctx, id, bson.M{"$inc": bson.M{"attempts": 1},
+		"$set": bson.M{"last_error": reason}, "$currentDate": bson.M{"dead_at": true}})
+	if err != nil {
+		return fmt.Errorf("mark dead method error %w", err)
+	}
+
+	return nil
+}
+
+// PurgeSent removes the messages sent before the given time
+func (c *OutboxMongoRepository) PurgeSent(ctx context.Context, before time.Time) (int64, error) {
+	result, err := c.db.Collection("outbox").DeleteMany(ctx, bson.M{"sent_at": bson.M{"$lt": before}})
+	if err != nil {
+		return 0, fmt.Errorf("purge sent method error %w", err)
+	}
+
+	return result.DeletedCount, nil
+}
+
+// insertMongoOutbox numbers the messages and writes them into the outbox in the transaction of the session
+func insertMongoOutbox(ctx mongo.SessionContext, database *mongo.Database, messages []*model.OutboxMessage) error {
+	var counter struct {
+		Seq int64 `bson:"seq"`
+	}
+	err := database.Collection("counters").FindOneAndUpdate(ctx, bson.M{"_id": mongoOutboxCounter},
+		bson.M{"$inc": bson.M{"seq": int64(len(messages))}},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)).Decode(&counter)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	seq := counter.Seq - int64(len(messages))
+	documents := make([]interface{}, 0, len(messages))
+	for _, message := range messages {
+		message.CreatedAt = now
+		seq++
+		documents = append(documents, &mongoOutboxMessage{OutboxMessage: *message, Seq: seq})
+	}
+	_, err = database.Collection("outbox").InsertMany(ctx, documents)
+
+	return err
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/catService/internal/model"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// OutboxPostgresRepository reads the outbox table the cat repository writes
+type OutboxPostgresRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewOutboxPostgres create new instance
+func NewOutboxPostgres(pool *pgxpool.Pool) *OutboxPostgresRepository {
+	return &OutboxPostgresRepository{db: pool}
+}
+
+// Pending returns the oldest not sent and not given up messages
+func (r *OutboxPostgresRepository) Pending(ctx context.Context, limit int) ([]*model.OutboxMessage, error) {
+	rows, err := r.db.Query(ctx, `SELECT id, action, payload, created_at, attempts, last_error, sent_at FROM outbox
+		WHERE sent_at IS NULL AND dead_at IS NULL ORDER BY created_at, id LIMIT $1`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("pending method error %w", err)
+	}
+	defer rows.Close()
+
+	messages := make([]*model.OutboxMessage, 0, limit)
+	for rows.Next() {
+		var message model.OutboxMessage
+		err := rows.Scan(&message.ID, &message.Action, &message.Payload, &message.CreatedAt, &message.Attempts,
+			&message.LastError, &message.SentAt)
+		if err != nil {
+			return nil, fmt.Errorf("pending method error %w", err)
+		}
+		messages = append(messages, &message)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("pending method error %w", err)
+	}
+
+	return messages, nil
+}
+
+// MarkSent marks the message as sent, the missing message is ignored
+func (r *OutboxPostgresRepository) MarkSent(ctx context.Context, id uuid.UUID) error {
+	_, err := r.db.Exec(ctx, "UPDATE outbox SET sent_at = now() WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("mark sent method error %w", err)
+	}
+
+	return nil
+}
+
+// MarkFailed counts the failed attempt, the missing message is ignored
+func (r *OutboxPostgresRepository) MarkFailed(ctx context.Context, id uuid.UUID, reason string) error {
+	_, err := r.db.Exec(ctx, "UPDATE outbox SET attempts = attempts+1, last_error = $2 WHERE id = $1", id, reason)
+	if err != nil {
+		return fmt.Errorf("mark failed method error %w", err)
+	}
+
+	return nil
+}
+
+// MarkDead counts the last failed attempt and gives the message up, the missing message is ignored
+func (r *OutboxPostgresRepository) MarkDead(ctx context.Context, id uuid.UUID, reason string) error {
+	_, err := r.db.Exec(ctx, "UPDATE outbox SET attempts = attempts+1, last_error = $2, dead_at = now() WHERE id = $1",
+		id, reason)
+	if err != nil {
+		return fmt.Errorf("mark dead method error %w", err)
+	}
+
+	return nil
+}
+
+// PurgeSent removes the messages sent before the given time
+func (r *OutboxPostgresRepository) PurgeSent(ctx context.Context, before time.Time) (int64, error) {
+	tag, err := r.db.Exec(ctx, "DELETE FROM outbox WHERE sent_at < $1", before)
+	if err != nil {
+		return 0, fmt.Errorf("purge sent method error %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+// insertOutbox writes the messages into the outbox in the transaction of the cat change,
+// the default of created_at gives them the time of the insert
+func insertOutbox(ctx context.Context, tx pgx.Tx, messages []*model.OutboxMessage) error {
+	rows := make([][]interface{}, 0, len(messages))
+	for _, message := range messages {
+		rows = append(rows, []interface{}{message.ID, message.Action, message.Payload})
+	}
+	_, err := tx.CopyFrom(ctx, pgx.Identifier{"outbox"}, []string{"id", "action", "payload"}, pgx.CopyFromRows(rows))
+
+	return err
+}
@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/catService/internal/model"
+
+	"github.com/google/uuid"
+)
+
+// OutboxSQLiteRepository reads the outbox table the cat repository writes
+type OutboxSQLiteRepository struct {
+	db *SQLiteDB
+}
+
+// NewOutboxSQLite create new instance
+func NewOutboxSQLite(db *SQLiteDB) *OutboxSQLiteRepository {
+	return &OutboxSQLiteRepository{db: db}
+}
+
+// Pending returns the oldest not sent and not given up messages
+func (r *OutboxSQLiteRepository) Pending(ctx context.Context, limit int) ([]*model.OutboxMessage, error) {
+	rows, err := r.db.Query(ctx, `SELECT id, action, payload, created_at, attempts, last_error, sent_at FROM outbox
+		WHERE sent_at IS NULL AND dead_at IS NULL ORDER BY rowid LIMIT ?1`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("pending method error %w", err)
+	}
+	defer rows.Close()
+
+	messages := make([]*model.OutboxMessage, 0, limit)
+	for rows.Next() {
+		var message model.OutboxMessage
+		err := rows.Scan(&message.ID, &message.Action, &message.Payload, &message.CreatedAt, &message.Attempts,
+			&message.LastError, &message.SentAt)
+		if err != nil {
+			return nil, fmt.Errorf("pending method error %w", err)
+		}
+		messages = append(messages, &message)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("pending method error %w", err)
+	}
+
+	return messages, nil
+}
+
+// MarkSent marks the message as sent, the missing message is ignored
+func (r *OutboxSQLiteRepository) MarkSent(ctx context.Context, id uuid.UUID) error {
+	_, err := r.db.Exec(ctx, "UPDATE outbox SET sent_at = ?2 WHERE id = ?1", id, time.Now())
+	if err != nil {
+		return fmt.Errorf("mark sent method error %w", err)
+	}
+
+	return nil
+}
+
+// MarkFailed counts the failed attempt, the missing message is ignored
+func (r *OutboxSQLiteRepository) MarkFailed(ctx context.Context, id uuid.UUID, reason string) error {
+	_, err := r.db.Exec(ctx, "UPDATE outbox SET attempts = attempts+1, last_error = ?2 WHERE id = ?1", id, reason)
+	if err != nil {
+		return fmt.Errorf("mark failed method error %w", err)
+	}
+
+	return nil
+}
+
+// MarkDead counts the last failed attempt and gives the message up, the missing message is ignored
+func (r *OutboxSQLiteRepository) MarkDead(ctx context.Context, id uuid.UUID, reason string) error {
+	_, err := r.db.Exec(ctx, "UPDATE outbox SET attempts = attempts+1, last_error = ?2, dead_at = ?3 WHERE id = ?1",
+		id, reason, time.Now())
+	if err != nil {
+		return fmt.Errorf("mark dead method error %w", err)
+	}
+
+	return nil
+}
+
+// PurgeSent removes the messages sent before the given time
+func (r *OutboxSQLiteRepository) PurgeSent(ctx context.Context, before time.Time) (int64, error) {
+	count, err := r.db.Exec(ctx, "DELETE FROM outbox WHERE sent_at < ?1", before)
+	if err != nil {
+		return 0, fmt.Errorf("purge sent method error %w", err)
+	}
+
+	return count, nil
+}
+
+// insertSQLiteOutbox writes the messages into the outbox in the transaction of the cat change
+func insertSQLiteOutbox(ctx context.Context, tx *sql.Tx, messages []*model.OutboxMessage) error {
+	statement, err := tx.PrepareContext(ctx, "INSERT INTO outbox(id, action, payload, created_at) VALUES (?1, ?2, ?3, ?4)")
+	if err != nil {
+		return err
+	}
+	defer statement.Close()
+
+	now := time.Now()
+	for _, message := range messages {
+		message.CreatedAt = now.UTC()
+		_, err := statement.ExecContext(ctx, sqliteArgs([]interface{}{message.ID, message.Action, message.Payload, now})...)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/catService/internal/model"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryOutbox(t *testing.T) {
+	store := NewMemoryStore()
+	testOutbox(t, NewMemoryRepository(store), NewOutboxMemoryRepository(store))
+}
+
+func TestSQLiteOutbox(t *testing.T) {
+	db := openTestSQLite(t)
+	testOutbox(t, NewSQLiteRepository(db), NewOutboxSQLiteRepository(db))
+}
+
+func TestMongoOutbox(t *testing.T) {
+	database := requireMongo(t)
+	testOutbox(t, NewMongoRepository(database), NewOutboxMongoRepository(database))
+}
+
+// testOutbox checks the cat changes write the messages in order and the relay marks them
+func testOutbox(t *testing.T, cats SheltersCatRepository, outbox OutboxRepository) {
+	cat := &model.Cat{ID: uuid.New(), Name: "Cat 1", Age: 2, Status: model.CatAvailable, Version: 1}
+	require.NoError(t, cats.Create(context.Background(), cat))
+	reserved := model.CatReserved
+	version, err := cats.Patch(context.Background(), cat.ID, 1, &model.CatPatch{Status: &reserved})
+	require.NoError(t, err)
+	_, err = cats.Patch(context.Background(), cat.ID, 1, &model.CatPatch{Status: &reserved})
+	require.ErrorIs(t, err, model.ErrVersionMismatch)
+	require.NoError(t, cats.Delete(context.Background(), cat.ID, version))
+
+	messages, err := outbox.Pending(context.Background(), 10)
+	require.NoError(t, err)
+	require.Len(t, messages, 3)
+	require.Equal(t, []string{model.OutboxCreate, model.CatReserve, model.OutboxDelete},
+		[]string{messages[0].Action, messages[1].Action, messages[2].Action})
+	require.Equal(t, cat.ID.String(), messages[2].Payload)
+	var patched model.Cat
+	require.NoError(t, json.Unmarshal([]byte(messages[1].Payload), &patched))
+	require.Equal(t, model.CatReserved, patched.Status)
+	require.Equal(t, version, patched.Version)
+
+	require.NoError(t, outbox.MarkFailed(context.Background(), messages[0].ID, "redis is down"))
+	require.NoError(t, outbox.MarkSent(context.Background(), messages[0].ID))
+	messages, err = outbox.Pending(context.Background(), 1)
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	require.Equal(t, model.CatReserve, messages[0].Action)
+
+	require.NoError(t, outbox.MarkDead(context.Background(), messages[0].ID, "message too large"))
+	messages, err = outbox.Pending(context.Background(), 10)
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	require.Equal(t, model.OutboxDelete, messages[0].Action)
+
+	purged, err := outbox.PurgeSent(context.Background(), time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	require.Zero(t, purged)
+	purged, err = outbox.PurgeSent(context.Background(), time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, int64(1), purged)
+}
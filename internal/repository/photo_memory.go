@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/catService/internal/model"
+
+	"github.com/google/uuid"
+)
+
+// PhotoMemoryRepository keeps photo descriptions with the cat in the memory store
+type PhotoMemoryRepository struct {
+	store *MemoryStore
+}
+
+// NewPhotoMemory create new instance
+func NewPhotoMemory(store *MemoryStore) *PhotoMemoryRepository {
+	return &PhotoMemoryRepository{store: store}
+}
+
+// ListPhotos returns photos of the cat in the order they were uploaded
+func (r *PhotoMemoryRepository) ListPhotos(ctx context.Context, catID uuid.UUID) ([]*model.Photo, error) {
+	r.store.mutex.RLock()
+	defer r.store.mutex.RUnlock()
+
+	entry, exist := r.store.data.Cats[catID]
+	if !exist {
+		return nil, fmt.Errorf("list photos method error %w", model.ErrCatNotFound)
+	}
+	photos := make([]*model.Photo, 0, len(entry.Photos))
+	for _, photo := range entry.Photos {
+		copied := *photo
+		photos = append(photos, &copied)
+	}
+	sort.SliceStable(photos, func(i, j int) bool {
+		return photos[i].CreatedAt.Before(photos[j].CreatedAt)
+	})
+
+	return photos, nil
+}
+
+// AddPhoto saves description of the cat photo unless the cat has limit photos
+func (r *PhotoMemoryRepository) AddPhoto(ctx context.Context, catID uuid.UUID, photo *model.Photo, limit int) error {
+	r.store.mutex.Lock()
+	defer r.store.mutex.Unlock()
+
+	entry, exist := r.store.data.Cats[catID]
+	if !exist {
+		return fmt.Errorf("add photo method error %w", model.ErrCatNotFound)
+	}
+	if len(entry.Photos) >= limit {
+		return fmt.Errorf("add photo method error %w: cat already has %d photos", model.ErrConflict, limit)
+	}
+	copied := *photo
+	copied.URLs = nil
+	entry.Photos = append(entry.Photos, &copied)
+
+	return nil
+}
+
+// DeletePhoto removes description of the cat photo
+func (r *PhotoMemoryRepository) DeletePhoto(ctx context.Context, catID, id uuid.UUID) error {
+	r.store.mutex.Lock()
+	defer r.store.mutex.Unlock()
+
+	if entry, exist := r.store.data.Cats[catID]; exist {
+		for i, photo := range entry.Photos {
+			if photo.ID == id {
+				entry.Photos = append(entry.Photos[:i:i], entry.Photos[i+1:]...)
+				return nil
+			}
+		}
+	}
+
+	return fmt.Errorf("delete photo method error %w", model.ErrPhotoNotFound)
+}
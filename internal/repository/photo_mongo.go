@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/catService/internal/model"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// PhotoMongoRepository keeps photo descriptions embedded into the cat document
+type PhotoMongoRepository struct {
+	db *mongo.Database
+}
+
+// NewPhotoMongo create new instance
+func NewPhotoMongo(database *mongo.Database) *PhotoMongoRepository {
+	return &PhotoMongoRepository{db: database}
+}
+
+// ListPhotos returns photos of the cat in the order they were uploaded
+func (c *PhotoMongoRepository) ListPhotos(ctx context.Context, catID uuid.UUID) ([]*model.Photo, error) {
+	var document struct {
+		Photos []*model.Photo `bson:"photos"`
+	}
+	opts := options.FindOne().SetProjection(bson.M{"photos": 1})
+	err := c.db.Collection("cat").FindOne(ctx, bson.M{"_id": catID}, opts).Decode(&document)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, fmt.Errorf("list photos method error %w", model.ErrCatNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("list photos method error %w", err)
+	}
+
+	photos := document.Photos
+	if photos == nil {
+		photos = make([]*model.Photo, 0)
+	}
+	sort.SliceStable(photos, func(i, j int) bool {
+		return photos[i].CreatedAt.Before(photos[j].CreatedAt)
+	})
+
+	return photos, nil
+}
+
+// AddPhoto saves description of the cat photo unless the cat has limit photos
+func (c *PhotoMongoRepository) AddPhoto(ctx context.Context, catID uuid.UUID, photo *model.Photo, limit int) error {
+	// the update of one document is atomic, the cat with the photo at the last allowed index isn't matched
+	filter := bson.M{"_id": catID, fmt.Sprintf("photos.%d", limit-1): bson.M{"$exists": false}}
+	result, err := c.db.Collection("cat").UpdateOne(ctx, filter, bson.M{"$push": bson.M{"photos": photo}})
+	if err != nil {
+		return fmt.Errorf("add photo method error %w", err)
+	}
+	if result.MatchedCount == 0 {
+		count, err := c.db.Collection("cat").CountDocuments(ctx, bson.M{"_id": catID})
+		if err != nil {
+			return fmt.Errorf("add photo method error %w", err)
+		}
+		if count == 0 {
+			return fmt.Errorf("add photo method error %w", model.ErrCatNotFound)
+		}
+		return fmt.Errorf("add photo method error %w: cat already has %d photos", model.ErrConflict, limit)
+	}
+
+	return nil
+}
+
+// DeletePhoto removes description of the cat photo
+func (c *PhotoMongoRepository) DeletePhoto(ctx context.Context, catID, id uuid.UUID) error {
+	result, err := c.db.Collection("cat").UpdateOne(ctx, bson.M{"_id": catID, "photos._id": id},
+		bson.M{"$pull": bson.M{"photos": bson.M{"_id": id}}})
+	if err != nil {
+		return fmt.Errorf("delete photo method error %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("delete photo method error %w", model.ErrPhotoNotFound)
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/catService/internal/model"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// PhotoPostgresRepository contains a link to the connection to db
+type PhotoPostgresRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewPhotoPostgres create new instance
+func NewPhotoPostgres(pool *pgxpool.Pool) *PhotoPostgresRepository {
+	return &PhotoPostgresRepository{db: pool}
+}
+
+// ListPhotos returns photos of the cat in the order they were uploaded
+func (r *PhotoPostgresRepository) ListPhotos(ctx context.Context, catID uuid.UUID) ([]*model.Photo, error) {
+	rows, err := r.db.Query(ctx, `SELECT id, content_type, size, width, height, created_at FROM cat_photos
+		WHERE cat_id = $1 ORDER BY created_at, id`, catID)
+	if err != nil {
+		return nil, fmt.Errorf("list photos method error %w", err)
+	}
+	defer rows.Close()
+
+	photos := make([]*model.Photo, 0)
+	for rows.Next() {
+		var photo model.Photo
+		err := rows.Scan(&photo.ID, &photo.ContentType, &photo.Size, &photo.Width, &photo.Height, &photo.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("list photos method error %w", err)
+		}
+		photos = append(photos, &photo)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list photos method error %w", err)
+	}
+
+	return photos, nil
+}
+
+// AddPhoto saves description of the cat photo unless the cat has limit photos.
+// The cat row is locked, so the concurrent uploads count the photos one after another
+func (r *PhotoPostgresRepository) AddPhoto(ctx context.Context, catID uuid.UUID, photo *model.Photo, limit int) error {
+	err := r.db.BeginFunc(ctx, func(tx pgx.Tx) error {
+		var count int
+		err := tx.QueryRow(ctx, "SELECT id FROM cats WHERE id = $1 FOR UPDATE", catID).Scan(new(uuid.UUID))
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.ErrCatNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if err := tx.QueryRow(ctx, "SELECT count(*) FROM cat_photos WHERE cat_id = $1", catID).Scan(&count); err != nil {
+			return err
+		}
+		if count >= limit {
+			return fmt.Errorf("%w: cat already has %d photos", model.ErrConflict, limit)
+		}
+		_, err = tx.Exec(ctx, `INSERT INTO cat_photos(id, cat_id, content_type, size, width, height, created_at)
+			VALUES ($1,$2,$3,$4,$5,$6,$7)`,
+			photo.ID, catID, photo.ContentType, photo.Size, photo.Width, photo.Height, photo.CreatedAt)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("add photo method error %w", pgError(err))
+	}
+
+	return nil
+}
+
+// DeletePhoto removes description of the cat photo
+func (r *PhotoPostgresRepository) DeletePhoto(ctx context.Context, catID, id uuid.UUID) error {
+	result, err := r.db.Exec(ctx, "DELETE FROM cat_photos WHERE cat_id = $1 AND id = $2", catID, id)
+	if err != nil {
+		return fmt.Errorf("delete photo method error %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("delete photo method error %w", model.ErrPhotoNotFound)
+	}
+
+	return nil
+}
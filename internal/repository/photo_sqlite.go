@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/catService/internal/model"
+
+	"github.com/google/uuid"
+)
+
+// PhotoSQLiteRepository contains a link to the SQLite database
+type PhotoSQLiteRepository struct {
+	db *SQLiteDB
+}
+
+// NewPhotoSQLite create new instance
+func NewPhotoSQLite(db *SQLiteDB) *PhotoSQLiteRepository {
+	return &PhotoSQLiteRepository{db: db}
+}
+
+// ListPhotos returns photos of the cat in the order they were uploaded
+func (r *PhotoSQLiteRepository) ListPhotos(ctx context.Context, catID uuid.UUID) ([]*model.Photo, error) {
+	rows, err := r.db.Query(ctx, `SELECT id, content_type, size, width, height, created_at FROM cat_photos
+		WHERE cat_id = ?1 ORDER BY created_at, id`, catID)
+	if err != nil {
+		return nil, fmt.Errorf("list photos method error %w", err)
+	}
+	defer rows.Close()
+
+	photos := make([]*model.Photo, 0)
+	for rows.Next() {
+		var photo model.Photo
+		err := rows.Scan(&photo.ID, &photo.ContentType, &photo.Size, &photo.Width, &photo.Height, &photo.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("list photos method error %w", err)
+		}
+		photos = append(photos, &photo)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list photos method error %w", err)
+	}
+
+	return photos, nil
+}
+
+// AddPhoto saves description of the cat photo unless the cat has limit photos
+func (r *PhotoSQLiteRepository) AddPhoto(ctx context.Context, catID uuid.UUID, photo *model.Photo, limit int) error {
+	// one statement counts and inserts, SQLite runs one writer at a time
+	affected, err := r.db.Exec(ctx, `INSERT INTO cat_photos(id, cat_id, content_type, size, width, height, created_at)
+		SELECT ?1,?2,?3,?4,?5,?6,?7 FROM cats WHERE id = ?2
+			AND (SELECT count(*) FROM cat_photos WHERE cat_id = ?2) < ?8`,
+		photo.ID, catID, photo.ContentType, photo.Size, photo.Width, photo.Height, photo.CreatedAt, limit)
+	if err != nil {
+		return fmt.Errorf("add photo method error %w", sqliteError(err))
+	}
+	if affected == 0 {
+		var exists bool
+		err := r.db.QueryRow(ctx, "SELECT EXISTS(SELECT 1 FROM cats WHERE id = ?1)", catID).Scan(&exists)
+		if err != nil {
+			return fmt.Errorf("add photo method error %w", err)
+		}
+		if !exists {
+			return fmt.Errorf("add photo method error %w", model.ErrCatNotFound)
+		}
+		return fmt.Errorf("add photo method error %w: cat already has %d photos", model.ErrConflict, limit)
+	}
+
+	return nil
+}
+
+// DeletePhoto removes description of the cat photo
+func (r *PhotoSQLiteRepository) DeletePhoto(ctx context.Context, catID, id uuid.UUID) error {
+	affected, err := r.db.Exec(ctx, "DELETE FROM cat_photos WHERE cat_id = ?1 AND id = ?2", catID, id)
+	if err != nil {
+		return fmt.Errorf("delete photo method error %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("delete photo method error %w", model.ErrPhotoNotFound)
+	}
+
+	return nil
+}
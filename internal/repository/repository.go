@@ -3,6 +3,9 @@ package repository
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"time"
 
 	"github.com/catService/internal/model"
 	"github.com/go-redis/redis/v8"
@@ -11,20 +14,173 @@ import (
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
-// SheltersCatRepository contains needed methods which must be implemented
-//go:generate mockery --dir . --name CatRepository --output ./mocks
+// SheltersCatRepository contains needed methods which must be implemented.
+// Changing methods take the expected version of the cat, model.AnyVersion disables the check.
+// On mismatch they return model.ErrVersionMismatch.
+// Create, CreateMany, Update, Delete, Restore and Patch write the cache events of the changed cats
+// into the outbox in the same transaction as the change, see OutboxRepository
+//go:generate mockery --dir . --name SheltersCatRepository --output ./repository_mock
 type SheltersCatRepository interface {
 	Get(context.Context, uuid.UUID) (*model.Cat, error)
+	// GetByMicrochip returns the not deleted cat with the normalized microchip number
+	GetByMicrochip(ctx context.Context, chip string) (*model.Cat, error)
 	Create(context.Context, *model.Cat) error
+	// CreateMany saves the batch of cats with one request
+	CreateMany(context.Context, []*model.Cat) error
+	// Update saves the cat if its version is equal to cat.Version and sets the new version to the cat
 	Update(context.Context, *model.Cat) error
+	// Delete marks the cat as deleted. Deleted cats are hidden from Get and List by default
+	Delete(ctx context.Context, id uuid.UUID, version int64) error
+	// Restore removes the deleted mark, model.ErrConflict is returned for not deleted cat
+	Restore(ctx context.Context, id uuid.UUID, version int64) (*model.Cat, error)
+	// Purge permanently removes cats deleted before the given time and returns their ids,
+	// the ids of cats purged before the failure are returned with the error
+	Purge(ctx context.Context, before time.Time) ([]uuid.UUID, error)
+	// Patch updates only the fields set in the patch and returns the new version
+	Patch(ctx context.Context, id uuid.UUID, version int64, patch *model.CatPatch) (int64, error)
+	// List returns cats matching the query which go after the given cat in the query sorting
+	List(ctx context.Context, query *model.CatQuery, after *model.Cat) ([]*model.Cat, error)
+	// Export reads cats matching the filter with a db cursor in id order and passes them to fn one by one.
+	// Error of fn stops the export and is returned
+	Export(ctx context.Context, filter *model.CatFilter, fn func(*model.Cat) error) error
+	// Stats counts not deleted cats matching the filter and their finished stays
+	Stats(ctx context.Context, filter *model.StatsFilter) (*model.CatStats, error)
+}
+
+// CatEventRepository keeps the history of cat changes
+//go:generate mockery --dir . --name CatEventRepository --output ./repository_mock
+type CatEventRepository interface {
+	AddEvent(context.Context, *model.CatEvent) error
+	// ListEvents returns cat history in chronological order starting after the given event
+	ListEvents(ctx context.Context, catID uuid.UUID, after *model.CatEvent, limit int) ([]*model.CatEvent, error)
+}
+
+// ShelterRepository contains methods of the shelter storage
+//go:generate mockery --dir . --name ShelterRepository --output ./repository_mock
+type ShelterRepository interface {
+	Get(context.Context, uuid.UUID) (*model.Shelter, error)
+	Create(context.Context, *model.Shelter) error
+	Update(context.Context, *model.Shelter) error
+	// Delete removes the shelter, model.ErrConflict is returned if the storage knows it has cats
+	Delete(context.Context, uuid.UUID) error
+	// List returns shelters in id order starting after the given ID, nil starts from the beginning
+	List(ctx context.Context, after *uuid.UUID, limit int) ([]*model.Shelter, error)
+}
+
+// AdopterRepository contains methods of the adopter storage
+//go:generate mockery --dir . --name AdopterRepository --output ./repository_mock
+type AdopterRepository interface {
+	Get(context.Context, uuid.UUID) (*model.Adopter, error)
+	Create(context.Context, *model.Adopter) error
+	Update(context.Context, *model.Adopter) error
 	Delete(context.Context, uuid.UUID) error
+	// List returns adopters in id order starting after the given ID, nil starts from the beginning
+	List(ctx context.Context, after *uuid.UUID, limit int) ([]*model.Adopter, error)
+}
+
+// FosterRepository contains methods of the foster storage and of the cat placements with fosters.
+// A cat has one active placement at most, AddPlacement returns model.ErrConflict for the second one
+//go:generate mockery --dir . --name FosterRepository --output ./repository_mock
+type FosterRepository interface {
+	Get(context.Context, uuid.UUID) (*model.Foster, error)
+	Create(context.Context, *model.Foster) error
+	Update(context.Context, *model.Foster) error
+	// Delete removes the foster, model.ErrConflict is returned if the foster has placements
+	Delete(context.Context, uuid.UUID) error
+	// List returns fosters in id order starting after the given ID, nil starts from the beginning
+	List(ctx context.Context, after *uuid.UUID, limit int) ([]*model.Foster, error)
+	// ListPlacements returns placements of the cat in the order they started
+	ListPlacements(ctx context.Context, catID uuid.UUID) ([]*model.Placement, error)
+	AddPlacement(ctx context.Context, placement *model.Placement) error
+	// EndPlacement ends the placement, model.ErrConflict is returned if the placement is not active
+	EndPlacement(ctx context.Context, catID, id uuid.UUID, endedAt time.Time, notes string) error
+}
+
+// VaccinationRepository keeps vaccination records of cats
+//go:generate mockery --dir . --name VaccinationRepository --output ./repository_mock
+type VaccinationRepository interface {
+	ListVaccinations(ctx context.Context, catID uuid.UUID) ([]*model.Vaccination, error)
+	AddVaccination(ctx context.Context, catID uuid.UUID, vaccination *model.Vaccination) error
+	DeleteVaccination(ctx context.Context, catID, id uuid.UUID) error
+	// DueVaccinations returns the latest record of every vaccine of the cats in care which expires before the given time
+	DueVaccinations(ctx context.Context, before time.Time) ([]*model.VaccinationDue, error)
+}
+
+// PhotoRepository keeps descriptions of cat photos, the images are kept in the BlobStore
+//go:generate mockery --dir . --name PhotoRepository --output ./repository_mock
+type PhotoRepository interface {
+	ListPhotos(ctx context.Context, catID uuid.UUID) ([]*model.Photo, error)
+	// AddPhoto saves the photo unless the cat already has limit photos, then it returns model.ErrConflict
+	AddPhoto(ctx context.Context, catID uuid.UUID, photo *model.Photo, limit int) error
+	DeletePhoto(ctx context.Context, catID, id uuid.UUID) error
+}
+
+// IntakeRepository keeps intake records of cats with their outcomes.
+// A cat has one open intake at most, AddIntake returns model.ErrConflict for the second one
+//go:generate mockery --dir . --name IntakeRepository --output ./repository_mock
+type IntakeRepository interface {
+	ListIntakes(ctx context.Context, catID uuid.UUID) ([]*model.Intake, error)
+	AddIntake(ctx context.Context, catID uuid.UUID, intake *model.Intake) error
+	// CloseIntake records the outcome of the intake, model.ErrConflict is returned if the intake is not open
+	CloseIntake(ctx context.Context, catID, id uuid.UUID, outcome *model.Outcome) error
+}
+
+// BlobStore keeps binary objects by slash separated keys.
+// Get of the missing blob returns model.ErrPhotoNotFound
+//go:generate mockery --dir . --name BlobStore --output ./repository_mock
+type BlobStore interface {
+	Put(ctx context.Context, key string, data io.Reader) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+	// DeletePrefix removes all blobs with keys under the slash separated prefix
+	DeletePrefix(ctx context.Context, prefix string) error
+}
+
+// JobRepository keeps the locks and the run history of the scheduled jobs shared by the replicas
+//go:generate mockery --dir . --name JobRepository --output ./repository_mock
+type JobRepository interface {
+	// Lock takes the lock for the owner unless somebody holds it, the lock is released after ttl
+	Lock(ctx context.Context, key, owner string, ttl time.Duration) (bool, error)
+	// Renew extends the lock held by the owner to ttl from now, false means the owner lost the lock
+	Renew(ctx context.Context, key, owner string, ttl time.Duration) (bool, error)
+	// AddRun saves the run of the job, only the latest keep runs of the job are kept
+	AddRun(ctx context.Context, run *model.JobRun, keep int) error
+	// ListRuns returns the latest runs of the job, the last run goes first
+	ListRuns(ctx context.Context, job string, limit int) ([]*model.JobRun, error)
+}
+
+// CheckpointRepository keeps the progress of the copy between storages
+//go:generate mockery --dir . --name CheckpointRepository --output ./repository_mock
+type CheckpointRepository interface {
+	// Load returns the saved checkpoint, nil when there is none
+	Load(context.Context) (*model.CopyCheckpoint, error)
+	Save(context.Context, *model.CopyCheckpoint) error
+}
+
+// OutboxRepository keeps the cache events the cat repositories write with the cat changes until they are published
+//go:generate mockery --dir . --name OutboxRepository --output ./repository_mock
+type OutboxRepository interface {
+	// Pending returns the oldest not sent and not given up messages in the order they were written
+	Pending(ctx context.Context, limit int) ([]*model.OutboxMessage, error)
+	// MarkSent marks the message as sent, Pending doesn't return it anymore
+	MarkSent(ctx context.Context, id uuid.UUID) error
+	// MarkFailed counts the failed attempt to publish the message and keeps the reason
+	MarkFailed(ctx context.Context, id uuid.UUID, reason string) error
+	// MarkDead counts the last failed attempt and gives the message up, Pending doesn't return it anymore.
+	// PurgeSent keeps the given up messages for the investigation
+	MarkDead(ctx context.Context, id uuid.UUID, reason string) error
+	// PurgeSent removes the messages sent before the given time and returns their number
+	PurgeSent(ctx context.Context, before time.Time) (int64, error)
 }
 
 // RedisRepository interface
+//go:generate mockery --dir . --name RedisRepository --output ./repository_mock
 type RedisRepository interface {
-	Get(context.Context, uuid.UUID) (*model.Cat, error)
-	Create(context.Context, *model.Cat) error
-	Delete(context.Context, uuid.UUID) error
+	Get(fmt.Stringer) (*model.Cat, error)
+	// GetByMicrochip returns the cached cat with the normalized microchip number
+	GetByMicrochip(chip string) (*model.Cat, error)
+	// Publish adds the outbox message to the cache stream, the caches of all replicas apply it
+	Publish(context.Context, *model.OutboxMessage) error
 }
 
 // NewPostgresRepository constructor
@@ -37,7 +193,202 @@ func NewMongoRepository(database *mongo.Database) SheltersCatRepository {
 	return NewCatMongo(database)
 }
 
+// NewMemoryRepository constructor
+func NewMemoryRepository(store *MemoryStore) SheltersCatRepository {
+	return NewCatMemory(store)
+}
+
+// NewSQLiteRepository constructor
+func NewSQLiteRepository(db *SQLiteDB) SheltersCatRepository {
+	return NewCatSQLite(db)
+}
+
+// NewEventPostgresRepository constructor
+func NewEventPostgresRepository(pool *pgxpool.Pool) CatEventRepository {
+	return NewCatEventPostgres(pool)
+}
+
+// NewEventMongoRepository constructor
+func NewEventMongoRepository(database *mongo.Database) CatEventRepository {
+	return NewCatEventMongo(database)
+}
+
+// NewEventMemoryRepository constructor
+func NewEventMemoryRepository(store *MemoryStore) CatEventRepository {
+	return NewCatEventMemory(store)
+}
+
+// NewEventSQLiteRepository constructor
+func NewEventSQLiteRepository(db *SQLiteDB) CatEventRepository {
+	return NewCatEventSQLite(db)
+}
+
+// NewShelterPostgresRepository constructor
+func NewShelterPostgresRepository(pool *pgxpool.Pool) ShelterRepository {
+	return NewShelterPostgres(pool)
+}
+
+// NewShelterMongoRepository constructor
+func NewShelterMongoRepository(database *mongo.Database) ShelterRepository {
+	return NewShelterMongo(database)
+}
+
+// NewShelterMemoryRepository constructor
+func NewShelterMemoryRepository(store *MemoryStore) ShelterRepository {
+	return NewShelterMemory(store)
+}
+
+// NewShelterSQLiteRepository constructor
+func NewShelterSQLiteRepository(db *SQLiteDB) ShelterRepository {
+	return NewShelterSQLite(db)
+}
+
+// NewAdopterPostgresRepository constructor
+func NewAdopterPostgresRepository(pool *pgxpool.Pool) AdopterRepository {
+	return NewAdopterPostgres(pool)
+}
+
+// NewAdopterMongoRepository constructor
+func NewAdopterMongoRepository(database *mongo.Database) AdopterRepository {
+	return NewAdopterMongo(database)
+}
+
+// NewAdopterMemoryRepository constructor
+func NewAdopterMemoryRepository(store *MemoryStore) AdopterRepository {
+	return NewAdopterMemory(store)
+}
+
+// NewAdopterSQLiteRepository constructor
+func NewAdopterSQLiteRepository(db *SQLiteDB) AdopterRepository {
+	return NewAdopterSQLite(db)
+}
+
+// NewFosterPostgresRepository constructor
+func NewFosterPostgresRepository(pool *pgxpool.Pool) FosterRepository {
+	return NewFosterPostgres(pool)
+}
+
+// NewFosterMongoRepository constructor
+func NewFosterMongoRepository(database *mongo.Database) FosterRepository {
+	return NewFosterMongo(database)
+}
+
+// NewFosterMemoryRepository constructor
+func NewFosterMemoryRepository(store *MemoryStore) FosterRepository {
+	return NewFosterMemory(store)
+}
+
+// NewFosterSQLiteRepository constructor
+func NewFosterSQLiteRepository(db *SQLiteDB) FosterRepository {
+	return NewFosterSQLite(db)
+}
+
+// NewVaccinationPostgresRepository constructor
+func NewVaccinationPostgresRepository(pool *pgxpool.Pool) VaccinationRepository {
+	return NewVaccinationPostgres(pool)
+}
+
+// NewVaccinationMongoRepository constructor
+func NewVaccinationMongoRepository(database *mongo.Database) VaccinationRepository {
+	return NewVaccinationMongo(database)
+}
+
+// NewVaccinationMemoryRepository constructor
+func NewVaccinationMemoryRepository(store *MemoryStore) VaccinationRepository {
+	return NewVaccinationMemory(store)
+}
+
+// NewVaccinationSQLiteRepository constructor
+func NewVaccinationSQLiteRepository(db *SQLiteDB) VaccinationRepository {
+	return NewVaccinationSQLite(db)
+}
+
+// NewPhotoPostgresRepository constructor
+func NewPhotoPostgresRepository(pool *pgxpool.Pool) PhotoRepository {
+	return NewPhotoPostgres(pool)
+}
+
+// NewPhotoMongoRepository constructor
+func NewPhotoMongoRepository(database *mongo.Database) PhotoRepository {
+	return NewPhotoMongo(database)
+}
+
+// NewPhotoMemoryRepository constructor
+func NewPhotoMemoryRepository(store *MemoryStore) PhotoRepository {
+	return NewPhotoMemory(store)
+}
+
+// NewPhotoSQLiteRepository constructor
+func NewPhotoSQLiteRepository(db *SQLiteDB) PhotoRepository {
+	return NewPhotoSQLite(db)
+}
+
+// NewIntakePostgresRepository constructor
+func NewIntakePostgresRepository(pool *pgxpool.Pool) IntakeRepository {
+	return NewIntakePostgres(pool)
+}
+
+// NewIntakeMongoRepository constructor
+func NewIntakeMongoRepository(database *mongo.Database) IntakeRepository {
+	return NewIntakeMongo(database)
+}
+
+// NewIntakeMemoryRepository constructor
+func NewIntakeMemoryRepository(store *MemoryStore) IntakeRepository {
+	return NewIntakeMemory(store)
+}
+
+// NewIntakeSQLiteRepository constructor
+func NewIntakeSQLiteRepository(db *SQLiteDB) IntakeRepository {
+	return NewIntakeSQLite(db)
+}
+
+// NewOutboxPostgresRepository constructor
+func NewOutboxPostgresRepository(pool *pgxpool.Pool) OutboxRepository {
+	return NewOutboxPostgres(pool)
+}
+
+// NewOutboxMongoRepository constructor
+func NewOutboxMongoRepository(database *mongo.Database) OutboxRepository {
+	return NewOutboxMongo(database)
+}
+
+// NewOutboxMemoryRepository constructor
+func NewOutboxMemoryRepository(store *MemoryStore) OutboxRepository {
+	return NewOutboxMemory(store)
+}
+
+// NewOutboxSQLiteRepository constructor
+func NewOutboxSQLiteRepository(db *SQLiteDB) OutboxRepository {
+	return NewOutboxSQLite(db)
+}
+
+// NewLocalBlobStore constructor
+func NewLocalBlobStore(dir string) BlobStore {
+	return NewLocalBlob(dir)
+}
+
+// NewCheckpointFileRepository constructor
+func NewCheckpointFileRepository(path string) CheckpointRepository {
+	return NewCheckpointFile(path)
+}
+
 // NewLocalCache constructor
 func NewLocalCache(ctx context.Context, client *redis.Client) *CatRedisCache {
 	return NewRedisCache(ctx, client)
 }
+
+// NewProcessCacheRepository constructor
+func NewProcessCacheRepository() RedisRepository {
+	return NewProcessCache()
+}
+
+// NewJobMemoryRepository constructor
+func NewJobMemoryRepository() JobRepository {
+	return NewJobMemory()
+}
+
+// NewJobRedisRepository constructor
+func NewJobRedisRepository(client *redis.Client) JobRepository {
+	return NewJobRedis(client)
+}
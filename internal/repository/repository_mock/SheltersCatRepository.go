@@ -4,11 +4,11 @@ package mocks
 
 import (
 	context "context"
+	time "time"
 
 	model "github.com/catService/internal/model"
-	mock "github.com/stretchr/testify/mock"
-
 	uuid "github.com/google/uuid"
+	mock "github.com/stretchr/testify/mock"
 )
 
 // SheltersCatRepository is an autogenerated mock type for the SheltersCatRepository type
@@ -30,12 +30,12 @@ func (_m *SheltersCatRepository) Create(_a0 context.Context, _a1 *model.Cat) err
 	return r0
 }
 
-// Delete provides a mock function with given fields: _a0, _a1
-func (_m *SheltersCatRepository) Delete(_a0 context.Context, _a1 uuid.UUID) error {
+// CreateMany provides a mock function with given fields: _a0, _a1
+func (_m *SheltersCatRepository) CreateMany(_a0 context.Context, _a1 []*model.Cat) error {
 	ret := _m.Called(_a0, _a1)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, []*model.Cat) error); ok {
 		r0 = rf(_a0, _a1)
 	} else {
 		r0 = ret.Error(0)
@@ -44,6 +44,34 @@ func (_m *SheltersCatRepository) Delete(_a0 context.Context, _a1 uuid.UUID) erro
 	return r0
 }
 
+// Delete provides a mock function with given fields: ctx, id, version
+func (_m *SheltersCatRepository) Delete(ctx context.Context, id uuid.UUID, version int64) error {
+	ret := _m.Called(ctx, id, version)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int64) error); ok {
+		r0 = rf(ctx, id, version)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Export provides a mock function with given fields: ctx, filter, fn
+func (_m *SheltersCatRepository) Export(ctx context.Context, filter *model.CatFilter, fn func(*model.Cat) error) error {
+	ret := _m.Called(ctx, filter, fn)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.CatFilter, func(*model.Cat) error) error); ok {
+		r0 = rf(ctx, filter, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Get provides a mock function with given fields: _a0, _a1
 func (_m *SheltersCatRepository) Get(_a0 context.Context, _a1 uuid.UUID) (*model.Cat, error) {
 	ret := _m.Called(_a0, _a1)
@@ -67,6 +95,142 @@ func (_m *SheltersCatRepository) Get(_a0 context.Context, _a1 uuid.UUID) (*model
 	return r0, r1
 }
 
+// GetByMicrochip provides a mock function with given fields: ctx, chip
+func (_m *SheltersCatRepository) GetByMicrochip(ctx context.Context, chip string) (*model.Cat, error) {
+	ret := _m.Called(ctx, chip)
+
+	var r0 *model.Cat
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.Cat); ok {
+		r0 = rf(ctx, chip)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Cat)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, chip)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// List provides a mock function with given fields: ctx, query, after
+func (_m *SheltersCatRepository) List(ctx context.Context, query *model.CatQuery, after *model.Cat) ([]*model.Cat, error) {
+	ret := _m.Called(ctx, query, after)
+
+	var r0 []*model.Cat
+	if rf, ok := ret.Get(0).(func(context.Context, *model.CatQuery, *model.Cat) []*model.Cat); ok {
+		r0 = rf(ctx, query, after)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Cat)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *model.CatQuery, *model.Cat) error); ok {
+		r1 = rf(ctx, query, after)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Patch provides a mock function with given fields: ctx, id, version, patch
+func (_m *SheltersCatRepository) Patch(ctx context.Context, id uuid.UUID, version int64, patch *model.CatPatch) (int64, error) {
+	ret := _m.Called(ctx, id, version, patch)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int64, *model.CatPatch) int64); ok {
+		r0 = rf(ctx, id, version, patch)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, int64, *model.CatPatch) error); ok {
+		r1 = rf(ctx, id, version, patch)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Purge provides a mock function with given fields: ctx, before
+func (_m *SheltersCatRepository) Purge(ctx context.Context, before time.Time) ([]uuid.UUID, error) {
+	ret := _m.Called(ctx, before)
+
+	var r0 []uuid.UUID
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []uuid.UUID); ok {
+		r0 = rf(ctx, before)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]uuid.UUID)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Restore provides a mock function with given fields: ctx, id, version
+func (_m *SheltersCatRepository) Restore(ctx context.Context, id uuid.UUID, version int64) (*model.Cat, error) {
+	ret := _m.Called(ctx, id, version)
+
+	var r0 *model.Cat
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int64) *model.Cat); ok {
+		r0 = rf(ctx, id, version)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Cat)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, int64) error); ok {
+		r1 = rf(ctx, id, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Stats provides a mock function with given fields: ctx, filter
+func (_m *SheltersCatRepository) Stats(ctx context.Context, filter *model.StatsFilter) (*model.CatStats, error) {
+	ret := _m.Called(ctx, filter)
+
+	var r0 *model.CatStats
+	if rf, ok := ret.Get(0).(func(context.Context, *model.StatsFilter) *model.CatStats); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.CatStats)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *model.StatsFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Update provides a mock function with given fields: _a0, _a1
 func (_m *SheltersCatRepository) Update(_a0 context.Context, _a1 *model.Cat) error {
 	ret := _m.Called(_a0, _a1)
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/catService/internal/model"
+
+	"github.com/google/uuid"
+)
+
+// ShelterMemoryRepository keeps shelters in the memory store
+type ShelterMemoryRepository struct {
+	store *MemoryStore
+}
+
+// NewShelterMemory create new instance
+func NewShelterMemory(store *MemoryStore) *ShelterMemoryRepository {
+	return &ShelterMemoryRepository{store: store}
+}
+
+// Get returns shelter
+func (r *ShelterMemoryRepository) Get(ctx context.Context, id uuid.UUID) (*model.Shelter, error) {
+	r.store.mutex.RLock()
+	defer r.store.mutex.RUnlock()
+
+	shelter, exist := r.store.data.Shelters[id]
+	if !exist {
+		return nil, fmt.Errorf("get method error %w", model.ErrShelterNotFound)
+	}
+	copied := *shelter
+
+	return &copied, nil
+}
+
+// Create new shelter in the store
+func (r *ShelterMemoryRepository) Create(ctx context.Context, shelter *model.Shelter) error {
+	r.store.mutex.Lock()
+	defer r.store.mutex.Unlock()
+
+	if _, exist := r.store.data.Shelters[shelter.ID]; exist {
+		return fmt.Errorf("create method error %w: shelter %s already exists", model.ErrConflict, shelter.ID)
+	}
+	copied := *shelter
+	r.store.data.Shelters[shelter.ID] = &copied
+
+	return nil
+}
+
+// Update states for shelter
+func (r *ShelterMemoryRepository) Update(ctx context.Context, shelter *model.Shelter) error {
+	r.store.mutex.Lock()
+	defer r.store.mutex.Unlock()
+
+	if _, exist := r.store.data.Shelters[shelter.ID]; !exist {
+		return fmt.Errorf("update method error %w", model.ErrShelterNotFound)
+	}
+	copied := *shelter
+	r.store.data.Shelters[shelter.ID] = &copied
+
+	return nil
+}
+
+// Delete removes shelter which has no cats, deleted cats count until they are purged like in Postgres
+func (r *ShelterMemoryRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.store.mutex.Lock()
+	defer r.store.mutex.Unlock()
+
+	if _, exist := r.store.data.Shelters[id]; !exist {
+		return fmt.Errorf("delete method error %w", model.ErrShelterNotFound)
+	}
+	for _, entry := range r.store.data.Cats {
+		if entry.Cat.ShelterID != nil && *entry.Cat.ShelterID == id {
+			return fmt.Errorf("delete method error %w: shelter has cats", model.ErrConflict)
+		}
+	}
+	delete(r.store.data.Shelters, id)
+
+	return nil
+}
+
+// List returns shelters in id order starting after the given one
+func (r *ShelterMemoryRepository) List(ctx context.Context, after *uuid.UUID, limit int) ([]*model.Shelter, error) {
+	r.store.mutex.RLock()
+	defer r.store.mutex.RUnlock()
+
+	ids := make([]uuid.UUID, 0, len(r.store.data.Shelters))
+	for id := range r.store.data.Shelters {
+		ids = append(ids, id)
+	}
+	ids = pageIDs(ids, after, limit)
+	shelters := make([]*model.Shelter, 0, len(ids))
+	for _, id := range ids {
+		copied := *r.store.data.Shelters[id]
+		shelters = append(shelters, &copied)
+	}
+
+	return shelters, nil
+}
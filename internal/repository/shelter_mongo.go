@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/catService/internal/model"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ShelterMongoRepository contains a link to the connection to db
+type ShelterMongoRepository struct {
+	db *mongo.Database
+}
+
+// NewShelterMongo create new instance
+func NewShelterMongo(database *mongo.Database) *ShelterMongoRepository {
+	return &ShelterMongoRepository{db: database}
+}
+
+// Get returns shelter
+func (c *ShelterMongoRepository) Get(ctx context.Context, id uuid.UUID) (*model.Shelter, error) {
+	shelter := model.Shelter{}
+	err := c.db.Collection("shelter").FindOne(ctx, bson.M{"_id": id}).Decode(&shelter)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, fmt.Errorf("get method error %w", model.ErrShelterNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get method error %w", err)
+	}
+
+	return &shelter, nil
+}
+
+// Create new shelter in db
+func (c *ShelterMongoRepository) Create(ctx context.Context, shelter *model.Shelter) error {
+	_, err := c.db.Collection("shelter").InsertOne(ctx, shelter)
+	if err != nil {
+		return fmt.Errorf("create method error %w", mongoError(err))
+	}
+
+	return nil
+}
+
+// Update states for shelter
+func (c *ShelterMongoRepository) Update(ctx context.Context, shelter *model.Shelter) error {
+	result, err := c.db.Collection("shelter").ReplaceOne(ctx, bson.M{"_id": shelter.ID}, shelter)
+	if err != nil {
+		return fmt.Errorf("update method error %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("update method error %w", model.ErrShelterNotFound)
+	}
+
+	return nil
+}
+
+// Delete removes shelter, the service checks it has no cats
+func (c *ShelterMongoRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result, err := c.db.Collection("shelter").DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return fmt.Errorf("delete method error %w", err)
+	}
+	if result.DeletedCount == 0 {
+		return fmt.Errorf("delete method error %w", model.ErrShelterNotFound)
+	}
+
+	return nil
+}
+
+// List returns shelters in id order starting after the given one
+func (c *ShelterMongoRepository) List(ctx context.Context, after *uuid.UUID, limit int) ([]*model.Shelter, error) {
+	filter := bson.M{}
+	if after != nil {
+		filter["_id"] = bson.M{"$gt": *after}
+	}
+
+	cursor, err := c.db.Collection("shelter").Find(ctx, filter,
+		options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}).SetLimit(int64(limit)))
+	if err != nil {
+		return nil, fmt.Errorf("list method error %w", err)
+	}
+	shelters := make([]*model.Shelter, 0, limit)
+	if err := cursor.All(ctx, &shelters); err != nil {
+		return nil, fmt.Errorf("failed decode shelters from DB %w", err)
+	}
+
+	return shelters, nil
+}
@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/catService/internal/model"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// shelterColumns are selected in the order scanShelter reads them
+const shelterColumns = "id, name, address, capacity, contact"
+
+// ShelterPostgresRepository contains a link to the connection to db
+type ShelterPostgresRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewShelterPostgres create new instance
+func NewShelterPostgres(pool *pgxpool.Pool) *ShelterPostgresRepository {
+	return &ShelterPostgresRepository{db: pool}
+}
+
+// Get returns shelter
+func (r *ShelterPostgresRepository) Get(ctx context.Context, id uuid.UUID) (*model.Shelter, error) {
+	row := r.db.QueryRow(ctx, "SELECT "+shelterColumns+" FROM shelters WHERE id = $1", id)
+
+	shelter, err := scanShelter(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("get method error %w", model.ErrShelterNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get method error %w", err)
+	}
+
+	return shelter, nil
+}
+
+// Create new shelter in db
+func (r *ShelterPostgresRepository) Create(ctx context.Context, shelter *model.Shelter) error {
+	_, err := r.db.Exec(ctx, "INSERT INTO shelters("+shelterColumns+") VALUES ($1,$2,$3,$4,$5)",
+		shelter.ID, shelter.Name, shelter.Address, shelter.Capacity, shelter.Contact)
+	if err != nil {
+		return fmt.Errorf("create method error %w", pgError(err))
+	}
+
+	return nil
+}
+
+// Update states for shelter
+func (r *ShelterPostgresRepository) Update(ctx context.Context, shelter *model.Shelter) error {
+	tag, err := r.db.Exec(ctx, "UPDATE shelters SET name=$1, address=$2, capacity=$3, contact=$4 WHERE id=$5",
+		shelter.Name, shelter.Address, shelter.Capacity, shelter.Contact, shelter.ID)
+	if err != nil {
+		return fmt.Errorf("update method error %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("update method error %w", model.ErrShelterNotFound)
+	}
+
+	return nil
+}
+
+// Delete removes shelter, shelters with cats are protected by the foreign key
+func (r *ShelterPostgresRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	tag, err := r.db.Exec(ctx, "DELETE FROM shelters WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("delete method error %w", pgError(err))
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("delete method error %w", model.ErrShelterNotFound)
+	}
+
+	return nil
+}
+
+// List returns shelters in id order starting after the given one
+func (r *ShelterPostgresRepository) List(ctx context.Context, after *uuid.UUID, limit int) ([]*model.Shelter, error) {
+	rows, err := r.db.Query(ctx, "SELECT "+shelterColumns+` FROM shelters
+		WHERE ($1::uuid IS NULL OR id > $1) ORDER BY id LIMIT $2`, after, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list method error %w", err)
+	}
+	defer rows.Close()
+
+	shelters := make([]*model.Shelter, 0, limit)
+	for rows.Next() {
+		shelter, err := scanShelter(rows)
+		if err != nil {
+			return nil, fmt.Errorf("list method error %w", err)
+		}
+		shelters = append(shelters, shelter)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list method error %w", err)
+	}
+
+	return shelters, nil
+}
+
+// scanShelter reads shelter from the row with shelterColumns
+func scanShelter(row pgx.Row) (*model.Shelter, error) {
+	shelter := model.Shelter{}
+	err := row.Scan(&shelter.ID, &shelter.Name, &shelter.Address, &shelter.Capacity, &shelter.Contact)
+	if err != nil {
+		return nil, err
+	}
+
+	return &shelter, nil
+}
@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/catService/internal/model"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShelters(t *testing.T) {
+	requirePostgres(t)
+	shelter := &model.Shelter{ID: uuid.New(), Name: "Shelter 1", Address: "Main st. 1", Capacity: 10}
+	require.NoError(t, shelters.Create(context.Background(), shelter))
+
+	shelter.Capacity = 20
+	require.NoError(t, shelters.Update(context.Background(), shelter))
+	stored, err := shelters.Get(context.Background(), shelter.ID)
+	require.NoError(t, err)
+	require.Equal(t, shelter, stored)
+
+	sheltered := &model.Cat{ID: uuid.New(), Name: "Cat 10", Age: 1, ShelterID: &shelter.ID, Version: 1}
+	require.NoError(t, repository.Create(context.Background(), sheltered))
+	cats, err := repository.List(context.Background(), &model.CatQuery{
+		CatFilter: model.CatFilter{ShelterID: &shelter.ID}, SortBy: model.CatSortID, Limit: 10}, nil)
+	require.NoError(t, err)
+	require.Len(t, cats, 1)
+	require.Equal(t, shelter.ID, *cats[0].ShelterID)
+
+	err = shelters.Delete(context.Background(), shelter.ID)
+	require.ErrorIs(t, err, model.ErrConflict)
+
+	unknown := uuid.New()
+	_, err = repository.Patch(context.Background(), sheltered.ID, model.AnyVersion, &model.CatPatch{ShelterID: &unknown})
+	require.ErrorIs(t, err, model.ErrConflict)
+	_, err = repository.Patch(context.Background(), sheltered.ID, model.AnyVersion, &model.CatPatch{ShelterID: &uuid.Nil})
+	require.NoError(t, err)
+	require.NoError(t, shelters.Delete(context.Background(), shelter.ID))
+
+	_, err = shelters.Get(context.Background(), shelter.ID)
+	require.ErrorIs(t, err, model.ErrShelterNotFound)
+}
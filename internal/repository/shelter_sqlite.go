@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/catService/internal/model"
+
+	"github.com/google/uuid"
+)
+
+// ShelterSQLiteRepository contains a link to the SQLite database
+type ShelterSQLiteRepository struct {
+	db *SQLiteDB
+}
+
+// NewShelterSQLite create new instance
+func NewShelterSQLite(db *SQLiteDB) *ShelterSQLiteRepository {
+	return &ShelterSQLiteRepository{db: db}
+}
+
+// Get returns shelter
+func (r *ShelterSQLiteRepository) Get(ctx context.Context, id uuid.UUID) (*model.Shelter, error) {
+	row := r.db.QueryRow(ctx, "SELECT "+shelterColumns+" FROM shelters WHERE id = ?1", id)
+
+	shelter, err := scanShelter(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("get method error %w", model.ErrShelterNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get method error %w", err)
+	}
+
+	return shelter, nil
+}
+
+// Create new shelter in db
+func (r *ShelterSQLiteRepository) Create(ctx context.Context, shelter *model.Shelter) error {
+	_, err := r.db.Exec(ctx, "INSERT INTO shelters("+shelterColumns+") VALUES (?1,?2,?3,?4,?5)",
+		shelter.ID, shelter.Name, shelter.Address, shelter.Capacity, shelter.Contact)
+	if err != nil {
+		return fmt.Errorf("create method error %w", sqliteError(err))
+	}
+
+	return nil
+}
+
+// Update states for shelter
+func (r *ShelterSQLiteRepository) Update(ctx context.Context, shelter *model.Shelter) error {
+	affected, err := r.db.Exec(ctx, "UPDATE shelters SET name=?1, address=?2, capacity=?3, contact=?4 WHERE id=?5",
+		shelter.Name, shelter.Address, shelter.Capacity, shelter.Contact, shelter.ID)
+	if err != nil {
+		return fmt.Errorf("update method error %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("update method error %w", model.ErrShelterNotFound)
+	}
+
+	return nil
+}
+
+// Delete removes shelter, shelters with cats are protected by the foreign key
+func (r *ShelterSQLiteRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	affected, err := r.db.Exec(ctx, "DELETE FROM shelters WHERE id = ?1", id)
+	if err != nil {
+		return fmt.Errorf("delete method error %w", sqliteError(err))
+	}
+	if affected == 0 {
+		return fmt.Errorf("delete method error %w", model.ErrShelterNotFound)
+	}
+
+	return nil
+}
+
+// List returns shelters in id order starting after the given one
+func (r *ShelterSQLiteRepository) List(ctx context.Context, after *uuid.UUID, limit int) ([]*model.Shelter, error) {
+	rows, err := r.db.Query(ctx, "SELECT "+shelterColumns+` FROM shelters
+		WHERE (?1 IS NULL OR id > ?1) ORDER BY id LIMIT ?2`, after, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list method error %w", err)
+	}
+	defer rows.Close()
+
+	shelters := make([]*model.Shelter, 0, limit)
+	for rows.Next() {
+		shelter, err := scanShelter(rows)
+		if err != nil {
+			return nil, fmt.Errorf("list method error %w", err)
+		}
+		shelters = append(shelters, shelter)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list method error %w", err)
+	}
+
+	return shelters, nil
+}
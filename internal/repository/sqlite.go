@@ -0,0 +1,178 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// sqliteMigrations are the SQLite migrations in the flyway naming, V<version>__<description>.sql
+//
+//go:embed sqlite/*.sql
+var sqliteMigrations embed.FS
+
+// SQLiteDB is the connection to the SQLite database file.
+// It takes the arguments the way pgx does: times are written in UTC,
+// so their text compares in the time order like timestamps in Postgres
+type SQLiteDB struct {
+	db *sql.DB
+}
+
+// OpenSQLite opens the database file, creates it if it doesn't exist and applies the missing migrations.
+// SQLite has one writer at a time: readers work beside it in WAL mode, writers wait for the busy timeout
+// and transactions take the write lock when they begin
+func OpenSQLite(ctx context.Context, path string) (*SQLiteDB, error) {
+	options := url.Values{}
+	options.Set("_foreign_keys", "1")
+	options.Set("_case_sensitive_like", "1")
+	options.Set("_journal_mode", "WAL")
+	options.Set("_busy_timeout", "5000")
+	options.Set("_txlock", "immediate")
+	db, err := sql.Open("sqlite3", "file:"+path+"?"+options.Encode())
+	if err != nil {
+		return nil, fmt.Errorf("open sqlite error %w", err)
+	}
+
+	conn := &SQLiteDB{db: db}
+	if err := conn.migrate(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("open sqlite error %w", err)
+	}
+
+	return conn, nil
+}
+
+// Close closes the database
+func (c *SQLiteDB) Close() error {
+	return c.db.Close()
+}
+
+// migrate applies the migrations newer than the user version of the database, each one in its transaction
+func (c *SQLiteDB) migrate(ctx context.Context) error {
+	files, err := fs.Glob(sqliteMigrations, "sqlite/V*__*.sql")
+	if err != nil {
+		return err
+	}
+	versions := make(map[int]string, len(files))
+	for _, file := range files {
+		version, err := strconv.Atoi(strings.TrimPrefix(strings.SplitN(file, "__", 2)[0], "sqlite/V"))
+		if err != nil {
+			return fmt.Errorf("bad migration name %s", file)
+		}
+		versions[version] = file
+	}
+	order := make([]int, 0, len(versions))
+	for version := range versions {
+		order = append(order, version)
+	}
+	sort.Ints(order)
+
+	var current int
+	if err := c.db.QueryRowContext(ctx, "PRAGMA user_version").Scan(&current); err != nil {
+		return err
+	}
+	for _, version := range order {
+		if version <= current {
+			continue
+		}
+		script, err := sqliteMigrations.ReadFile(versions[version])
+		if err != nil {
+			return err
+		}
+		if err := c.apply(ctx, version, string(script)); err != nil {
+			return fmt.Errorf("migration %s error %w", versions[version], err)
+		}
+	}
+
+	return nil
+}
+
+// apply runs the migration script and records its version
+func (c *SQLiteDB) apply(ctx context.Context, version int, script string) error {
+	tx, err := c.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	// rollback after commit does nothing
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, script); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf("PRAGMA user_version = %d", version)); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Exec runs the statement and returns the number of changed rows
+func (c *SQLiteDB) Exec(ctx context.Context, query string, args ...interface{}) (int64, error) {
+	result, err := c.db.ExecContext(ctx, query, sqliteArgs(args)...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+// Query runs the query returning rows
+func (c *SQLiteDB) Query(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return c.db.QueryContext(ctx, query, sqliteArgs(args)...)
+}
+
+// QueryRow runs the query returning one row
+func (c *SQLiteDB) QueryRow(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return c.db.QueryRowContext(ctx, query, sqliteArgs(args)...)
+}
+
+// sqliteArgs converts times into UTC, other arguments are passed as they are
+func sqliteArgs(args []interface{}) []interface{} {
+	converted := make([]interface{}, len(args))
+	for i, arg := range args {
+		switch value := arg.(type) {
+		case time.Time:
+			converted[i] = value.UTC()
+		case *time.Time:
+			if value != nil {
+				converted[i] = value.UTC()
+			}
+		default:
+			converted[i] = arg
+		}
+	}
+
+	return converted
+}
+
+// sqliteError translates SQLite specific errors into domain errors like pgError does
+func sqliteError(err error) error {
+	var sqliteErr sqlite3.Error
+	if !errors.As(err, &sqliteErr) {
+		return err
+	}
+	switch sqliteErr.ExtendedCode {
+	case sqlite3.ErrConstraintUnique, sqlite3.ErrConstraintPrimaryKey:
+		// the message ends with the columns of the constraint, like "UNIQUE constraint failed: cats.microchip"
+		columns := sqliteErr.Error()
+		if i := strings.LastIndex(columns, ": "); i >= 0 {
+			columns = columns[i+2:]
+		}
+		return conflictError(columns, conflictExists, err)
+	// ON DELETE RESTRICT is checked by the trigger SQLite makes for the foreign key, the schema has no other triggers
+	case sqlite3.ErrConstraintForeignKey, sqlite3.ErrConstraintTrigger:
+		return conflictError("", conflictReferenced, err)
+	default:
+		return err
+	}
+}
@@ -0,0 +1,141 @@
+-- the schema of Postgres migrations V1-V12 in SQLite types,
+-- uuids and times are kept as text, times are written in UTC so the text compares in the time order
+CREATE TABLE cats
+(
+    id                   text      NOT NULL PRIMARY KEY,
+    name                 text      NOT NULL,
+    age                  integer   NOT NULL,
+    vaccinated           boolean   NOT NULL DEFAULT false,
+    shelter_id           text REFERENCES shelters (id) ON DELETE RESTRICT,
+    status               text      NOT NULL DEFAULT 'available'
+        CHECK (status IN ('available', 'reserved', 'adopted', 'returned')),
+    birth_date           date,
+    birth_date_estimated boolean   NOT NULL DEFAULT false,
+    sex                  text      NOT NULL DEFAULT '',
+    breed                text      NOT NULL DEFAULT '',
+    coat_color           text      NOT NULL DEFAULT '',
+    neutered             boolean,
+    intake_date          date,
+    microchip            text UNIQUE,
+    good_with_kids       boolean,
+    version              integer   NOT NULL DEFAULT 1,
+    deleted_at           timestamp
+);
+
+CREATE INDEX cats_deleted_at_idx ON cats (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX cats_shelter_id_idx ON cats (shelter_id);
+CREATE INDEX cats_status_idx ON cats (status);
+
+CREATE TABLE cat_events
+(
+    id         text      NOT NULL PRIMARY KEY,
+    cat_id     text      NOT NULL,
+    action     text      NOT NULL,
+    before     text,
+    after      text,
+    actor      text      NOT NULL,
+    created_at timestamp NOT NULL
+);
+
+CREATE INDEX cat_events_cat_id_idx ON cat_events (cat_id, created_at, id);
+
+-- shelters with cats, even deleted but not purged ones, can't be removed
+CREATE TABLE shelters
+(
+    id       text    NOT NULL PRIMARY KEY,
+    name     text    NOT NULL,
+    address  text    NOT NULL DEFAULT '',
+    capacity integer NOT NULL DEFAULT 0,
+    contact  text    NOT NULL DEFAULT ''
+);
+
+CREATE TABLE cat_vaccinations
+(
+    id              text      NOT NULL PRIMARY KEY,
+    cat_id          text      NOT NULL REFERENCES cats (id) ON DELETE CASCADE,
+    vaccine         text      NOT NULL,
+    administered_at timestamp NOT NULL,
+    lot             text      NOT NULL DEFAULT '',
+    vet             text      NOT NULL DEFAULT '',
+    expires_at      timestamp
+);
+
+CREATE INDEX cat_vaccinations_cat_id_idx ON cat_vaccinations (cat_id, administered_at, id);
+
+CREATE TABLE cat_photos
+(
+    id           text      NOT NULL PRIMARY KEY,
+    cat_id       text      NOT NULL REFERENCES cats (id) ON DELETE CASCADE,
+    content_type text      NOT NULL,
+    size         integer   NOT NULL,
+    width        integer   NOT NULL,
+    height       integer   NOT NULL,
+    created_at   timestamp NOT NULL
+);
+
+CREATE INDEX cat_photos_cat_id_idx ON cat_photos (cat_id, created_at, id);
+
+CREATE TABLE cat_intakes
+(
+    id               text      NOT NULL PRIMARY KEY,
+    cat_id           text      NOT NULL REFERENCES cats (id) ON DELETE CASCADE,
+    type             text      NOT NULL,
+    date             timestamp NOT NULL,
+    location         text      NOT NULL DEFAULT '',
+    notes            text      NOT NULL DEFAULT '',
+    outcome_type     text,
+    outcome_date     timestamp,
+    outcome_location text,
+    outcome_notes    text
+);
+
+CREATE INDEX cat_intakes_cat_id_idx ON cat_intakes (cat_id, date, id);
+
+-- a cat has one open intake at most
+CREATE UNIQUE INDEX cat_intakes_open_idx ON cat_intakes (cat_id) WHERE outcome_type IS NULL;
+
+-- fosters with placements can't be removed
+CREATE TABLE fosters
+(
+    id      text NOT NULL PRIMARY KEY,
+    name    text NOT NULL,
+    address text NOT NULL DEFAULT '',
+    contact text NOT NULL DEFAULT '',
+    notes   text NOT NULL DEFAULT ''
+);
+
+CREATE TABLE cat_placements
+(
+    id         text      NOT NULL PRIMARY KEY,
+    cat_id     text      NOT NULL REFERENCES cats (id) ON DELETE CASCADE,
+    foster_id  text      NOT NULL REFERENCES fosters (id) ON DELETE RESTRICT,
+    started_at timestamp NOT NULL,
+    ended_at   timestamp,
+    notes      text      NOT NULL DEFAULT '',
+    CHECK (ended_at IS NULL OR ended_at >= started_at)
+);
+
+CREATE INDEX cat_placements_cat_id_idx ON cat_placements (cat_id, started_at, id);
+CREATE INDEX cat_placements_foster_id_idx ON cat_placements (foster_id);
+
+-- a cat has one active placement at most
+CREATE UNIQUE INDEX cat_placements_active_idx ON cat_placements (cat_id) WHERE ended_at IS NULL;
+
+CREATE TABLE adopters
+(
+    id                        text    NOT NULL PRIMARY KEY,
+    name                      text    NOT NULL,
+    email                     text    NOT NULL DEFAULT '',
+    phone                     text    NOT NULL DEFAULT '',
+    address                   text    NOT NULL DEFAULT '',
+    household_adults          integer NOT NULL DEFAULT 1,
+    household_children        integer NOT NULL DEFAULT 0,
+    household_other_pets      boolean NOT NULL DEFAULT false,
+    household_home_type       text    NOT NULL DEFAULT '',
+    preferred_min_age         integer,
+    preferred_max_age         integer,
+    preferred_sex             text    NOT NULL DEFAULT '',
+    preferred_vaccinated_only boolean NOT NULL DEFAULT false,
+    preferred_good_with_kids  boolean NOT NULL DEFAULT false,
+    CHECK (preferred_min_age IS NULL OR preferred_max_age IS NULL OR preferred_min_age <= preferred_max_age)
+);
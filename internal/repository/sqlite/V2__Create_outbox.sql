@@ -0,0 +1,13 @@
+-- the rowid orders the messages, SQLite has one writer at a time
+CREATE TABLE outbox
+(
+    id         text      NOT NULL PRIMARY KEY,
+    action     text      NOT NULL,
+    payload    text      NOT NULL,
+    created_at timestamp NOT NULL,
+    attempts   integer   NOT NULL DEFAULT 0,
+    last_error text      NOT NULL DEFAULT '',
+    sent_at    timestamp
+);
+
+CREATE INDEX outbox_sent_at_idx ON outbox (sent_at);
@@ -0,0 +1,2 @@
+-- the relay gives up the message which failed too many times, so it doesn't block the messages after it
+ALTER TABLE outbox ADD COLUMN dead_at timestamp;
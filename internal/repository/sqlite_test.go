@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/catService/internal/model"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func openTestSQLite(t *testing.T) *SQLiteDB {
+	db, err := OpenSQLite(context.Background(), filepath.Join(t.TempDir(), "cats.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		db.Close()
+	})
+
+	return db
+}
+
+func TestSQLiteCats(t *testing.T) {
+	cats := NewSQLiteRepository(openTestSQLite(t))
+
+	chip := "900000000000001"
+	cat := &model.Cat{ID: uuid.New(), Name: "Cat 1", Age: 2, Status: model.CatAvailable, Version: 1, CatProfile: model.CatProfile{Microchip: &chip}}
+	require.NoError(t, cats.Create(context.Background(), cat))
+	other := &model.Cat{ID: uuid.New(), Name: "Cat 2", Age: 1, Status: model.CatAvailable, Version: 1, CatProfile: model.CatProfile{Microchip: &chip}}
+	err := cats.Create(context.Background(), other)
+	require.ErrorIs(t, err, model.ErrConflict)
+	require.Contains(t, err.Error(), "microchip already registered")
+	require.NotContains(t, err.Error(), "UNIQUE")
+	other.Microchip = nil
+	require.NoError(t, cats.CreateMany(context.Background(), []*model.Cat{other}))
+
+	found, err := cats.GetByMicrochip(context.Background(), chip)
+	require.NoError(t, err)
+	require.Equal(t, cat.ID, found.ID)
+
+	vaccinated := true
+	version, err := cats.Patch(context.Background(), cat.ID, 1, &model.CatPatch{Vaccinated: &vaccinated})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), version)
+	_, err = cats.Patch(context.Background(), cat.ID, 1, &model.CatPatch{Vaccinated: &vaccinated})
+	require.ErrorIs(t, err, model.ErrVersionMismatch)
+
+	query := &model.CatQuery{SortBy: model.CatSortAge, Desc: true, Limit: 1}
+	page, err := cats.List(context.Background(), query, nil)
+	require.NoError(t, err)
+	require.Equal(t, []uuid.UUID{cat.ID}, catIDs(page))
+	page, err = cats.List(context.Background(), query, page[0])
+	require.NoError(t, err)
+	require.Equal(t, []uuid.UUID{other.ID}, catIDs(page))
+
+	require.NoError(t, cats.Delete(context.Background(), cat.ID, version))
+	_, err = cats.Get(context.Background(), cat.ID)
+	require.ErrorIs(t, err, model.ErrCatNotFound)
+	restored, err := cats.Restore(context.Background(), cat.ID, model.AnyVersion)
+	require.NoError(t, err)
+	require.Nil(t, restored.DeletedAt)
+	require.Equal(t, int64(4), restored.Version)
+
+	require.NoError(t, cats.Delete(context.Background(), other.ID, model.AnyVersion))
+	purged, err := cats.Purge(context.Background(), time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, []uuid.UUID{other.ID}, purged)
+}
+
+func TestSQLiteRecords(t *testing.T) {
+	db := openTestSQLite(t)
+	shelter := &model.Shelter{ID: uuid.New(), Name: "Shelter 1", Capacity: 10}
+	require.NoError(t, NewShelterSQLiteRepository(db).Create(context.Background(), shelter))
+	cat := &model.Cat{ID: uuid.New(), Name: "Cat 1", Age: 2, Status: model.CatAvailable, Version: 1, ShelterID: &shelter.ID}
+	require.NoError(t, NewSQLiteRepository(db).Create(context.Background(), cat))
+	err := NewShelterSQLiteRepository(db).Delete(context.Background(), shelter.ID)
+	require.ErrorIs(t, err, model.ErrConflict)
+
+	now := time.Now()
+	intakes := NewIntakeSQLiteRepository(db)
+	intake := &model.Intake{ID: uuid.New(), Type: model.IntakeStray, Date: now.AddDate(0, 0, -10)}
+	require.NoError(t, intakes.AddIntake(context.Background(), cat.ID, intake))
+	outcome := &model.Outcome{Type: model.OutcomeAdoption, Date: now.AddDate(0, 0, -4)}
+	require.NoError(t, intakes.CloseIntake(context.Background(), cat.ID, intake.ID, outcome))
+	err = intakes.CloseIntake(context.Background(), cat.ID, intake.ID, outcome)
+	require.ErrorIs(t, err, model.ErrConflict)
+	listed, err := intakes.ListIntakes(context.Background(), cat.ID)
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	require.True(t, intake.Date.Equal(listed[0].Date))
+
+	stats, err := NewSQLiteRepository(db).Stats(context.Background(), &model.StatsFilter{ShelterID: &shelter.ID})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), stats.Total)
+	require.Equal(t, int64(1), stats.Stays)
+	require.InDelta(t, 6, stats.MedianStayDays, 0.01)
+
+	vaccinations := NewVaccinationSQLiteRepository(db)
+	expired, renewed := now.AddDate(0, 0, -30), now.AddDate(0, 0, 5)
+	old := &model.Vaccination{ID: uuid.New(), Vaccine: "Rabies", AdministeredAt: now.AddDate(-1, 0, 0), ExpiresAt: &expired}
+	require.NoError(t, vaccinations.AddVaccination(context.Background(), cat.ID, old))
+	latest := &model.Vaccination{ID: uuid.New(), Vaccine: "rabies", AdministeredAt: now.AddDate(0, 0, -1), ExpiresAt: &renewed}
+	require.NoError(t, vaccinations.AddVaccination(context.Background(), cat.ID, latest))
+	due, err := vaccinations.DueVaccinations(context.Background(), now.AddDate(0, 0, 7))
+	require.NoError(t, err)
+	require.Len(t, due, 1)
+	require.Equal(t, latest.ID, due[0].ID)
+	require.Equal(t, cat.Name, due[0].CatName)
+	due, err = vaccinations.DueVaccinations(context.Background(), now)
+	require.NoError(t, err)
+	require.Empty(t, due)
+}
+
+func TestSQLiteCandidateFilter(t *testing.T) {
+	testCandidateFilter(t, NewSQLiteRepository(openTestSQLite(t)))
+}
+
+func TestSQLitePhotoLimit(t *testing.T) {
+	db := openTestSQLite(t)
+	testPhotoLimit(t, NewSQLiteRepository(db), NewPhotoSQLiteRepository(db))
+}
@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/catService/internal/model"
+
+	"github.com/google/uuid"
+)
+
+// VaccinationMemoryRepository keeps vaccination records with the cat in the memory store
+type VaccinationMemoryRepository struct {
+	store *MemoryStore
+}
+
+// NewVaccinationMemory create new instance
+func NewVaccinationMemory(store *MemoryStore) *VaccinationMemoryRepository {
+	return &VaccinationMemoryRepository{store: store}
+}
+
+// ListVaccinations returns vaccination records of the cat in the order they were administered
+func (r *VaccinationMemoryRepository) ListVaccinations(ctx context.Context, catID uuid.UUID) ([]*model.Vaccination, error) {
+	r.store.mutex.RLock()
+	defer r.store.mutex.RUnlock()
+
+	entry, exist := r.store.data.Cats[catID]
+	if !exist {
+		return nil, fmt.Errorf("list vaccinations method error %w", model.ErrCatNotFound)
+	}
+	vaccinations := make([]*model.Vaccination, 0, len(entry.Vaccinations))
+	for _, vaccination := range entry.Vaccinations {
+		copied := *vaccination
+		vaccinations = append(vaccinations, &copied)
+	}
+	sort.SliceStable(vaccinations, func(i, j int) bool {
+		return vaccinations[i].AdministeredAt.Before(vaccinations[j].AdministeredAt)
+	})
+
+	return vaccinations, nil
+}
+
+// AddVaccination saves new vaccination record of the cat
+func (r *VaccinationMemoryRepository) AddVaccination(ctx context.Context, catID uuid.UUID, vaccination *model.Vaccination) error {
+	r.store.mutex.Lock()
+	defer r.store.mutex.Unlock()
+
+	entry, exist := r.store.data.Cats[catID]
+	if !exist {
+		return fmt.Errorf("add vaccination method error %w", model.ErrCatNotFound)
+	}
+	copied := *vaccination
+	entry.Vaccinations = append(entry.Vaccinations, &copied)
+
+	return nil
+}
+
+// DeleteVaccination removes vaccination record of the cat
+func (r *VaccinationMemoryRepository) DeleteVaccination(ctx context.Context, catID, id uuid.UUID) error {
+	r.store.mutex.Lock()
+	defer r.store.mutex.Unlock()
+
+	if entry, exist := r.store.data.Cats[catID]; exist {
+		for i, vaccination := range entry.Vaccinations {
+			if vaccination.ID == id {
+				entry.Vaccinations = append(entry.Vaccinations[:i:i], entry.Vaccinations[i+1:]...)
+				return nil
+			}
+		}
+	}
+
+	return fmt.Errorf("delete vaccination method error %w", model.ErrVaccinationNotFound)
+}
+
+// DueVaccinations returns the latest record of every vaccine of the cats in care which expires before the given time.
+// Records replaced by a newer shot of the same vaccine are not due
+func (r *VaccinationMemoryRepository) DueVaccinations(ctx context.Context, before time.Time) ([]*model.VaccinationDue, error) {
+	r.store.mutex.RLock()
+	defer r.store.mutex.RUnlock()
+
+	due := make([]*model.VaccinationDue, 0)
+	for _, entry := range r.store.data.Cats {
+		cat := entry.Cat
+		if cat.DeletedAt != nil || cat.Status == model.CatAdopted {
+			continue
+		}
+		latest := make(map[string]*model.Vaccination)
+		for _, vaccination := range entry.Vaccinations {
+			vaccine := strings.ToLower(vaccination.Vaccine)
+			last, exist := latest[vaccine]
+			if !exist || vaccination.AdministeredAt.After(last.AdministeredAt) ||
+				vaccination.AdministeredAt.Equal(last.AdministeredAt) && compareIDs(vaccination.ID, last.ID) > 0 {
+				latest[vaccine] = vaccination
+			}
+		}
+		for _, vaccination := range latest {
+			if vaccination.ExpiresAt != nil && vaccination.ExpiresAt.Before(before) {
+				due = append(due, &model.VaccinationDue{
+					CatID: cat.ID, CatName: cat.Name, ShelterID: cat.ShelterID, Vaccination: *vaccination,
+				})
+			}
+		}
+	}
+	sort.Slice(due, func(i, j int) bool {
+		if !due[i].ExpiresAt.Equal(*due[j].ExpiresAt) {
+			return due[i].ExpiresAt.Before(*due[j].ExpiresAt)
+		}
+		return compareIDs(due[i].CatID, due[j].CatID) < 0
+	})
+
+	return due, nil
+}
@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/catService/internal/model"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// VaccinationMongoRepository keeps vaccination records embedded into the cat document
+type VaccinationMongoRepository struct {
+	db *mongo.Database
+}
+
+// NewVaccinationMongo create new instance
+func NewVaccinationMongo(database *mongo.Database) *VaccinationMongoRepository {
+	return &VaccinationMongoRepository{db: database}
+}
+
+// ListVaccinations returns vaccination records of the cat in the order they were administered
+func (c *VaccinationMongoRepository) ListVaccinations(ctx context.Context, catID uuid.UUID) ([]*model.Vaccination, error) {
+	var document struct {
+		Vaccinations []*model.Vaccination `bson:"vaccinations"`
+	}
+	opts := options.FindOne().SetProjection(bson.M{"vaccinations": 1})
+	err := c.db.Collection("cat").FindOne(ctx, bson.M{"_id": catID}, opts).Decode(&document)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, fmt.Errorf("list vaccinations method error %w", model.ErrCatNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("list vaccinations method error %w", err)
+	}
+
+	vaccinations := document.Vaccinations
+	if vaccinations == nil {
+		vaccinations = make([]*model.Vaccination, 0)
+	}
+	sort.SliceStable(vaccinations, func(i, j int) bool {
+		return vaccinations[i].AdministeredAt.Before(vaccinations[j].AdministeredAt)
+	})
+
+	return vaccinations, nil
+}
+
+// AddVaccination saves new vaccination record of the cat
+func (c *VaccinationMongoRepository) AddVaccination(ctx context.Context, catID uuid.UUID, vaccination *model.Vaccination) error {
+	result, err := c.db.Collection("cat").UpdateOne(ctx, bson.M{"_id": catID},
+		bson.M{"$push": bson.M{"vaccinations": vaccination}})
+	if err != nil {
+		return fmt.Errorf("add vaccination method error %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("add vaccination method error %w", model.ErrCatNotFound)
+	}
+
+	return nil
+}
+
+// DeleteVaccination removes vaccination record of the cat
+func (c *VaccinationMongoRepository) DeleteVaccination(ctx context.Context, catID, id uuid.UUID) error {
+	result, err := c.db.Collection("cat").UpdateOne(ctx, bson.M{"_id": catID, "vaccinations._id": id},
+		bson.M{"$pull": bson.M{"vaccinations": bson.M{"_id": id}}})
+	if err != nil {
+		return fmt.Errorf("delete vaccination method error %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("delete vaccination method error %w", model.ErrVaccinationNotFound)
+	}
+
+	return nil
+}
+
+// DueVaccinations returns the latest record of every vaccine of the cats in care which expires before the given time.
+// Records replaced by a newer shot of the same vaccine are not due
+func (c *VaccinationMongoRepository) DueVaccinations(ctx context.Context, before time.Time) ([]*model.VaccinationDue, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"deleted_at":              nil,
+			"status":                  bson.M{"$ne": model.CatAdopted},
+			"vaccinations.expires_at": bson.M{"$lt": before},
+		}}},
+		{{Key: "$unwind", Value: "$vaccinations"}},
+		{{Key: "$addFields", Value: bson.M{"vaccine_key": bson.M{"$toLower": "$vaccinations.vaccine"}}}},
+		{{Key: "$sort", Value: bson.D{
+			{Key: "_id", Value: 1}, {Key: "vaccine_key", Value: 1},
+			{Key: "vaccinations.administered_at", Value: -1}, {Key: "vaccinations._id", Value: -1},
+		}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":         bson.M{"cat_id": "$_id", "vaccine": "$vaccine_key"},
+			"cat_name":    bson.M{"$first": "$name"},
+			"shelter_id":  bson.M{"$first": "$shelter_id"},
+			"vaccination": bson.M{"$first": "$vaccinations"},
+		}}},
+		{{Key: "$match", Value: bson.M{"vaccination.expires_at": bson.M{"$lt": before}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "vaccination.expires_at", Value: 1}, {Key: "_id.cat_id", Value: 1}}}},
+	}
+	cursor, err := c.db.Collection("cat").Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("due vaccinations method error %w", err)
+	}
+
+	var rows []struct {
+		Key struct {
+			CatID uuid.UUID `bson:"cat_id"`
+		} `bson:"_id"`
+		CatName     string            `bson:"cat_name"`
+		ShelterID   *uuid.UUID        `bson:"shelter_id"`
+		Vaccination model.Vaccination `bson:"vaccination"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, fmt.Errorf("failed decode due vaccinations from DB %w", err)
+	}
+
+	due := make([]*model.VaccinationDue, 0, len(rows))
+	for _, row := range rows {
+		due = append(due, &model.VaccinationDue{
+			CatID:       row.Key.CatID,
+			CatName:     row.CatName,
+			ShelterID:   row.ShelterID,
+			Vaccination: row.Vaccination,
+		})
+	}
+
+	return due, nil
+}
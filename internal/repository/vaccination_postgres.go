@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/catService/internal/model"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// VaccinationPostgresRepository contains a link to the connection to db
+type VaccinationPostgresRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewVaccinationPostgres create new instance
+func NewVaccinationPostgres(pool *pgxpool.Pool) *VaccinationPostgresRepository {
+	return &VaccinationPostgresRepository{db: pool}
+}
+
+// ListVaccinations returns vaccination records of the cat in the order they were administered
+func (r *VaccinationPostgresRepository) ListVaccinations(ctx context.Context, catID uuid.UUID) ([]*model.Vaccination, error) {
+	rows, err := r.db.Query(ctx, `SELECT id, vaccine, administered_at, lot, vet, expires_at FROM cat_vaccinations
+		WHERE cat_id = $1 ORDER BY administered_at, id`, catID)
+	if err != nil {
+		return nil, fmt.Errorf("list vaccinations method error %w", err)
+	}
+	defer rows.Close()
+
+	vaccinations := make([]*model.Vaccination, 0)
+	for rows.Next() {
+		var vaccination model.Vaccination
+		err := rows.Scan(&vaccination.ID, &vaccination.Vaccine, &vaccination.AdministeredAt,
+			&vaccination.Lot, &vaccination.Vet, &vaccination.ExpiresAt)
+		if err != nil {
+			return nil, fmt.Errorf("list vaccinations method error %w", err)
+		}
+		vaccinations = append(vaccinations, &vaccination)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list vaccinations method error %w", err)
+	}
+
+	return vaccinations, nil
+}
+
+// AddVaccination saves new vaccination record of the cat
+func (r *VaccinationPostgresRepository) AddVaccination(ctx context.Context, catID uuid.UUID, vaccination *model.Vaccination) error {
+	_, err := r.db.Exec(ctx, `INSERT INTO cat_vaccinations(id, cat_id, vaccine, administered_at, lot, vet, expires_at)
+		VALUES ($1,$2,$3,$4,$5,$6,$7)`,
+		vaccination.ID, catID, vaccination.Vaccine, vaccination.AdministeredAt, vaccination.Lot, vaccination.Vet, vaccination.ExpiresAt)
+	if err != nil {
+		return fmt.Errorf("add vaccination method error %w", pgError(err))
+	}
+
+	return nil
+}
+
+// DeleteVaccination removes vaccination record of the cat
+func (r *VaccinationPostgresRepository) DeleteVaccination(ctx context.Context, catID, id uuid.UUID) error {
+	result, err := r.db.Exec(ctx, "DELETE FROM cat_vaccinations WHERE cat_id = $1 AND id = $2", catID, id)
+	if err != nil {
+		return fmt.Errorf("delete vaccination method error %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("delete vaccination method error %w", model.ErrVaccinationNotFound)
+	}
+
+	return nil
+}
+
+// DueVaccinations returns the latest record of every vaccine of the cats in care which expires before the given time.
+// Records replaced by a newer shot of the same vaccine are not due
+func (r *VaccinationPostgresRepository) DueVaccinations(ctx context.Context, before time.Time) ([]*model.VaccinationDue, error) {
+	rows, err := r.db.Query(ctx, `SELECT cat_id, name, shelter_id, id, vaccine, administered_at, lot, vet, expires_at FROM (
+			SELECT DISTINCT ON (v.cat_id, lower(v.vaccine)) v.cat_id, c.name, c.shelter_id,
+				v.id, v.vaccine, v.administered_at, v.lot, v.vet, v.expires_at
+			FROM cat_vaccinations v JOIN cats c ON c.id = v.cat_id
+			WHERE c.deleted_at IS NULL AND c.status <> $2
+			ORDER BY v.cat_id, lower(v.vaccine), v.administered_at DESC, v.id DESC
+		) latest
+		WHERE expires_at < $1 ORDER BY expires_at, cat_id`, before, model.CatAdopted)
+	if err != nil {
+		return nil, fmt.Errorf("due vaccinations method error %w", err)
+	}
+	defer rows.Close()
+
+	due := make([]*model.VaccinationDue, 0)
+	for rows.Next() {
+		var record model.VaccinationDue
+		err := rows.Scan(&record.CatID, &record.CatName, &record.ShelterID, &record.ID, &record.Vaccine,
+			&record.AdministeredAt, &record.Lot, &record.Vet, &record.ExpiresAt)
+		if err != nil {
+			return nil, fmt.Errorf("due vaccinations method error %w", err)
+		}
+		due = append(due, &record)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("due vaccinations method error %w", err)
+	}
+
+	return due, nil
+}
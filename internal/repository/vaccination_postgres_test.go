@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/catService/internal/model"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVaccinations(t *testing.T) {
+	requirePostgres(t)
+	cat := &model.Cat{ID: uuid.New(), Name: "Cat 11", Age: 3, Version: 1}
+	require.NoError(t, repository.Create(context.Background(), cat))
+
+	administered := time.Now().UTC().Truncate(time.Millisecond).AddDate(0, -2, 0)
+	expires := administered.AddDate(1, 0, 0)
+	rabies := &model.Vaccination{ID: uuid.New(), Vaccine: model.VaccineRabies, AdministeredAt: administered.AddDate(0, 1, 0),
+		Lot: "R-17", Vet: "Dr. Smith", ExpiresAt: &expires}
+	fvrcp := &model.Vaccination{ID: uuid.New(), Vaccine: model.VaccineFVRCP, AdministeredAt: administered}
+	require.NoError(t, vaccinations.AddVaccination(context.Background(), cat.ID, rabies))
+	require.NoError(t, vaccinations.AddVaccination(context.Background(), cat.ID, fvrcp))
+
+	records, err := vaccinations.ListVaccinations(context.Background(), cat.ID)
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	require.Equal(t, fvrcp.ID, records[0].ID)
+	require.Nil(t, records[0].ExpiresAt)
+	require.Equal(t, "R-17", records[1].Lot)
+	require.True(t, expires.Equal(*records[1].ExpiresAt))
+
+	require.NoError(t, vaccinations.DeleteVaccination(context.Background(), cat.ID, fvrcp.ID))
+	err = vaccinations.DeleteVaccination(context.Background(), cat.ID, fvrcp.ID)
+	require.ErrorIs(t, err, model.ErrVaccinationNotFound)
+
+	err = vaccinations.AddVaccination(context.Background(), uuid.New(), &model.Vaccination{ID: uuid.New(), Vaccine: model.VaccineFeLV, AdministeredAt: administered})
+	require.ErrorIs(t, err, model.ErrConflict)
+}
+
+func TestDueVaccinations(t *testing.T) {
+	requirePostgres(t)
+	cat := &model.Cat{ID: uuid.New(), Name: "Cat 16", Age: 3, Version: 1}
+	require.NoError(t, repository.Create(context.Background(), cat))
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	expired, soon, later := now.AddDate(0, 0, -3), now.AddDate(0, 0, 5), now.AddDate(1, 0, 0)
+	// the expired rabies shot was repeated, only FVRCP is due
+	oldRabies := &model.Vaccination{ID: uuid.New(), Vaccine: model.VaccineRabies, AdministeredAt: now.AddDate(-1, 0, 0), ExpiresAt: &expired}
+	newRabies := &model.Vaccination{ID: uuid.New(), Vaccine: "Rabies", AdministeredAt: now.AddDate(0, 0, -1), ExpiresAt: &later}
+	fvrcp := &model.Vaccination{ID: uuid.New(), Vaccine: model.VaccineFVRCP, AdministeredAt: now.AddDate(-1, 0, 0), ExpiresAt: &soon}
+	for _, vaccination := range []*model.Vaccination{oldRabies, newRabies, fvrcp} {
+		require.NoError(t, vaccinations.AddVaccination(context.Background(), cat.ID, vaccination))
+	}
+
+	due, err := vaccinations.DueVaccinations(context.Background(), now.AddDate(0, 0, 14))
+	require.NoError(t, err)
+	var found []*model.VaccinationDue
+	for _, record := range due {
+		if record.CatID == cat.ID {
+			found = append(found, record)
+		}
+	}
+	require.Len(t, found, 1)
+	require.Equal(t, fvrcp.ID, found[0].ID)
+	require.Equal(t, "Cat 16", found[0].CatName)
+}
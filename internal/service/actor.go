@@ -0,0 +1,27 @@
+package service
+
+import "context"
+
+// Actors known to the service, the other actors are the names claimed by the clients
+const (
+	// AnonymousActor is the actor of requests which didn't introduce themselves
+	AnonymousActor = "anonymous"
+	// AdminActor is the actor of requests authenticated with the admin token
+	AdminActor = "admin"
+)
+
+type actorKey struct{}
+
+// WithActor returns context of the request made by the actor
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext returns the actor who made the request
+func ActorFromContext(ctx context.Context) string {
+	if actor, ok := ctx.Value(actorKey{}).(string); ok && actor != "" {
+		return actor
+	}
+
+	return AnonymousActor
+}
@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/catService/internal/model"
+	"github.com/catService/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+// matchCandidates bounds the cats scored for one adopter
+const matchCandidates = 5 * MaxListLimit
+
+// AdoptersService contains business logic for adopters and their matches
+//go:generate mockery --dir . --name AdoptersService --output ./service_mock
+type AdoptersService interface {
+	Get(context.Context, uuid.UUID) (*model.Adopter, error)
+	Create(context.Context, *model.Adopter) error
+	Update(context.Context, *model.Adopter) error
+	Delete(context.Context, uuid.UUID) error
+	List(ctx context.Context, limit int, cursor string) (*model.AdopterPage, error)
+	Matches(ctx context.Context, id uuid.UUID, limit int) ([]*model.CatMatch, error)
+}
+
+// AdopterService contains links to the adopter storage and the cat service
+type AdopterService struct {
+	rps  repository.AdopterRepository
+	cats SheltersCatService
+}
+
+// NewAdopterService create new instance
+func NewAdopterService(rps repository.AdopterRepository, cats SheltersCatService) *AdopterService {
+	return &AdopterService{
+		rps:  rps,
+		cats: cats,
+	}
+}
+
+// Get returns adopter
+func (s *AdopterService) Get(ctx context.Context, id uuid.UUID) (*model.Adopter, error) {
+	adopter, err := s.rps.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("get adopter %s: %w", id, err)
+	}
+
+	return adopter, nil
+}
+
+// Create validates and saves new adopter
+func (s *AdopterService) Create(ctx context.Context, adopter *model.Adopter) error {
+	if err := prepareAdopter(adopter); err != nil {
+		return err
+	}
+
+	adopter.ID = uuid.New()
+	if err := s.rps.Create(ctx, adopter); err != nil {
+		return fmt.Errorf("create adopter: %w", err)
+	}
+
+	return nil
+}
+
+// Update validates and saves adopter states
+func (s *AdopterService) Update(ctx context.Context, adopter *model.Adopter) error {
+	if err := prepareAdopter(adopter); err != nil {
+		return err
+	}
+	if err := s.rps.Update(ctx, adopter); err != nil {
+		return fmt.Errorf("update adopter %s: %w", adopter.ID, err)
+	}
+
+	return nil
+}
+
+// Delete removes adopter
+func (s *AdopterService) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := s.rps.Delete(ctx, id); err != nil {
+		return fmt.Errorf("delete adopter %s: %w", id, err)
+	}
+
+	return nil
+}
+
+// List returns a page of adopters
+func (s *AdopterService) List(ctx context.Context, limit int, cursor string) (*model.AdopterPage, error) {
+	switch {
+	case limit == 0:
+		limit = DefaultListLimit
+	case limit < 0 || limit > MaxListLimit:
+		return nil, fmt.Errorf("%w: limit must be between 1 and %d", model.ErrInvalid, MaxListLimit)
+	}
+	after, err := decodeIDCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	// one extra adopter shows whether there is a next page
+	adopters, err := s.rps.List(ctx, after, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("list adopters: %w", err)
+	}
+
+	page := &model.AdopterPage{Adopters: adopters}
+	if len(adopters) > limit {
+		page.Adopters = adopters[:limit]
+		page.NextCursor = encodeIDCursor(page.Adopters[limit-1].ID)
+	}
+
+	return page, nil
+}
+
+// Matches ranks the cats which can be adopted now against the preferences of the adopter
+// and returns the best ones. The storage leaves out the cats the preferences exclude,
+// at most matchCandidates of the available and returned cats are scored, vaccinated ones first
+func (s *AdopterService) Matches(ctx context.Context, id uuid.UUID, limit int) ([]*model.CatMatch, error) {
+	switch {
+	case limit == 0:
+		limit = DefaultListLimit
+	case limit < 0 || limit > MaxListLimit:
+		return nil, fmt.Errorf("%w: limit must be between 1 and %d", model.ErrInvalid, MaxListLimit)
+	}
+	adopter, err := s.rps.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("adopter %s matches: %w", id, err)
+	}
+
+	matches := make([]*model.CatMatch, 0)
+	candidates := 0
+	for _, status := range []string{model.CatAvailable, model.CatReturned} {
+		query := &model.CatQuery{CatFilter: adopter.CandidateFilter(), SortBy: model.CatSortVaccinated, Desc: true, Limit: MaxListLimit}
+		query.Status = status
+		for candidates < matchCandidates {
+			page, err := s.cats.List(ctx, query)
+			if err != nil {
+				return nil, fmt.Errorf("adopter %s matches: %w", id, err)
+			}
+			candidates += len(page.Cats)
+			for _, cat := range page.Cats {
+				if match := adopter.Match(cat); match != nil {
+					matches = append(matches, match)
+				}
+			}
+			if page.NextCursor == "" {
+				break
+			}
+			query.Cursor = page.NextCursor
+		}
+	}
+
+	model.SortMatches(matches)
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+
+	return matches, nil
+}
+
+// prepareAdopter normalizes and validates the adopter
+func prepareAdopter(adopter *model.Adopter) error {
+	adopter.Preferences.Sex = strings.ToLower(strings.TrimSpace(adopter.Preferences.Sex))
+	adopter.Household.HomeType = strings.ToLower(strings.TrimSpace(adopter.Household.HomeType))
+
+	if strings.TrimSpace(adopter.Name) == "" {
+		return fmt.Errorf("%w: name is required", model.ErrInvalid)
+	}
+	if strings.TrimSpace(adopter.Email) == "" && strings.TrimSpace(adopter.Phone) == "" {
+		return fmt.Errorf("%w: email or phone is required", model.ErrInvalid)
+	}
+	if adopter.Household.Adults < 0 || adopter.Household.Children < 0 {
+		return fmt.Errorf("%w: household size must not be negative", model.ErrInvalid)
+	}
+
+	preferences := &adopter.Preferences
+	if preferences.MinAge != nil && *preferences.MinAge < 0 || preferences.MaxAge != nil && *preferences.MaxAge < 0 {
+		return fmt.Errorf("%w: preferred age must not be negative", model.ErrInvalid)
+	}
+	if preferences.MinAge != nil && preferences.MaxAge != nil && *preferences.MinAge > *preferences.MaxAge {
+		return fmt.Errorf("%w: preferred min age is greater than max age", model.ErrInvalid)
+	}
+	switch preferences.Sex {
+	case "", model.CatMale, model.CatFemale:
+	default:
+		return fmt.Errorf("%w: preferred sex must be male or female", model.ErrInvalid)
+	}
+
+	return nil
+}
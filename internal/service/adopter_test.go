@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/catService/internal/model"
+	mocks "github.com/catService/internal/repository/repository_mock"
+	servicemock "github.com/catService/internal/service/service_mock"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdopterService_Matches(t *testing.T) {
+	minAge, maxAge := 1, 3
+	yes, no := true, false
+	adopter := &model.Adopter{ID: uuid.New(), Name: "Kate", Email: "kate@example.com",
+		Household:   model.Household{Adults: 2, Children: 1},
+		Preferences: model.AdopterPreferences{MinAge: &minAge, MaxAge: &maxAge, Sex: model.CatFemale}}
+
+	longAgo := time.Now().AddDate(-1, 0, 0)
+	best := &model.Cat{ID: uuid.New(), Name: "Best", Age: 2, Vaccinated: true,
+		CatProfile: model.CatProfile{Sex: model.CatFemale, GoodWithKids: &yes}}
+	waited := &model.Cat{ID: uuid.New(), Name: "Waited", Age: 2, CatProfile: model.CatProfile{Sex: model.CatFemale, IntakeDate: &longAgo}}
+	fresh := &model.Cat{ID: uuid.New(), Name: "Fresh", Age: 3, CatProfile: model.CatProfile{Sex: model.CatFemale}}
+	near := &model.Cat{ID: uuid.New(), Name: "Near", Age: 4, CatProfile: model.CatProfile{Sex: model.CatFemale}}
+	old := &model.Cat{ID: uuid.New(), Name: "Old", Age: 12, CatProfile: model.CatProfile{Sex: model.CatFemale}}
+	male := &model.Cat{ID: uuid.New(), Name: "Male", Age: 2, CatProfile: model.CatProfile{Sex: model.CatMale}}
+	badWithKids := &model.Cat{ID: uuid.New(), Name: "Bad", Age: 2, CatProfile: model.CatProfile{Sex: model.CatFemale, GoodWithKids: &no}}
+
+	candidates := func(status, cursor string) interface{} {
+		return mock.MatchedBy(func(query *model.CatQuery) bool {
+			return query.Status == status && query.Cursor == cursor && query.Sex == model.CatFemale && query.NotBadWithKids &&
+				*query.MinAge == 0 && *query.MaxAge == 4 && query.Vaccinated == nil && query.SortBy == model.CatSortVaccinated && query.Desc
+		})
+	}
+	rps := &mocks.AdopterRepository{}
+	rps.On("Get", context.Background(), adopter.ID).Return(adopter, nil)
+	cats := &servicemock.SheltersCatService{}
+	// the storage filters the candidates, the excluded cats are checked again
+	cats.On("List", context.Background(), candidates(model.CatAvailable, "")).
+		Return(&model.CatPage{Cats: []*model.Cat{old, fresh, badWithKids, male}, NextCursor: "next"}, nil)
+	cats.On("List", context.Background(), candidates(model.CatAvailable, "next")).
+		Return(&model.CatPage{Cats: []*model.Cat{waited, near}}, nil)
+	cats.On("List", context.Background(), candidates(model.CatReturned, "")).
+		Return(&model.CatPage{Cats: []*model.Cat{best}}, nil)
+
+	srv := NewAdopterService(rps, cats)
+	matches, err := srv.Matches(context.Background(), adopter.ID, 0)
+	require.NoError(t, err)
+	require.Len(t, matches, 4)
+	require.Equal(t, best.ID, matches[0].Cat.ID)
+	require.Equal(t, []string{model.MatchAgeInRange, model.MatchGoodWithKids, model.MatchSex, model.MatchVaccinated}, matches[0].Reasons)
+	require.Equal(t, waited.ID, matches[1].Cat.ID)
+	require.Equal(t, fresh.ID, matches[2].Cat.ID)
+	require.Equal(t, near.ID, matches[3].Cat.ID)
+	require.Equal(t, []string{model.MatchAgeNearRange, model.MatchSex}, matches[3].Reasons)
+
+	matches, err = srv.Matches(context.Background(), adopter.ID, 1)
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+}
+
+func TestAdopterService_MatchesVaccinatedOnly(t *testing.T) {
+	adopter := &model.Adopter{ID: uuid.New(), Name: "Kate", Phone: "+375291111111",
+		Preferences: model.AdopterPreferences{VaccinatedOnly: true}}
+	vaccinated := &model.Cat{ID: uuid.New(), Name: "Vaccinated", Age: 2, Vaccinated: true}
+
+	rps := &mocks.AdopterRepository{}
+	rps.On("Get", context.Background(), adopter.ID).Return(adopter, nil)
+	cats := &servicemock.SheltersCatService{}
+	cats.On("List", context.Background(), mock.MatchedBy(func(query *model.CatQuery) bool {
+		return query.Status == model.CatAvailable && query.Vaccinated != nil && *query.Vaccinated
+	})).Return(&model.CatPage{Cats: []*model.Cat{vaccinated, {ID: uuid.New(), Age: 1}}}, nil)
+	cats.On("List", context.Background(), mock.MatchedBy(func(query *model.CatQuery) bool {
+		return query.Status == model.CatReturned && query.Vaccinated != nil && *query.Vaccinated
+	})).Return(&model.CatPage{Cats: []*model.Cat{}}, nil)
+
+	matches, err := NewAdopterService(rps, cats).Matches(context.Background(), adopter.ID, 0)
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+	require.Equal(t, vaccinated.ID, matches[0].Cat.ID)
+	require.Empty(t, matches[0].Reasons)
+}
+
+func TestAdopterService_CreateInvalid(t *testing.T) {
+	minAge, maxAge := 5, 2
+	srv := NewAdopterService(&mocks.AdopterRepository{}, nil)
+
+	tests := []*model.Adopter{
+		{Email: "kate@example.com"},
+		{Name: "Kate"},
+		{Name: "Kate", Email: "kate@example.com", Household: model.Household{Children: -1}},
+		{Name: "Kate", Email: "kate@example.com", Preferences: model.AdopterPreferences{MinAge: &minAge, MaxAge: &maxAge}},
+		{Name: "Kate", Email: "kate@example.com", Preferences: model.AdopterPreferences{Sex: "any"}},
+	}
+	for _, adopter := range tests {
+		require.ErrorIs(t, srv.Create(context.Background(), adopter), model.ErrInvalid)
+	}
+}
+
+func TestAdopterService_MatchesBounded(t *testing.T) {
+	adopter := &model.Adopter{ID: uuid.New(), Name: "Kate", Email: "kate@example.com"}
+	page := &model.CatPage{Cats: make([]*model.Cat, 0, MaxListLimit), NextCursor: "next"}
+	for i := 0; i < MaxListLimit; i++ {
+		page.Cats = append(page.Cats, &model.Cat{ID: uuid.New(), Age: 1})
+	}
+
+	rps := &mocks.AdopterRepository{}
+	rps.On("Get", context.Background(), adopter.ID).Return(adopter, nil)
+	cats := &servicemock.SheltersCatService{}
+	cats.On("List", context.Background(), mock.Anything).Return(page, nil)
+
+	matches, err := NewAdopterService(rps, cats).Matches(context.Background(), adopter.ID, 1)
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+	cats.AssertNumberOfCalls(t, "List", matchCandidates/MaxListLimit)
+}
@@ -0,0 +1,685 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/catService/internal/model"
+	"github.com/catService/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+// verifyExamples limits the IDs of the differences kept in the verify result
+const verifyExamples = 20
+
+// Storage is the set of repositories of one storage backend
+type Storage struct {
+	Cats         repository.SheltersCatRepository
+	Events       repository.CatEventRepository
+	Shelters     repository.ShelterRepository
+	Vaccinations repository.VaccinationRepository
+	Photos       repository.PhotoRepository
+	Intakes      repository.IntakeRepository
+	Fosters      repository.FosterRepository
+	Adopters     repository.AdopterRepository
+	Outbox       repository.OutboxRepository
+}
+
+// CopyService copies the data from one storage to another.
+// Records are read in ID order by batches and the checkpoint is saved after every batch,
+// so the interrupted copy resumes after the last saved batch. Records which already exist
+// in the target are kept, so the batch interrupted halfway is copied again without conflicts.
+// Photo images stay in the blob store, only their descriptions are copied
+type CopyService struct {
+	from        *Storage
+	to          *Storage
+	fromName    string
+	toName      string
+	checkpoints repository.CheckpointRepository
+	batch       int
+}
+
+// NewCopyService create new instance, the names of the storages are kept in the checkpoint
+func NewCopyService(fromName string, from *Storage, toName string, to *Storage,
+	checkpoints repository.CheckpointRepository, batch int) *CopyService {
+	return &CopyService{
+		from:        from,
+		to:          to,
+		fromName:    fromName,
+		toName:      toName,
+		checkpoints: checkpoints,
+		batch:       batch,
+	}
+}
+
+// catRecords are the records kept with the cat
+type catRecords struct {
+	Vaccinations []*model.Vaccination
+	Photos       []*model.Photo
+	Intakes      []*model.Intake
+	Placements   []*model.Placement
+	Events       []*model.CatEvent
+}
+
+// count returns the number of the records
+func (r *catRecords) count() int64 {
+	return int64(len(r.Vaccinations) + len(r.Photos) + len(r.Intakes) + len(r.Placements) + len(r.Events))
+}
+
+// Copy copies shelters, fosters, adopters and cats with their records starting from the saved checkpoint.
+// The dry run reads the source and counts what would be copied without writing anything
+func (s *CopyService) Copy(ctx context.Context, dryRun bool) (*model.CopyReport, error) {
+	checkpoint, err := s.checkpoints.Load(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("copy: %w", err)
+	}
+	if checkpoint == nil {
+		checkpoint = &model.CopyCheckpoint{From: s.fromName, To: s.toName, Stage: model.CopyShelters}
+	}
+	if checkpoint.From != s.fromName || checkpoint.To != s.toName {
+		return nil, fmt.Errorf("copy: %w: checkpoint belongs to the copy from %s to %s",
+			model.ErrConflict, checkpoint.From, checkpoint.To)
+	}
+
+	report := &model.CopyReport{}
+	if checkpoint.Stage == model.CopyDone {
+		return report, nil
+	}
+	stages := []struct {
+		name string
+		copy func(context.Context, *model.CopyCheckpoint, bool) (int64, error)
+		sum  *int64
+	}{
+		{model.CopyShelters, s.copyShelters, &report.Shelters},
+		{model.CopyFosters, s.copyFosters, &report.Fosters},
+		{model.CopyAdopters, s.copyAdopters, &report.Adopters},
+		{model.CopyCats, func(ctx context.Context, checkpoint *model.CopyCheckpoint, dryRun bool) (int64, error) {
+			return s.copyCats(ctx, checkpoint, dryRun, report)
+		}, &report.Cats},
+	}
+
+	started := false
+	for i, stage := range stages {
+		if !started && stage.name != checkpoint.Stage {
+			continue
+		}
+		started = true
+
+		copied, err := stage.copy(ctx, checkpoint, dryRun)
+		*stage.sum += copied
+		if err != nil {
+			return report, fmt.Errorf("copy %s: %w", stage.name, err)
+		}
+
+		checkpoint.Stage, checkpoint.After = model.CopyDone, nil
+		if i+1 < len(stages) {
+			checkpoint.Stage = stages[i+1].name
+		}
+		if err := s.saveCheckpoint(ctx, checkpoint, dryRun); err != nil {
+			return report, fmt.Errorf("copy %s: %w", stage.name, err)
+		}
+	}
+	if !started {
+		return nil, fmt.Errorf("copy: %w: unknown checkpoint stage %s", model.ErrInvalid, checkpoint.Stage)
+	}
+
+	return report, nil
+}
+
+// saveCheckpoint saves the checkpoint unless it's the dry run
+func (s *CopyService) saveCheckpoint(ctx context.Context, checkpoint *model.CopyCheckpoint, dryRun bool) error {
+	if dryRun {
+		return nil
+	}
+
+	return s.checkpoints.Save(ctx, checkpoint)
+}
+
+// copyPages copies the pages of records starting after the checkpoint and saves the checkpoint after every page.
+// The page function reads the records after the ID and returns their IDs in order with the function writing them
+func (s *CopyService) copyPages(ctx context.Context, checkpoint *model.CopyCheckpoint, dryRun bool,
+	page func(after *uuid.UUID) ([]uuid.UUID, func() error, error)) (int64, error) {
+	var copied int64
+	for {
+		ids, write, err := page(checkpoint.After)
+		if err != nil {
+			return copied, err
+		}
+		if len(ids) == 0 {
+			return copied, nil
+		}
+		if !dryRun {
+			if err := write(); err != nil {
+				return copied, err
+			}
+		}
+
+		copied += int64(len(ids))
+		last := ids[len(ids)-1]
+		checkpoint.After = &last
+		if err := s.saveCheckpoint(ctx, checkpoint, dryRun); err != nil {
+			return copied, err
+		}
+		if len(ids) < s.batch {
+			return copied, nil
+		}
+	}
+}
+
+// copyShelters copies the shelters, existing ones are kept
+func (s *CopyService) copyShelters(ctx context.Context, checkpoint *model.CopyCheckpoint, dryRun bool) (int64, error) {
+	return s.copyPages(ctx, checkpoint, dryRun, func(after *uuid.UUID) ([]uuid.UUID, func() error, error) {
+		shelters, err := s.from.Shelters.List(ctx, after, s.batch)
+		ids := make([]uuid.UUID, 0, len(shelters))
+		for _, shelter := range shelters {
+			ids = append(ids, shelter.ID)
+		}
+
+		return ids, func() error {
+			for _, shelter := range shelters {
+				if err := s.to.Shelters.Create(ctx, shelter); err != nil && !errors.Is(err, model.ErrConflict) {
+					return err
+				}
+			}
+			return nil
+		}, err
+	})
+}
+
+// copyFosters copies the fosters, existing ones are kept
+func (s *CopyService) copyFosters(ctx context.Context, checkpoint *model.CopyCheckpoint, dryRun bool) (int64, error) {
+	return s.copyPages(ctx, checkpoint, dryRun, func(after *uuid.UUID) ([]uuid.UUID, func() error, error) {
+		fosters, err := s.from.Fosters.List(ctx, after, s.batch)
+		ids := make([]uuid.UUID, 0, len(fosters))
+		for _, foster := range fosters {
+			ids = append(ids, foster.ID)
+		}
+
+		return ids, func() error {
+			for _, foster := range fosters {
+				if err := s.to.Fosters.Create(ctx, foster); err != nil && !errors.Is(err, model.ErrConflict) {
+					return err
+				}
+			}
+			return nil
+		}, err
+	})
+}
+
+// copyAdopters copies the adopters, existing ones are kept
+func (s *CopyService) copyAdopters(ctx context.Context, checkpoint *model.CopyCheckpoint, dryRun bool) (int64, error) {
+	return s.copyPages(ctx, checkpoint, dryRun, func(after *uuid.UUID) ([]uuid.UUID, func() error, error) {
+		adopters, err := s.from.Adopters.List(ctx, after, s.batch)
+		ids := make([]uuid.UUID, 0, len(adopters))
+		for _, adopter := range adopters {
+			ids = append(ids, adopter.ID)
+		}
+
+		return ids, func() error {
+			for _, adopter := range adopters {
+				if err := s.to.Adopters.Create(ctx, adopter); err != nil && !errors.Is(err, model.ErrConflict) {
+					return err
+				}
+			}
+			return nil
+		}, err
+	})
+}
+
+// copyCats copies the cats, deleted ones included, with their records.
+// The batch is created with one request, when some of its cats exist they are created one by one
+// and the missing records are added to the existing cats
+func (s *CopyService) copyCats(ctx context.Context, checkpoint *model.CopyCheckpoint, dryRun bool,
+	report *model.CopyReport) (int64, error) {
+	query := &model.CatQuery{CatFilter: model.CatFilter{Deleted: model.DeletedInclude}, SortBy: model.CatSortID, Limit: s.batch}
+	return s.copyPages(ctx, checkpoint, dryRun, func(after *uuid.UUID) ([]uuid.UUID, func() error, error) {
+		var afterCat *model.Cat
+		if after != nil {
+			afterCat = &model.Cat{ID: *after}
+		}
+		cats, err := s.from.Cats.List(ctx, query, afterCat)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		ids := make([]uuid.UUID, 0, len(cats))
+		records := make([]*catRecords, 0, len(cats))
+		for _, cat := range cats {
+			ids = append(ids, cat.ID)
+			catRecords, err := readCatRecords(ctx, s.from, cat.ID)
+			if err != nil {
+				return nil, nil, err
+			}
+			records = append(records, catRecords)
+			report.Records += catRecords.count()
+		}
+
+		return ids, func() error {
+			return s.writeCats(ctx, cats, records)
+		}, nil
+	})
+}
+
+// writeCats saves the batch of cats with their records into the target
+func (s *CopyService) writeCats(ctx context.Context, cats []*model.Cat, records []*catRecords) error {
+	err := s.to.Cats.CreateMany(ctx, cats)
+	if err == nil {
+		for i, cat := range cats {
+			if err := s.addRecords(ctx, cat.ID, records[i], &catRecords{}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if !errors.Is(err, model.ErrConflict) {
+		return err
+	}
+
+	for i, cat := range cats {
+		existing := &catRecords{}
+		err := s.to.Cats.Create(ctx, cat)
+		if errors.Is(err, model.ErrConflict) {
+			existing, err = readCatRecords(ctx, s.to, cat.ID)
+		}
+		if err != nil {
+			return fmt.Errorf("cat %s: %w", cat.ID, err)
+		}
+		if err := s.addRecords(ctx, cat.ID, records[i], existing); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// addRecords adds the records of the cat which the target doesn't have yet.
+// Intakes and placements are added open and closed then, so the cat has one open record at most
+func (s *CopyService) addRecords(ctx context.Context, catID uuid.UUID, records, existing *catRecords) error {
+	exists := make(map[uuid.UUID]bool)
+	for _, vaccination := range existing.Vaccinations {
+		exists[vaccination.ID] = true
+	}
+	for _, photo := range existing.Photos {
+		exists[photo.ID] = true
+	}
+	for _, intake := range existing.Intakes {
+		exists[intake.ID] = true
+	}
+	for _, placement := range existing.Placements {
+		exists[placement.ID] = true
+	}
+	for _, event := range existing.Events {
+		exists[event.ID] = true
+	}
+
+	for _, vaccination := range records.Vaccinations {
+		if exists[vaccination.ID] {
+			continue
+		}
+		if err := s.to.Vaccinations.AddVaccination(ctx, catID, vaccination); err != nil {
+			return fmt.Errorf("cat %s vaccination %s: %w", catID, vaccination.ID, err)
+		}
+	}
+	for _, photo := range records.Photos {
+		if exists[photo.ID] {
+			continue
+		}
+		// the copied photos are the photos of the source cat, so their number fits
+		if err := s.to.Photos.AddPhoto(ctx, catID, photo, len(records.Photos)); err != nil {
+			return fmt.Errorf("cat %s photo %s: %w", catID, photo.ID, err)
+		}
+	}
+	for _, intake := range records.Intakes {
+		if exists[intake.ID] {
+			continue
+		}
+		open := *intake
+		open.Outcome = nil
+		if err := s.to.Intakes.AddIntake(ctx, catID, &open); err != nil {
+			return fmt.Errorf("cat %s intake %s: %w", catID, intake.ID, err)
+		}
+		if intake.Outcome != nil {
+			if err := s.to.Intakes.CloseIntake(ctx, catID, intake.ID, intake.Outcome); err != nil {
+				return fmt.Errorf("cat %s intake %s: %w", catID, intake.ID, err)
+			}
+		}
+	}
+	for _, placement := range records.Placements {
+		if exists[placement.ID] {
+			continue
+		}
+		active := *placement
+		active.EndedAt = nil
+		if err := s.to.Fosters.AddPlacement(ctx, &active); err != nil {
+			return fmt.Errorf("cat %s placement %s: %w", catID, placement.ID, err)
+		}
+		if placement.EndedAt != nil {
+			err := s.to.Fosters.EndPlacement(ctx, catID, placement.ID, *placement.EndedAt, placement.Notes)
+			if err != nil {
+				return fmt.Errorf("cat %s placement %s: %w", catID, placement.ID, err)
+			}
+		}
+	}
+	for _, event := range records.Events {
+		if exists[event.ID] {
+			continue
+		}
+		if err := s.to.Events.AddEvent(ctx, event); err != nil {
+			return fmt.Errorf("cat %s event %s: %w", catID, event.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// readCatRecords reads all records of the cat from the storage
+func readCatRecords(ctx context.Context, storage *Storage, catID uuid.UUID) (*catRecords, error) {
+	var records catRecords
+	var err error
+	if records.Vaccinations, err = storage.Vaccinations.ListVaccinations(ctx, catID); err != nil {
+		return nil, err
+	}
+	if records.Photos, err = storage.Photos.ListPhotos(ctx, catID); err != nil {
+		return nil, err
+	}
+	if records.Intakes, err = storage.Intakes.ListIntakes(ctx, catID); err != nil {
+		return nil, err
+	}
+	if records.Placements, err = storage.Fosters.ListPlacements(ctx, catID); err != nil {
+		return nil, err
+	}
+
+	var after *model.CatEvent
+	for {
+		events, err := storage.Events.ListEvents(ctx, catID, after, MaxListLimit)
+		if err != nil {
+			return nil, err
+		}
+		records.Events = append(records.Events, events...)
+		if len(events) < MaxListLimit {
+			return &records, nil
+		}
+		after = events[len(events)-1]
+	}
+}
+
+// recordChecksum is the checksum of one record in the verify
+type recordChecksum struct {
+	id       uuid.UUID
+	checksum string
+}
+
+// checksumPager returns the checksums of the page of records in ID order after the given ID
+type checksumPager func(ctx context.Context, after *uuid.UUID) ([]*recordChecksum, error)
+
+// Verify compares the records of the storages kind by kind.
+// Cats are compared with their records, deleted cats included
+func (s *CopyService) Verify(ctx context.Context) ([]*model.VerifyResult, error) {
+	kinds := []struct {
+		name     string
+		pagerFor func(*Storage) checksumPager
+	}{
+		{model.CopyShelters, s.shelterChecksums},
+		{model.CopyFosters, s.fosterChecksums},
+		{model.CopyAdopters, s.adopterChecksums},
+		{model.CopyCats, s.catChecksums},
+	}
+
+	results := make([]*model.VerifyResult, 0, len(kinds))
+	for _, kind := range kinds {
+		result, err := verifyKind(ctx, kind.name, kind.pagerFor(s.from), kind.pagerFor(s.to))
+		if err != nil {
+			return results, fmt.Errorf("verify %s: %w", kind.name, err)
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// shelterChecksums pages the checksums of the shelters
+func (s *CopyService) shelterChecksums(storage *Storage) checksumPager {
+	return func(ctx context.Context, after *uuid.UUID) ([]*recordChecksum, error) {
+		shelters, err := storage.Shelters.List(ctx, after, s.batch)
+		if err != nil {
+			return nil, err
+		}
+		checksums := make([]*recordChecksum, 0, len(shelters))
+		for _, shelter := range shelters {
+			checksum, err := checksumOf(shelter)
+			if err != nil {
+				return nil, err
+			}
+			checksums = append(checksums, &recordChecksum{id: shelter.ID, checksum: checksum})
+		}
+
+		return checksums, nil
+	}
+}
+
+// fosterChecksums pages the checksums of the fosters
+func (s *CopyService) fosterChecksums(storage *Storage) checksumPager {
+	return func(ctx context.Context, after *uuid.UUID) ([]*recordChecksum, error) {
+		fosters, err := storage.Fosters.List(ctx, after, s.batch)
+		if err != nil {
+			return nil, err
+		}
+		checksums := make([]*recordChecksum, 0, len(fosters))
+		for _, foster := range fosters {
+			checksum, err := checksumOf(foster)
+			if err != nil {
+				return nil, err
+			}
+			checksums = append(checksums, &recordChecksum{id: foster.ID, checksum: checksum})
+		}
+
+		return checksums, nil
+	}
+}
+
+// adopterChecksums pages the checksums of the adopters
+func (s *CopyService) adopterChecksums(storage *Storage) checksumPager {
+	return func(ctx context.Context, after *uuid.UUID) ([]*recordChecksum, error) {
+		adopters, err := storage.Adopters.List(ctx, after, s.batch)
+		if err != nil {
+			return nil, err
+		}
+		checksums := make([]*recordChecksum, 0, len(adopters))
+		for _, adopter := range adopters {
+			checksum, err := checksumOf(adopter)
+			if err != nil {
+				return nil, err
+			}
+			checksums = append(checksums, &recordChecksum{id: adopter.ID, checksum: checksum})
+		}
+
+		return checksums, nil
+	}
+}
+
+// catChecksums pages the checksums of the cats with their records, the records are ordered by ID
+// because the storages order records of the same time differently
+func (s *CopyService) catChecksums(storage *Storage) checksumPager {
+	query := &model.CatQuery{CatFilter: model.CatFilter{Deleted: model.DeletedInclude}, SortBy: model.CatSortID, Limit: s.batch}
+	return func(ctx context.Context, after *uuid.UUID) ([]*recordChecksum, error) {
+		var afterCat *model.Cat
+		if after != nil {
+			afterCat = &model.Cat{ID: *after}
+		}
+		cats, err := storage.Cats.List(ctx, query, afterCat)
+		if err != nil {
+			return nil, err
+		}
+
+		checksums := make([]*recordChecksum, 0, len(cats))
+		for _, cat := range cats {
+			records, err := readCatRecords(ctx, storage, cat.ID)
+			if err != nil {
+				return nil, err
+			}
+			sort.Slice(records.Vaccinations, func(i, j int) bool {
+				return lessID(records.Vaccinations[i].ID, records.Vaccinations[j].ID)
+			})
+			sort.Slice(records.Photos, func(i, j int) bool {
+				return lessID(records.Photos[i].ID, records.Photos[j].ID)
+			})
+			sort.Slice(records.Intakes, func(i, j int) bool {
+				return lessID(records.Intakes[i].ID, records.Intakes[j].ID)
+			})
+			sort.Slice(records.Placements, func(i, j int) bool {
+				return lessID(records.Placements[i].ID, records.Placements[j].ID)
+			})
+			sort.Slice(records.Events, func(i, j int) bool {
+				return lessID(records.Events[i].ID, records.Events[j].ID)
+			})
+
+			checksum, err := checksumOf(struct {
+				Cat     *model.Cat
+				Records *catRecords
+			}{cat, records})
+			if err != nil {
+				return nil, err
+			}
+			checksums = append(checksums, &recordChecksum{id: cat.ID, checksum: checksum})
+		}
+
+		return checksums, nil
+	}
+}
+
+// verifyKind merges the checksums of both storages in ID order and counts the differences
+func verifyKind(ctx context.Context, kind string, source, target checksumPager) (*model.VerifyResult, error) {
+	result := &model.VerifyResult{Kind: kind}
+	sourceCursor := &checksumCursor{pager: source}
+	targetCursor := &checksumCursor{pager: target}
+	for {
+		sourceRecord, err := sourceCursor.peek(ctx)
+		if err != nil {
+			return nil, err
+		}
+		targetRecord, err := targetCursor.peek(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case sourceRecord == nil && targetRecord == nil:
+			return result, nil
+		case targetRecord == nil || sourceRecord != nil && lessID(sourceRecord.id, targetRecord.id):
+			result.Source++
+			result.MissingCount++
+			result.Missing = appendExample(result.Missing, sourceRecord.id)
+			sourceCursor.next()
+		case sourceRecord == nil || lessID(targetRecord.id, sourceRecord.id):
+			result.Target++
+			result.ExtraCount++
+			result.Extra = appendExample(result.Extra, targetRecord.id)
+			targetCursor.next()
+		default:
+			result.Source++
+			result.Target++
+			if sourceRecord.checksum != targetRecord.checksum {
+				result.ChangedCount++
+				result.Changed = appendExample(result.Changed, sourceRecord.id)
+			}
+			sourceCursor.next()
+			targetCursor.next()
+		}
+	}
+}
+
+// checksumCursor reads the checksums page by page
+type checksumCursor struct {
+	pager checksumPager
+	page  []*recordChecksum
+	after *uuid.UUID
+	done  bool
+}
+
+// peek returns the current checksum, nil at the end
+func (c *checksumCursor) peek(ctx context.Context) (*recordChecksum, error) {
+	if len(c.page) == 0 && !c.done {
+		page, err := c.pager(ctx, c.after)
+		if err != nil {
+			return nil, err
+		}
+		if len(page) == 0 {
+			c.done = true
+			return nil, nil
+		}
+		c.page = page
+		c.after = &page[len(page)-1].id
+	}
+	if len(c.page) == 0 {
+		return nil, nil
+	}
+
+	return c.page[0], nil
+}
+
+// next moves to the next checksum
+func (c *checksumCursor) next() {
+	c.page = c.page[1:]
+}
+
+// appendExample keeps the first IDs of the differences
+func appendExample(ids []uuid.UUID, id uuid.UUID) []uuid.UUID {
+	if len(ids) >= verifyExamples {
+		return ids
+	}
+
+	return append(ids, id)
+}
+
+// lessID orders IDs by their bytes like the storages do
+func lessID(a, b uuid.UUID) bool {
+	return bytes.Compare(a[:], b[:]) < 0
+}
+
+// checksumOf is the SHA-256 of the JSON of the record with the times in UTC milliseconds,
+// the precision mongo keeps, so the copies of the record have equal checksums in all storages
+func checksumOf(record interface{}) (string, error) {
+	content, err := json.Marshal(record)
+	if err != nil {
+		return "", err
+	}
+	var value interface{}
+	if err := json.Unmarshal(content, &value); err != nil {
+		return "", err
+	}
+	content, err = json.Marshal(normalizeTimes(value))
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(content)
+
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// normalizeTimes replaces the times in the decoded JSON with UTC times truncated to milliseconds
+func normalizeTimes(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for key, item := range value {
+			value[key] = normalizeTimes(item)
+		}
+	case []interface{}:
+		for i, item := range value {
+			value[i] = normalizeTimes(item)
+		}
+	case string:
+		if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+			return t.UTC().Truncate(time.Millisecond).Format(time.RFC3339Nano)
+		}
+	}
+
+	return value
+}
@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/catService/internal/model"
+	"github.com/catService/internal/repository"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func memoryStorage() *Storage {
+	store := repository.NewMemoryStore()
+	return &Storage{
+		Cats:         repository.NewMemoryRepository(store),
+		Events:       repository.NewEventMemoryRepository(store),
+		Shelters:     repository.NewShelterMemoryRepository(store),
+		Vaccinations: repository.NewVaccinationMemoryRepository(store),
+		Photos:       repository.NewPhotoMemoryRepository(store),
+		Intakes:      repository.NewIntakeMemoryRepository(store),
+		Fosters:      repository.NewFosterMemoryRepository(store),
+		Adopters:     repository.NewAdopterMemoryRepository(store),
+	}
+}
+
+func sqliteStorage(t *testing.T) *Storage {
+	db, err := repository.OpenSQLite(context.Background(), filepath.Join(t.TempDir(), "cats.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		db.Close()
+	})
+
+	return &Storage{
+		Cats:         repository.NewSQLiteRepository(db),
+		Events:       repository.NewEventSQLiteRepository(db),
+		Shelters:     repository.NewShelterSQLiteRepository(db),
+		Vaccinations: repository.NewVaccinationSQLiteRepository(db),
+		Photos:       repository.NewPhotoSQLiteRepository(db),
+		Intakes:      repository.NewIntakeSQLiteRepository(db),
+		Fosters:      repository.NewFosterSQLiteRepository(db),
+		Adopters:     repository.NewAdopterSQLiteRepository(db),
+	}
+}
+
+func fillStorage(t *testing.T, storage *Storage) []*model.Cat {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	shelter := &model.Shelter{ID: uuid.New(), Name: "Shelter 1", Capacity: 10}
+	require.NoError(t, storage.Shelters.Create(ctx, shelter))
+	foster := &model.Foster{ID: uuid.New(), Name: "Foster 1"}
+	require.NoError(t, storage.Fosters.Create(ctx, foster))
+	require.NoError(t, storage.Adopters.Create(ctx, &model.Adopter{ID: uuid.New(), Name: "Adopter 1"}))
+
+	deleted := now.Add(-time.Hour)
+	cats := []*model.Cat{
+		{ID: uuid.New(), Name: "Cat 1", Age: 2, Status: model.CatAvailable, Version: 3, ShelterID: &shelter.ID},
+		{ID: uuid.New(), Name: "Cat 2", Age: 4, Status: model.CatAdopted, Version: 1},
+		{ID: uuid.New(), Name: "Cat 3", Age: 1, Status: model.CatAvailable, Version: 2, DeletedAt: &deleted},
+	}
+	require.NoError(t, storage.Cats.CreateMany(ctx, cats))
+
+	cat := cats[0]
+	intake := &model.Intake{ID: uuid.New(), Type: model.IntakeStray, Date: now.AddDate(0, 0, -10)}
+	require.NoError(t, storage.Intakes.AddIntake(ctx, cat.ID, intake))
+	require.NoError(t, storage.Intakes.CloseIntake(ctx, cat.ID, intake.ID,
+		&model.Outcome{Type: model.OutcomeTransfer, Date: now.AddDate(0, 0, -5)}))
+	require.NoError(t, storage.Intakes.AddIntake(ctx, cat.ID,
+		&model.Intake{ID: uuid.New(), Type: model.IntakeTransfer, Date: now.AddDate(0, 0, -4)}))
+	placement := &model.Placement{ID: uuid.New(), CatID: cat.ID, FosterID: foster.ID, StartedAt: now.AddDate(0, 0, -3)}
+	require.NoError(t, storage.Fosters.AddPlacement(ctx, placement))
+	require.NoError(t, storage.Fosters.EndPlacement(ctx, cat.ID, placement.ID, now.AddDate(0, 0, -1), "back"))
+	require.NoError(t, storage.Vaccinations.AddVaccination(ctx, cat.ID,
+		&model.Vaccination{ID: uuid.New(), Vaccine: "rabies", AdministeredAt: now.AddDate(0, -1, 0)}))
+	require.NoError(t, storage.Photos.AddPhoto(ctx, cat.ID,
+		&model.Photo{ID: uuid.New(), ContentType: "image/jpeg", Size: 100, Width: 10, Height: 10, CreatedAt: now}, MaxPhotos))
+	require.NoError(t, storage.Events.AddEvent(ctx,
+		&model.CatEvent{ID: uuid.New(), CatID: cat.ID, Action: model.CatEventCreate, After: cat, Actor: "test", CreatedAt: now}))
+
+	return cats
+}
+
+func requireEqualStorages(t *testing.T, srv *CopyService) {
+	results, err := srv.Verify(context.Background())
+	require.NoError(t, err)
+	require.Len(t, results, 4)
+	for _, result := range results {
+		require.True(t, result.Equal(), result.Kind)
+		require.Equal(t, result.Source, result.Target)
+	}
+}
+
+func TestCopyService_Copy(t *testing.T) {
+	from, to := memoryStorage(), sqliteStorage(t)
+	fillStorage(t, from)
+	checkpoints := repository.NewCheckpointFileRepository(filepath.Join(t.TempDir(), "checkpoint.json"))
+
+	srv := NewCopyService("memory", from, "sqlite", to, checkpoints, 2)
+	report, err := srv.Copy(context.Background(), false)
+	require.NoError(t, err)
+	require.Equal(t, &model.CopyReport{Shelters: 1, Fosters: 1, Adopters: 1, Cats: 3, Records: 6}, report)
+	requireEqualStorages(t, srv)
+
+	checkpoint, err := checkpoints.Load(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, model.CopyDone, checkpoint.Stage)
+	report, err = srv.Copy(context.Background(), false)
+	require.NoError(t, err)
+	require.Equal(t, &model.CopyReport{}, report)
+
+	_, err = NewCopyService("memory", from, "mongo", to, checkpoints, 2).Copy(context.Background(), false)
+	require.ErrorIs(t, err, model.ErrConflict)
+}
+
+func TestCopyService_Resume(t *testing.T) {
+	from, to := memoryStorage(), memoryStorage()
+	cats := fillStorage(t, from)
+	checkpoints := repository.NewCheckpointFileRepository(filepath.Join(t.TempDir(), "checkpoint.json"))
+
+	srv := NewCopyService("memory", from, "memory", to, checkpoints, 10)
+	report, err := srv.Copy(context.Background(), true)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), report.Cats)
+	results, err := srv.Verify(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, int64(3), results[3].MissingCount)
+
+	checkpoint, err := checkpoints.Load(context.Background())
+	require.NoError(t, err)
+	require.Nil(t, checkpoint)
+
+	_, err = srv.Copy(context.Background(), false)
+	require.NoError(t, err)
+	// the copy stopped after the cats were written but before the checkpoint was saved
+	require.NoError(t, checkpoints.Save(context.Background(), &model.CopyCheckpoint{From: "memory", To: "memory", Stage: model.CopyCats}))
+	report, err = srv.Copy(context.Background(), false)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), report.Cats)
+	requireEqualStorages(t, srv)
+
+	patch := "Changed"
+	_, err = to.Cats.Patch(context.Background(), cats[0].ID, model.AnyVersion, &model.CatPatch{Name: &patch})
+	require.NoError(t, err)
+	results, err = srv.Verify(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []uuid.UUID{cats[0].ID}, results[3].Changed)
+}
@@ -0,0 +1,203 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/catService/internal/model"
+)
+
+// cronSearchYears limits the search of the next run, schedules like "0 0 30 2 *" never run
+const cronSearchYears = 5
+
+// cronField is the range of one field of the cron expression
+type cronField struct {
+	name     string
+	min, max int
+}
+
+// Fields of the cron expression in their order. Sunday is 0, 7 is accepted for it too
+func cronFields() []cronField {
+	return []cronField{
+		{name: "minute", min: 0, max: 59},
+		{name: "hour", min: 0, max: 23},
+		{name: "day of month", min: 1, max: 31},
+		{name: "month", min: 1, max: 12},
+		{name: "day of week", min: 0, max: 7},
+	}
+}
+
+// cronDescriptor returns the expression of the predefined schedule, empty string for unknown ones
+func cronDescriptor(spec string) string {
+	switch spec {
+	case "@yearly", "@annually":
+		return "0 0 1 1 *"
+	case "@monthly":
+		return "0 0 1 * *"
+	case "@weekly":
+		return "0 0 * * 0"
+	case "@daily", "@midnight":
+		return "0 0 * * *"
+	case "@hourly":
+		return "0 * * * *"
+	default:
+		return ""
+	}
+}
+
+// Schedule is the parsed cron expression of minute, hour, day of month, month and day of week.
+// Each field is a bit set of the allowed values, the times are in UTC
+type Schedule struct {
+	minute, hour, dom, month, dow uint64
+	// domAny and dowAny are set for * days, the day matches both fields then instead of any of them
+	domAny, dowAny bool
+}
+
+// ParseSchedule parses the standard five-field cron expression or one of @yearly, @monthly,
+// @weekly, @daily and @hourly. Fields accept *, lists, ranges and steps like 1-5, */15 or 0-30/10
+func ParseSchedule(spec string) (*Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	if strings.HasPrefix(spec, "@") {
+		expression := cronDescriptor(spec)
+		if expression == "" {
+			return nil, fmt.Errorf("%w: unknown schedule %q", model.ErrInvalid, spec)
+		}
+		spec = expression
+	}
+
+	parts := strings.Fields(spec)
+	fields := cronFields()
+	if len(parts) != len(fields) {
+		return nil, fmt.Errorf("%w: schedule %q must have %d fields", model.ErrInvalid, spec, len(fields))
+	}
+	bits := make([]uint64, len(fields))
+	for i, field := range fields {
+		var err error
+		if bits[i], err = parseCronField(parts[i], field); err != nil {
+			return nil, fmt.Errorf("%w: schedule %q: %s", model.ErrInvalid, spec, err)
+		}
+	}
+
+	// Sunday is both 0 and 7
+	if bits[4]&(1<<7) != 0 {
+		bits[4] |= 1
+	}
+
+	return &Schedule{
+		minute: bits[0],
+		hour:   bits[1],
+		dom:    bits[2],
+		month:  bits[3],
+		dow:    bits[4],
+		domAny: strings.HasPrefix(parts[2], "*"),
+		dowAny: strings.HasPrefix(parts[4], "*"),
+	}, nil
+}
+
+// parseCronField converts the field into the bit set of the allowed values
+func parseCronField(value string, field cronField) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(value, ",") {
+		rangePart, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			var err error
+			rangePart = part[:i]
+			if step, err = strconv.Atoi(part[i+1:]); err != nil || step <= 0 {
+				return 0, fmt.Errorf("%s step %q must be a positive integer", field.name, part[i+1:])
+			}
+		}
+
+		low, high := field.min, field.max
+		switch {
+		case rangePart == "*":
+		case strings.Contains(rangePart, "-"):
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			if low, err = cronValue(bounds[0], field); err != nil {
+				return 0, err
+			}
+			if high, err = cronValue(bounds[1], field); err != nil {
+				return 0, err
+			}
+			if low > high {
+				return 0, fmt.Errorf("%s range %q is reversed", field.name, rangePart)
+			}
+		default:
+			var err error
+			if low, err = cronValue(rangePart, field); err != nil {
+				return 0, err
+			}
+			if step == 1 {
+				high = low
+			}
+		}
+
+		for v := low; v <= high; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+
+	return bits, nil
+}
+
+// cronValue parses one value of the field and checks its range
+func cronValue(value string, field cronField) (int, error) {
+	v, err := strconv.Atoi(value)
+	if err != nil || v < field.min || v > field.max {
+		return 0, fmt.Errorf("%s %q must be between %d and %d", field.name, value, field.min, field.max)
+	}
+
+	return v, nil
+}
+
+// Next returns the first time after the given one the schedule fires at, zero time if it never fires
+func (s *Schedule) Next(after time.Time) time.Time {
+	t := after.UTC().Truncate(time.Minute).Add(time.Minute)
+	limit := t.Year() + cronSearchYears
+
+wrap:
+	for t.Year() <= limit {
+		for s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+			if t.Month() == time.January {
+				continue wrap
+			}
+		}
+		for !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+			if t.Day() == 1 {
+				continue wrap
+			}
+		}
+		for s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, time.UTC)
+			if t.Hour() == 0 {
+				continue wrap
+			}
+		}
+		for s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			if t.Minute() == 0 {
+				continue wrap
+			}
+		}
+
+		return t
+	}
+
+	return time.Time{}
+}
+
+// dayMatches checks the day of month and the day of week. When both are restricted
+// the day matches any of them as cron does, "0 0 1 * 1" runs on the 1st and on Mondays
+func (s *Schedule) dayMatches(t time.Time) bool {
+	dom := s.dom&(1<<uint(t.Day())) != 0
+	dow := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domAny || s.dowAny {
+		return dom && dow
+	}
+
+	return dom || dow
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/catService/internal/model"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchedule_Next(t *testing.T) {
+	from := time.Date(2022, time.March, 14, 10, 7, 30, 0, time.UTC) // Monday
+
+	tests := []struct {
+		spec string
+		want time.Time
+	}{
+		{spec: "*/15 * * * *", want: time.Date(2022, time.March, 14, 10, 15, 0, 0, time.UTC)},
+		{spec: "0 3 * * *", want: time.Date(2022, time.March, 15, 3, 0, 0, 0, time.UTC)},
+		{spec: "@hourly", want: time.Date(2022, time.March, 14, 11, 0, 0, 0, time.UTC)},
+		{spec: "30 8 * * 1-5", want: time.Date(2022, time.March, 15, 8, 30, 0, 0, time.UTC)},
+		{spec: "0 0 * * 7", want: time.Date(2022, time.March, 20, 0, 0, 0, 0, time.UTC)},
+		{spec: "0 0 1 * 3", want: time.Date(2022, time.March, 16, 0, 0, 0, 0, time.UTC)},
+		{spec: "0 12 31 * *", want: time.Date(2022, time.March, 31, 12, 0, 0, 0, time.UTC)},
+		{spec: "0 0 29 2 *", want: time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		{spec: "@yearly", want: time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{spec: "5,10 10 14 3 *", want: time.Date(2022, time.March, 14, 10, 10, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			schedule, err := ParseSchedule(tt.spec)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, schedule.Next(from))
+		})
+	}
+}
+
+func TestSchedule_NextNever(t *testing.T) {
+	schedule, err := ParseSchedule("0 0 30 2 *")
+	require.NoError(t, err)
+	require.True(t, schedule.Next(time.Now()).IsZero())
+}
+
+func TestParseScheduleInvalid(t *testing.T) {
+	for _, spec := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "5-1 * * * *", "*/0 * * * *", "@often"} {
+		_, err := ParseSchedule(spec)
+		require.ErrorIs(t, err, model.ErrInvalid, spec)
+	}
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/catService/internal/model"
+
+	"github.com/google/uuid"
+)
+
+// catCursor is a keyset position in the cat list. Clients get it as an opaque base64 string
+type catCursor struct {
+	SortBy string          `json:"s"`
+	Desc   bool            `json:"d,omitempty"`
+	ID     uuid.UUID       `json:"i"`
+	Value  json.RawMessage `json:"v,omitempty"`
+}
+
+// encodeCursor returns cursor which points right after the last cat
+func encodeCursor(query *model.CatQuery, last *model.Cat) (string, error) {
+	value, err := json.Marshal(last.SortKey(query.SortBy))
+	if err != nil {
+		return "", fmt.Errorf("marshal sort value: %w", err)
+	}
+
+	data, err := json.Marshal(catCursor{
+		SortBy: query.SortBy,
+		Desc:   query.Desc,
+		ID:     last.ID,
+		Value:  value,
+	})
+	if err != nil {
+		return "", fmt.Errorf("marshal cursor: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// decodeCursor returns the cat the page must start after.
+// The cursor is valid only for the same sorting it was created with
+func decodeCursor(query *model.CatQuery) (*model.Cat, error) {
+	if query.Cursor == "" {
+		return nil, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(query.Cursor)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed cursor", model.ErrInvalid)
+	}
+	var cursor catCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, fmt.Errorf("%w: malformed cursor", model.ErrInvalid)
+	}
+	if cursor.SortBy != query.SortBy || cursor.Desc != query.Desc {
+		return nil, fmt.Errorf("%w: cursor doesn't match sorting", model.ErrInvalid)
+	}
+
+	after := &model.Cat{ID: cursor.ID}
+	if err := json.Unmarshal(cursor.Value, after.SortKey(query.SortBy)); err != nil {
+		return nil, fmt.Errorf("%w: malformed cursor", model.ErrInvalid)
+	}
+
+	return after, nil
+}
+
+// eventCursor is a keyset position in the cat history
+type eventCursor struct {
+	CreatedAt time.Time `json:"t"`
+	ID        uuid.UUID `json:"i"`
+}
+
+// encodeEventCursor returns cursor which points right after the last event
+func encodeEventCursor(last *model.CatEvent) (string, error) {
+	data, err := json.Marshal(eventCursor{CreatedAt: last.CreatedAt, ID: last.ID})
+	if err != nil {
+		return "", fmt.Errorf("marshal cursor: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// decodeEventCursor returns the event the page must start after
+func decodeEventCursor(value string) (*model.CatEvent, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed cursor", model.ErrInvalid)
+	}
+	var cursor eventCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, fmt.Errorf("%w: malformed cursor", model.ErrInvalid)
+	}
+
+	return &model.CatEvent{ID: cursor.ID, CreatedAt: cursor.CreatedAt}, nil
+}
+
+// encodeIDCursor returns cursor which points right after the last shelter or foster,
+// the lists ordered by ID need nothing else
+func encodeIDCursor(last uuid.UUID) string {
+	return base64.RawURLEncoding.EncodeToString(last[:])
+}
+
+// decodeIDCursor returns ID of the shelter or the foster the page must start after
+func decodeIDCursor(value string) (*uuid.UUID, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed cursor", model.ErrInvalid)
+	}
+	id, err := uuid.FromBytes(data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed cursor", model.ErrInvalid)
+	}
+
+	return &id, nil
+}
@@ -0,0 +1,220 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/catService/internal/model"
+	"github.com/catService/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+// FostersService contains business logic for fosters and the placements of cats with them
+//go:generate mockery --dir . --name FostersService --output ./service_mock
+type FostersService interface {
+	Get(context.Context, uuid.UUID) (*model.Foster, error)
+	Create(context.Context, *model.Foster) error
+	Update(context.Context, *model.Foster) error
+	Delete(context.Context, uuid.UUID) error
+	List(ctx context.Context, limit int, cursor string) (*model.FosterPage, error)
+	Placements(ctx context.Context, catID uuid.UUID) ([]*model.Placement, error)
+	Place(ctx context.Context, catID uuid.UUID, placement *model.Placement) error
+	BringBack(ctx context.Context, catID uuid.UUID, endedAt time.Time, notes string) (*model.Placement, error)
+}
+
+// FosterService contains links to the foster storage and the cat service
+type FosterService struct {
+	rps  repository.FosterRepository
+	cats SheltersCatService
+}
+
+// NewFosterService create new instance
+func NewFosterService(rps repository.FosterRepository, cats SheltersCatService) *FosterService {
+	return &FosterService{
+		rps:  rps,
+		cats: cats,
+	}
+}
+
+// Get returns foster
+func (s *FosterService) Get(ctx context.Context, id uuid.UUID) (*model.Foster, error) {
+	foster, err := s.rps.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("get foster %s: %w", id, err)
+	}
+
+	return foster, nil
+}
+
+// Create validates and saves new foster
+func (s *FosterService) Create(ctx context.Context, foster *model.Foster) error {
+	if err := validateFoster(foster); err != nil {
+		return err
+	}
+
+	foster.ID = uuid.New()
+	if err := s.rps.Create(ctx, foster); err != nil {
+		return fmt.Errorf("create foster: %w", err)
+	}
+
+	return nil
+}
+
+// Update validates and saves foster states
+func (s *FosterService) Update(ctx context.Context, foster *model.Foster) error {
+	if err := validateFoster(foster); err != nil {
+		return err
+	}
+	if err := s.rps.Update(ctx, foster); err != nil {
+		return fmt.Errorf("update foster %s: %w", foster.ID, err)
+	}
+
+	return nil
+}
+
+// Delete removes foster, fosters with placements are kept for the cat history
+func (s *FosterService) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := s.rps.Delete(ctx, id); err != nil {
+		return fmt.Errorf("delete foster %s: %w", id, err)
+	}
+
+	return nil
+}
+
+// List returns a page of fosters
+func (s *FosterService) List(ctx context.Context, limit int, cursor string) (*model.FosterPage, error) {
+	switch {
+	case limit == 0:
+		limit = DefaultListLimit
+	case limit < 0 || limit > MaxListLimit:
+		return nil, fmt.Errorf("%w: limit must be between 1 and %d", model.ErrInvalid, MaxListLimit)
+	}
+	after, err := decodeIDCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	// one extra foster shows whether there is a next page
+	fosters, err := s.rps.List(ctx, after, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("list fosters: %w", err)
+	}
+
+	page := &model.FosterPage{Fosters: fosters}
+	if len(fosters) > limit {
+		page.Fosters = fosters[:limit]
+		page.NextCursor = encodeIDCursor(page.Fosters[limit-1].ID)
+	}
+
+	return page, nil
+}
+
+// Placements returns placements of the cat in the order they started
+func (s *FosterService) Placements(ctx context.Context, catID uuid.UUID) ([]*model.Placement, error) {
+	if _, err := s.cats.Get(ctx, catID); err != nil {
+		return nil, fmt.Errorf("cat %s placements: %w", catID, err)
+	}
+	placements, err := s.rps.ListPlacements(ctx, catID)
+	if err != nil {
+		return nil, fmt.Errorf("cat %s placements: %w", catID, err)
+	}
+
+	return placements, nil
+}
+
+// Place validates and saves new active placement of the cat with the foster.
+// Placement starts now if the start is not given, it must not overlap previous placements
+func (s *FosterService) Place(ctx context.Context, catID uuid.UUID, placement *model.Placement) error {
+	if placement.StartedAt.IsZero() {
+		placement.StartedAt = time.Now()
+	}
+	placement.StartedAt = placement.StartedAt.UTC().Truncate(time.Millisecond)
+	if placement.StartedAt.After(time.Now()) {
+		return fmt.Errorf("%w: start is in the future", model.ErrInvalid)
+	}
+
+	cat, err := s.cats.Get(ctx, catID)
+	if err != nil {
+		return fmt.Errorf("place cat %s: %w", catID, err)
+	}
+	if model.StatusOrDefault(cat.Status) == model.CatAdopted {
+		return fmt.Errorf("%w: cat is adopted", model.ErrConflict)
+	}
+	if _, err := s.rps.Get(ctx, placement.FosterID); err != nil {
+		return fmt.Errorf("place cat %s: %w: foster %s doesn't exist", catID, model.ErrInvalid, placement.FosterID)
+	}
+	placements, err := s.rps.ListPlacements(ctx, catID)
+	if err != nil {
+		return fmt.Errorf("place cat %s: %w", catID, err)
+	}
+	for _, previous := range placements {
+		if previous.Active() {
+			return fmt.Errorf("%w: cat is already with foster %s", model.ErrConflict, previous.FosterID)
+		}
+		if placement.StartedAt.Before(*previous.EndedAt) {
+			return fmt.Errorf("%w: placement overlaps the previous one", model.ErrInvalid)
+		}
+	}
+
+	placement.ID = uuid.New()
+	placement.CatID = catID
+	placement.EndedAt = nil
+	if err := s.rps.AddPlacement(ctx, placement); err != nil {
+		return fmt.Errorf("place cat %s: %w", catID, err)
+	}
+
+	return nil
+}
+
+// BringBack ends the active placement of the cat, the ended placement is returned.
+// Placement ends now if the end is not given, the notes replace the placement notes if they are given
+func (s *FosterService) BringBack(ctx context.Context, catID uuid.UUID, endedAt time.Time, notes string) (*model.Placement, error) {
+	if endedAt.IsZero() {
+		endedAt = time.Now()
+	}
+	endedAt = endedAt.UTC().Truncate(time.Millisecond)
+	if endedAt.After(time.Now()) {
+		return nil, fmt.Errorf("%w: end is in the future", model.ErrInvalid)
+	}
+
+	placements, err := s.Placements(ctx, catID)
+	if err != nil {
+		return nil, fmt.Errorf("bring back cat %s: %w", catID, err)
+	}
+	var placement *model.Placement
+	for _, p := range placements {
+		if p.Active() {
+			placement = p
+		}
+	}
+	if placement == nil {
+		return nil, fmt.Errorf("%w: cat is not with a foster", model.ErrConflict)
+	}
+	if endedAt.Before(placement.StartedAt) {
+		return nil, fmt.Errorf("%w: end is before the start", model.ErrInvalid)
+	}
+	if strings.TrimSpace(notes) != "" {
+		placement.Notes = notes
+	}
+
+	if err := s.rps.EndPlacement(ctx, catID, placement.ID, endedAt, placement.Notes); err != nil {
+		return nil, fmt.Errorf("bring back cat %s: %w", catID, err)
+	}
+	placement.EndedAt = &endedAt
+
+	return placement, nil
+}
+
+func validateFoster(foster *model.Foster) error {
+	if strings.TrimSpace(foster.Name) == "" {
+		return fmt.Errorf("%w: name is required", model.ErrInvalid)
+	}
+	if strings.TrimSpace(foster.Contact) == "" {
+		return fmt.Errorf("%w: contact is required", model.ErrInvalid)
+	}
+
+	return nil
+}
@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/catService/internal/model"
+	mocks "github.com/catService/internal/repository/repository_mock"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFosterService_Place(t *testing.T) {
+	catID := uuid.New()
+	foster := &model.Foster{ID: uuid.New(), Name: "Anna", Contact: "+375291111111"}
+	ended := time.Now().AddDate(0, -1, 0)
+	previous := &model.Placement{ID: uuid.New(), CatID: catID, FosterID: uuid.New(),
+		StartedAt: ended.AddDate(0, -1, 0), EndedAt: &ended}
+
+	rps := &mocks.FosterRepository{}
+	rps.On("Get", context.Background(), foster.ID).Return(foster, nil)
+	rps.On("ListPlacements", context.Background(), catID).Return([]*model.Placement{previous}, nil)
+	rps.On("AddPlacement", context.Background(), mock.Anything).Return(nil)
+
+	srv := NewFosterService(rps, intakeCats(catID))
+	placement := &model.Placement{FosterID: foster.ID, Notes: "Needs quiet home"}
+	require.NoError(t, srv.Place(context.Background(), catID, placement))
+	require.NotEqual(t, uuid.Nil, placement.ID)
+	require.Equal(t, catID, placement.CatID)
+	require.True(t, placement.Active())
+	require.False(t, placement.StartedAt.IsZero())
+	rps.AssertExpectations(t)
+
+	overlap := &model.Placement{FosterID: foster.ID, StartedAt: ended.Add(-time.Hour)}
+	require.ErrorIs(t, srv.Place(context.Background(), catID, overlap), model.ErrInvalid)
+}
+
+func TestFosterService_PlaceActive(t *testing.T) {
+	catID := uuid.New()
+	foster := &model.Foster{ID: uuid.New(), Name: "Anna", Contact: "+375291111111"}
+	active := &model.Placement{ID: uuid.New(), CatID: catID, FosterID: uuid.New(), StartedAt: time.Now().AddDate(0, 0, -3)}
+
+	rps := &mocks.FosterRepository{}
+	rps.On("Get", context.Background(), foster.ID).Return(foster, nil)
+	rps.On("ListPlacements", context.Background(), catID).Return([]*model.Placement{active}, nil)
+
+	srv := NewFosterService(rps, intakeCats(catID))
+	err := srv.Place(context.Background(), catID, &model.Placement{FosterID: foster.ID})
+	require.ErrorIs(t, err, model.ErrConflict)
+	rps.AssertNotCalled(t, "AddPlacement", mock.Anything, mock.Anything)
+}
+
+func TestFosterService_PlaceUnknownFoster(t *testing.T) {
+	catID := uuid.New()
+	fosterID := uuid.New()
+
+	rps := &mocks.FosterRepository{}
+	rps.On("Get", context.Background(), fosterID).Return(nil, model.ErrFosterNotFound)
+
+	srv := NewFosterService(rps, intakeCats(catID))
+	err := srv.Place(context.Background(), catID, &model.Placement{FosterID: fosterID})
+	require.ErrorIs(t, err, model.ErrInvalid)
+	require.NotErrorIs(t, err, model.ErrFosterNotFound)
+
+	future := &model.Placement{FosterID: fosterID, StartedAt: time.Now().Add(time.Hour)}
+	require.ErrorIs(t, srv.Place(context.Background(), catID, future), model.ErrInvalid)
+}
+
+func TestFosterService_BringBack(t *testing.T) {
+	catID := uuid.New()
+	active := &model.Placement{ID: uuid.New(), CatID: catID, FosterID: uuid.New(),
+		StartedAt: time.Now().AddDate(0, 0, -3), Notes: "Needs quiet home"}
+
+	rps := &mocks.FosterRepository{}
+	rps.On("ListPlacements", context.Background(), catID).Return([]*model.Placement{active}, nil)
+	rps.On("EndPlacement", context.Background(), catID, active.ID, mock.Anything, "Gained weight").Return(nil)
+
+	srv := NewFosterService(rps, intakeCats(catID))
+	placement, err := srv.BringBack(context.Background(), catID, time.Time{}, "Gained weight")
+	require.NoError(t, err)
+	require.False(t, placement.Active())
+	require.Equal(t, "Gained weight", placement.Notes)
+	rps.AssertExpectations(t)
+}
+
+func TestFosterService_BringBackBeforeStart(t *testing.T) {
+	catID := uuid.New()
+	active := &model.Placement{ID: uuid.New(), CatID: catID, FosterID: uuid.New(), StartedAt: time.Now().AddDate(0, 0, -3)}
+
+	rps := &mocks.FosterRepository{}
+	rps.On("ListPlacements", context.Background(), catID).Return([]*model.Placement{active}, nil)
+
+	srv := NewFosterService(rps, intakeCats(catID))
+	_, err := srv.BringBack(context.Background(), catID, active.StartedAt.Add(-time.Hour), "")
+	require.ErrorIs(t, err, model.ErrInvalid)
+	rps.AssertNotCalled(t, "EndPlacement", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestFosterService_BringBackNotPlaced(t *testing.T) {
+	catID := uuid.New()
+
+	rps := &mocks.FosterRepository{}
+	rps.On("ListPlacements", context.Background(), catID).Return([]*model.Placement{}, nil)
+
+	srv := NewFosterService(rps, intakeCats(catID))
+	_, err := srv.BringBack(context.Background(), catID, time.Time{}, "")
+	require.ErrorIs(t, err, model.ErrConflict)
+}
+
+func TestFosterService_List(t *testing.T) {
+	fosters := []*model.Foster{{ID: uuid.New()}, {ID: uuid.New()}, {ID: uuid.New()}}
+
+	rps := &mocks.FosterRepository{}
+	rps.On("List", context.Background(), (*uuid.UUID)(nil), 3).Return(fosters, nil)
+	rps.On("List", context.Background(), &fosters[1].ID, 3).Return(fosters[2:], nil)
+
+	srv := NewFosterService(rps, nil)
+	page, err := srv.List(context.Background(), 2, "")
+	require.NoError(t, err)
+	require.Len(t, page.Fosters, 2)
+	require.NotEmpty(t, page.NextCursor)
+
+	page, err = srv.List(context.Background(), 2, page.NextCursor)
+	require.NoError(t, err)
+	require.Equal(t, fosters[2:], page.Fosters)
+	require.Empty(t, page.NextCursor)
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"image"
+	"image/color"
+)
+
+// resizeImage scales the image down to fit into the square with the given side keeping the proportions.
+// Every pixel of the result is the average of the source pixels it covers, transparent parts become white
+func resizeImage(src image.Image, side int) image.Image {
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	newWidth, newHeight := width, height
+	switch {
+	case width <= side && height <= side:
+	case width >= height:
+		newWidth, newHeight = side, height*side/width
+	default:
+		newWidth, newHeight = width*side/height, side
+	}
+	if newWidth < 1 {
+		newWidth = 1
+	}
+	if newHeight < 1 {
+		newHeight = 1
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, newWidth, newHeight))
+	for y := 0; y < newHeight; y++ {
+		y0, y1 := bounds.Min.Y+y*height/newHeight, bounds.Min.Y+(y+1)*height/newHeight
+		for x := 0; x < newWidth; x++ {
+			x0, x1 := bounds.Min.X+x*width/newWidth, bounds.Min.X+(x+1)*width/newWidth
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					pr, pg, pb, pa := src.At(sx, sy).RGBA()
+					r, g, b, a, n = r+uint64(pr), g+uint64(pg), b+uint64(pb), a+uint64(pa), n+1
+				}
+			}
+			// colors are alpha-premultiplied, so adding the missing alpha puts them on white
+			white := 0xffff - a/n
+			dst.Set(x, y, color.RGBA64{
+				R: uint16(r/n + white),
+				G: uint16(g/n + white),
+				B: uint16(b/n + white),
+				A: 0xffff,
+			})
+		}
+	}
+
+	return dst
+}
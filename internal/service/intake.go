@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/catService/internal/model"
+	"github.com/catService/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+// IntakesService contains business logic for intake and outcome records
+//go:generate mockery --dir . --name IntakesService --output ./service_mock
+type IntakesService interface {
+	Intakes(ctx context.Context, catID uuid.UUID) ([]*model.Intake, error)
+	AddIntake(ctx context.Context, catID uuid.UUID, intake *model.Intake) error
+	AddOutcome(ctx context.Context, catID uuid.UUID, outcome *model.Outcome) (*model.Intake, error)
+}
+
+// IntakeService contains links to the intake storage and the cat service
+type IntakeService struct {
+	rps  repository.IntakeRepository
+	cats SheltersCatService
+}
+
+// NewIntakeService create new instance
+func NewIntakeService(rps repository.IntakeRepository, cats SheltersCatService) *IntakeService {
+	return &IntakeService{
+		rps:  rps,
+		cats: cats,
+	}
+}
+
+// Intakes returns intake records of the cat with their outcomes in the order of intake
+func (s *IntakeService) Intakes(ctx context.Context, catID uuid.UUID) ([]*model.Intake, error) {
+	if _, err := s.cats.Get(ctx, catID); err != nil {
+		return nil, fmt.Errorf("cat %s intakes: %w", catID, err)
+	}
+	intakes, err := s.rps.ListIntakes(ctx, catID)
+	if err != nil {
+		return nil, fmt.Errorf("cat %s intakes: %w", catID, err)
+	}
+
+	return intakes, nil
+}
+
+// AddIntake validates and saves new open intake. The cat must have no open intake
+// and the intake must not go before the outcome of the previous one
+func (s *IntakeService) AddIntake(ctx context.Context, catID uuid.UUID, intake *model.Intake) error {
+	intake.Type = strings.ToLower(strings.TrimSpace(intake.Type))
+	if !model.IsIntakeType(intake.Type) {
+		return fmt.Errorf("%w: intake type must be stray, owner_surrender, transfer or born_in_care", model.ErrInvalid)
+	}
+	if err := validateRecordDate(intake.Date); err != nil {
+		return err
+	}
+	intakes, err := s.Intakes(ctx, catID)
+	if err != nil {
+		return fmt.Errorf("add cat %s intake: %w", catID, err)
+	}
+	for _, previous := range intakes {
+		if previous.Open() {
+			return fmt.Errorf("%w: cat already has open intake %s", model.ErrConflict, previous.ID)
+		}
+		if intake.Date.Before(previous.Outcome.Date) {
+			return fmt.Errorf("%w: intake date is before the previous outcome", model.ErrInvalid)
+		}
+	}
+
+	intake.ID = uuid.New()
+	intake.Outcome = nil
+	if err := s.rps.AddIntake(ctx, catID, intake); err != nil {
+		return fmt.Errorf("add cat %s intake: %w", catID, err)
+	}
+
+	return nil
+}
+
+// AddOutcome validates the outcome and closes the open intake with it, the closed intake is returned
+func (s *IntakeService) AddOutcome(ctx context.Context, catID uuid.UUID, outcome *model.Outcome) (*model.Intake, error) {
+	outcome.Type = strings.ToLower(strings.TrimSpace(outcome.Type))
+	if !model.IsOutcomeType(outcome.Type) {
+		return nil, fmt.Errorf("%w: outcome type must be adoption, return_to_owner, transfer or death", model.ErrInvalid)
+	}
+	if err := validateRecordDate(outcome.Date); err != nil {
+		return nil, err
+	}
+	intakes, err := s.Intakes(ctx, catID)
+	if err != nil {
+		return nil, fmt.Errorf("add cat %s outcome: %w", catID, err)
+	}
+	intake := model.OpenIntake(intakes)
+	if intake == nil {
+		return nil, fmt.Errorf("%w: cat has no open intake", model.ErrConflict)
+	}
+	if outcome.Date.Before(intake.Date) {
+		return nil, fmt.Errorf("%w: outcome date is before the intake", model.ErrInvalid)
+	}
+
+	if err := s.rps.CloseIntake(ctx, catID, intake.ID, outcome); err != nil {
+		return nil, fmt.Errorf("add cat %s outcome: %w", catID, err)
+	}
+	intake.Outcome = outcome
+
+	return intake, nil
+}
+
+// validateRecordDate checks the date of the intake or the outcome
+func validateRecordDate(date time.Time) error {
+	if date.IsZero() {
+		return fmt.Errorf("%w: date is required", model.ErrInvalid)
+	}
+	if date.After(time.Now()) {
+		return fmt.Errorf("%w: date is in the future", model.ErrInvalid)
+	}
+
+	return nil
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/catService/internal/model"
+	mocks "github.com/catService/internal/repository/repository_mock"
+	servicemock "github.com/catService/internal/service/service_mock"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// intakeCats returns the cat service which knows the cat
+func intakeCats(catID uuid.UUID) *servicemock.SheltersCatService {
+	cats := &servicemock.SheltersCatService{}
+	cats.On("Get", context.Background(), catID).Return(&model.Cat{ID: catID}, nil)
+
+	return cats
+}
+
+func TestIntakeService_AddIntake(t *testing.T) {
+	catID := uuid.New()
+	left := time.Now().AddDate(0, -2, 0)
+	closed := &model.Intake{ID: uuid.New(), Type: model.IntakeStray, Date: left.AddDate(0, -1, 0),
+		Outcome: &model.Outcome{Type: model.OutcomeReturnToOwner, Date: left}}
+
+	rps := &mocks.IntakeRepository{}
+	rps.On("ListIntakes", context.Background(), catID).Return([]*model.Intake{closed}, nil)
+	rps.On("AddIntake", context.Background(), catID, mock.Anything).Return(nil)
+
+	srv := NewIntakeService(rps, intakeCats(catID))
+	intake := &model.Intake{Type: " Owner_Surrender ", Date: time.Now().AddDate(0, -1, 0), Location: "Front desk"}
+	require.NoError(t, srv.AddIntake(context.Background(), catID, intake))
+	require.NotEqual(t, uuid.Nil, intake.ID)
+	require.Equal(t, model.IntakeOwnerSurrender, intake.Type)
+	rps.AssertExpectations(t)
+
+	err := srv.AddIntake(context.Background(), catID, &model.Intake{Type: model.IntakeTransfer, Date: left.AddDate(0, 0, -1)})
+	require.ErrorIs(t, err, model.ErrInvalid)
+}
+
+func TestIntakeService_AddIntakeInvalid(t *testing.T) {
+	srv := NewIntakeService(&mocks.IntakeRepository{}, &servicemock.SheltersCatService{})
+
+	tests := []*model.Intake{
+		{Type: "found", Date: time.Now()},
+		{Type: model.IntakeStray},
+		{Type: model.IntakeStray, Date: time.Now().Add(time.Hour)},
+	}
+	for _, intake := range tests {
+		require.ErrorIs(t, srv.AddIntake(context.Background(), uuid.New(), intake), model.ErrInvalid)
+	}
+}
+
+func TestIntakeService_AddIntakeOpen(t *testing.T) {
+	catID := uuid.New()
+	open := &model.Intake{ID: uuid.New(), Type: model.IntakeStray, Date: time.Now().AddDate(0, -1, 0)}
+
+	rps := &mocks.IntakeRepository{}
+	rps.On("ListIntakes", context.Background(), catID).Return([]*model.Intake{open}, nil)
+
+	srv := NewIntakeService(rps, intakeCats(catID))
+	err := srv.AddIntake(context.Background(), catID, &model.Intake{Type: model.IntakeTransfer, Date: time.Now()})
+	require.ErrorIs(t, err, model.ErrConflict)
+	rps.AssertNotCalled(t, "AddIntake", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestIntakeService_AddOutcome(t *testing.T) {
+	catID := uuid.New()
+	open := &model.Intake{ID: uuid.New(), Type: model.IntakeStray, Date: time.Now().AddDate(0, -1, 0)}
+
+	rps := &mocks.IntakeRepository{}
+	rps.On("ListIntakes", context.Background(), catID).Return([]*model.Intake{open}, nil)
+	rps.On("CloseIntake", context.Background(), catID, open.ID, mock.Anything).Return(nil)
+
+	srv := NewIntakeService(rps, intakeCats(catID))
+	_, err := srv.AddOutcome(context.Background(), catID, &model.Outcome{Type: model.OutcomeAdoption, Date: open.Date.AddDate(0, 0, -1)})
+	require.ErrorIs(t, err, model.ErrInvalid)
+
+	intake, err := srv.AddOutcome(context.Background(), catID, &model.Outcome{Type: model.OutcomeAdoption, Date: time.Now()})
+	require.NoError(t, err)
+	require.Equal(t, open.ID, intake.ID)
+	require.False(t, intake.Open())
+	rps.AssertExpectations(t)
+}
+
+func TestIntakeService_AddOutcomeWithoutIntake(t *testing.T) {
+	catID := uuid.New()
+	closed := &model.Intake{ID: uuid.New(), Type: model.IntakeStray, Date: time.Now().AddDate(0, -1, 0),
+		Outcome: &model.Outcome{Type: model.OutcomeTransfer, Date: time.Now()}}
+
+	rps := &mocks.IntakeRepository{}
+	rps.On("ListIntakes", context.Background(), catID).Return([]*model.Intake{closed}, nil)
+
+	srv := NewIntakeService(rps, intakeCats(catID))
+	_, err := srv.AddOutcome(context.Background(), catID, &model.Outcome{Type: model.OutcomeDeath, Date: time.Now()})
+	require.ErrorIs(t, err, model.ErrConflict)
+	rps.AssertNotCalled(t, "CloseIntake", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
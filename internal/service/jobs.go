@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Names of the scheduled jobs
+const (
+	PurgeJob               = "purge-deleted-cats"
+	VaccinationReminderJob = "vaccination-reminders"
+	VaccinationSyncJob     = "vaccination-sync"
+)
+
+// NewPurgeJob returns the job which permanently removes cats deleted longer than retention ago
+func NewPurgeJob(cats SheltersCatService, retention time.Duration) JobFunc {
+	return func(ctx context.Context) (string, error) {
+		purged, err := cats.Purge(ctx, retention)
+		if err != nil {
+			return "", err
+		}
+
+		return fmt.Sprintf("%d cats purged", purged), nil
+	}
+}
+
+// NewVaccinationReminderJob returns the job which reminds the staff of the vaccinations
+// of the cats in care expiring within the window. Reminders are written to the log as warnings
+func NewVaccinationReminderJob(cats SheltersCatService, window time.Duration) JobFunc {
+	return func(ctx context.Context) (string, error) {
+		due, err := cats.DueVaccinations(ctx, window)
+		if err != nil {
+			return "", err
+		}
+
+		overdue := 0
+		now := time.Now()
+		for _, record := range due {
+			if record.ExpiresAt.Before(now) {
+				overdue++
+			}
+			logrus.Warnf("vaccination reminder: %s of cat %s %s expires on %s",
+				record.Vaccine, record.CatName, record.CatID, record.ExpiresAt.UTC().Format("2006-01-02"))
+		}
+
+		return fmt.Sprintf("%d vaccinations due, %d overdue", len(due), overdue), nil
+	}
+}
+
+// NewVaccinationSyncJob returns the job which clears the vaccinated flag of the cats whose vaccinations expired
+func NewVaccinationSyncJob(cats SheltersCatService) JobFunc {
+	return func(ctx context.Context) (string, error) {
+		synced, err := cats.SyncVaccinated(ctx)
+		if err != nil {
+			return "", err
+		}
+
+		return fmt.Sprintf("%d cats no longer vaccinated", synced), nil
+	}
+}
@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/catService/internal/model"
+	"github.com/catService/internal/repository"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Outbox relay settings
+const (
+	// relayLockKey is the lock which lets one replica publish the outbox, so the stream keeps the order of the changes
+	relayLockKey = "outbox-relay"
+	// relayLockTTL is how long another replica waits before taking over the relay of the stopped one
+	relayLockTTL = 30 * time.Second
+	// relayBatchSize is the number of the pending messages read at once
+	relayBatchSize = 100
+	// relayMaxBackoff limits the delay between the retries of the failed message
+	relayMaxBackoff = time.Minute
+	// relayMaxAttempts is the number of the failed attempts after which the message is given up,
+	// with the growing delays it is about a quarter of an hour
+	relayMaxAttempts = 20
+	// outboxRetention is how long the sent messages are kept
+	outboxRetention = 24 * time.Hour
+	// outboxPurgeInterval is how often the sent messages are purged
+	outboxPurgeInterval = time.Hour
+)
+
+// OutboxRelay publishes the outbox messages to the cache stream and marks them as sent.
+// Every replica runs the relay, the lock in the job storage lets one of them publish at a time.
+// The failed message is retried with growing delays and the messages after it wait for it,
+// so the caches get the changes in order. After relayMaxAttempts the message is given up and
+// the relay goes on, the caches miss that change. A message may be published twice if marking fails,
+// the caches apply it again
+type OutboxRelay struct {
+	outbox   repository.OutboxRepository
+	cache    repository.RedisRepository
+	locks    repository.JobRepository
+	owner    string
+	interval time.Duration
+	leading  bool
+	failures int
+	purgedAt time.Time
+	wg       sync.WaitGroup
+}
+
+// NewOutboxRelay create new instance, interval is the delay between the polls of the empty outbox
+func NewOutboxRelay(outbox repository.OutboxRepository, cache repository.RedisRepository, locks repository.JobRepository,
+	owner string, interval time.Duration) *OutboxRelay {
+	return &OutboxRelay{
+		outbox:   outbox,
+		cache:    cache,
+		locks:    locks,
+		owner:    owner,
+		interval: interval,
+	}
+}
+
+// Start publishes the messages in the background until the context is done
+func (r *OutboxRelay) Start(ctx context.Context) {
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		for {
+			timer := time.NewTimer(r.relay(ctx))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+		}
+	}()
+}
+
+// Wait blocks until the relay stops
+func (r *OutboxRelay) Wait() {
+	r.wg.Wait()
+}
+
+// relay publishes one batch of the pending messages and returns the delay before the next one
+func (r *OutboxRelay) relay(ctx context.Context) time.Duration {
+	if !r.lead(ctx) {
+		return r.interval
+	}
+
+	messages, err := r.outbox.Pending(ctx, relayBatchSize)
+	if err != nil {
+		if ctx.Err() == nil {
+			logrus.Errorf("outbox relay error %s", err)
+		}
+		return r.interval
+	}
+	for _, message := range messages {
+		if err := r.cache.Publish(ctx, message); err != nil {
+			if message.Attempts+1 < relayMaxAttempts {
+				return r.retry(ctx, message, err)
+			}
+			if err := r.giveUp(ctx, message, err); err != nil {
+				return r.interval
+			}
+			continue
+		}
+		r.failures = 0
+		if err := r.outbox.MarkSent(ctx, message.ID); err != nil {
+			logrus.Errorf("outbox message %s is published, but not marked as sent: %s", message.ID, err)
+			return r.interval
+		}
+	}
+	if len(messages) == relayBatchSize {
+		return 0
+	}
+
+	r.purge(ctx)
+	return r.interval
+}
+
+// retry counts the failed attempt and returns the delay which doubles with every failure in a row
+func (r *OutboxRelay) retry(ctx context.Context, message *model.OutboxMessage, err error) time.Duration {
+	r.failures++
+	logrus.Errorf("outbox message %s publish attempt %d failed: %s", message.ID, message.Attempts+1, err)
+	if err := r.outbox.MarkFailed(ctx, message.ID, err.Error()); err != nil {
+		logrus.Errorf("outbox message %s mark failed error %s", message.ID, err)
+	}
+
+	delay := r.interval
+	for i := 1; i < r.failures && delay < relayMaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > relayMaxBackoff {
+		delay = relayMaxBackoff
+	}
+
+	return delay
+}
+
+// giveUp marks the message which failed the last attempt as dead, so the messages after it are published
+func (r *OutboxRelay) giveUp(ctx context.Context, message *model.OutboxMessage, err error) error {
+	logrus.Errorf("outbox message %s %s is given up after %d failed attempts, the caches miss the change: %s",
+		message.ID, message.Action, message.Attempts+1, err)
+	if err := r.outbox.MarkDead(ctx, message.ID, err.Error()); err != nil {
+		logrus.Errorf("outbox message %s mark dead error %s", message.ID, err)
+		return err
+	}
+
+	return nil
+}
+
+// lead takes or renews the relay lock, false means another replica publishes the outbox
+func (r *OutboxRelay) lead(ctx context.Context) bool {
+	var err error
+	if r.leading {
+		r.leading, err = r.locks.Renew(ctx, relayLockKey, r.owner, relayLockTTL)
+	} else {
+		r.leading, err = r.locks.Lock(ctx, relayLockKey, r.owner, relayLockTTL)
+	}
+	if err != nil {
+		if ctx.Err() == nil {
+			logrus.Errorf("outbox relay lock error %s", err)
+		}
+		r.leading = false
+	}
+
+	return r.leading
+}
+
+// purge removes the messages sent longer than outboxRetention ago, at most once in outboxPurgeInterval
+func (r *OutboxRelay) purge(ctx context.Context) {
+	if time.Since(r.purgedAt) < outboxPurgeInterval {
+		return
+	}
+	r.purgedAt = time.Now()
+
+	purged, err := r.outbox.PurgeSent(ctx, r.purgedAt.Add(-outboxRetention))
+	if err != nil {
+		logrus.Errorf("outbox purge error %s", err)
+		return
+	}
+	if purged > 0 {
+		logrus.Infof("%d sent outbox messages purged", purged)
+	}
+}
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/catService/internal/model"
+	mocks "github.com/catService/internal/repository/repository_mock"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutboxRelay_Relay(t *testing.T) {
+	first := &model.OutboxMessage{ID: uuid.New(), Action: model.OutboxCreate, Payload: "{}"}
+	second := &model.OutboxMessage{ID: uuid.New(), Action: model.OutboxDelete, Payload: uuid.NewString(), Attempts: 2}
+	third := &model.OutboxMessage{ID: uuid.New(), Action: model.OutboxCreate, Payload: "{}"}
+
+	locks := &mocks.JobRepository{}
+	locks.On("Lock", context.Background(), relayLockKey, "replica-1", relayLockTTL).Return(true, nil).Once()
+	locks.On("Renew", context.Background(), relayLockKey, "replica-1", relayLockTTL).Return(true, nil)
+	outbox := &mocks.OutboxRepository{}
+	outbox.On("Pending", context.Background(), relayBatchSize).Return([]*model.OutboxMessage{first, second, third}, nil).Once()
+	outbox.On("Pending", context.Background(), relayBatchSize).Return([]*model.OutboxMessage{second, third}, nil)
+	outbox.On("MarkSent", context.Background(), first.ID).Return(nil).Once()
+	outbox.On("MarkFailed", context.Background(), second.ID, "redis is down").Return(nil).Twice()
+	cache := &mocks.RedisRepository{}
+	cache.On("Publish", context.Background(), first).Return(nil).Once()
+	cache.On("Publish", context.Background(), second).Return(errors.New("redis is down")).Twice()
+
+	relay := NewOutboxRelay(outbox, cache, locks, "replica-1", time.Second)
+	require.Equal(t, time.Second, relay.relay(context.Background()))
+	require.Equal(t, 2*time.Second, relay.relay(context.Background()))
+	outbox.AssertExpectations(t)
+	cache.AssertExpectations(t)
+	cache.AssertNotCalled(t, "Publish", context.Background(), third)
+
+	cache.On("Publish", context.Background(), mock.Anything).Return(nil)
+	outbox.On("MarkSent", context.Background(), mock.Anything).Return(nil)
+	outbox.On("PurgeSent", context.Background(), mock.Anything).Return(int64(0), nil).Once()
+	require.Equal(t, time.Second, relay.relay(context.Background()))
+	require.Equal(t, time.Second, relay.relay(context.Background()))
+	require.Zero(t, relay.failures)
+	locks.AssertExpectations(t)
+	outbox.AssertExpectations(t)
+}
+
+func TestOutboxRelay_PoisonMessage(t *testing.T) {
+	poison := &model.OutboxMessage{ID: uuid.New(), Action: model.OutboxCreate, Payload: "{", Attempts: relayMaxAttempts - 1}
+	next := &model.OutboxMessage{ID: uuid.New(), Action: model.OutboxDelete, Payload: uuid.NewString()}
+
+	locks := &mocks.JobRepository{}
+	locks.On("Lock", context.Background(), relayLockKey, "replica-1", relayLockTTL).Return(true, nil).Once()
+	outbox := &mocks.OutboxRepository{}
+	outbox.On("Pending", context.Background(), relayBatchSize).Return([]*model.OutboxMessage{poison, next}, nil).Once()
+	outbox.On("MarkDead", context.Background(), poison.ID, "cant unmarshal value").Return(nil).Once()
+	outbox.On("MarkSent", context.Background(), next.ID).Return(nil).Once()
+	outbox.On("PurgeSent", context.Background(), mock.Anything).Return(int64(0), nil).Once()
+	cache := &mocks.RedisRepository{}
+	cache.On("Publish", context.Background(), poison).Return(errors.New("cant unmarshal value")).Once()
+	cache.On("Publish", context.Background(), next).Return(nil).Once()
+
+	relay := NewOutboxRelay(outbox, cache, locks, "replica-1", time.Second)
+	require.Equal(t, time.Second, relay.relay(context.Background()))
+	outbox.AssertExpectations(t)
+	cache.AssertExpectations(t)
+	outbox.AssertNotCalled(t, "MarkFailed", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestOutboxRelay_Follower(t *testing.T) {
+	locks := &mocks.JobRepository{}
+	locks.On("Lock", context.Background(), relayLockKey, "replica-2", relayLockTTL).Return(false, nil).Once()
+	locks.On("Lock", context.Background(), relayLockKey, "replica-2", relayLockTTL).Return(true, nil).Once()
+	locks.On("Renew", context.Background(), relayLockKey, "replica-2", relayLockTTL).Return(false, nil).Once()
+	outbox := &mocks.OutboxRepository{}
+	outbox.On("Pending", context.Background(), relayBatchSize).Return([]*model.OutboxMessage{}, nil).Once()
+	outbox.On("PurgeSent", context.Background(), mock.Anything).Return(int64(3), nil).Once()
+
+	relay := NewOutboxRelay(outbox, &mocks.RedisRepository{}, locks, "replica-2", time.Second)
+	for i := 0; i < 3; i++ {
+		require.Equal(t, time.Second, relay.relay(context.Background()))
+	}
+	require.False(t, relay.leading)
+	locks.AssertExpectations(t)
+	outbox.AssertExpectations(t)
+}
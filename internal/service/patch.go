@@ -0,0 +1,162 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/catService/internal/model"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"github.com/google/uuid"
+)
+
+// Patch document formats of ApplyPatch
+const (
+	MergePatch = "merge-patch" // JSON Merge Patch, RFC 7386
+	JSONPatch  = "json-patch"  // JSON Patch, RFC 6902
+)
+
+// catDocument is the cat representation patch documents are applied to
+type catDocument struct {
+	Name               string     `json:"name"`
+	Age                int        `json:"age"`
+	Vaccinated         bool       `json:"vaccinated"`
+	ShelterID          *uuid.UUID `json:"shelter_id"`
+	BirthDate          *time.Time `json:"birth_date"`
+	BirthDateEstimated bool       `json:"birth_date_estimated"`
+	Sex                string     `json:"sex"`
+	Breed              string     `json:"breed"`
+	CoatColor          string     `json:"coat_color"`
+	Neutered           *bool      `json:"neutered"`
+	IntakeDate         *time.Time `json:"intake_date"`
+	Microchip          *string    `json:"microchip"`
+	GoodWithKids       *bool      `json:"good_with_kids"`
+}
+
+// newCatDocument returns the document of the cat
+func newCatDocument(cat *model.Cat) *catDocument {
+	return &catDocument{
+		Name:               cat.Name,
+		Age:                cat.Age,
+		Vaccinated:         cat.Vaccinated,
+		ShelterID:          cat.ShelterID,
+		BirthDate:          cat.BirthDate,
+		BirthDateEstimated: cat.BirthDateEstimated,
+		Sex:                cat.Sex,
+		Breed:              cat.Breed,
+		CoatColor:          cat.CoatColor,
+		Neutered:           cat.Neutered,
+		IntakeDate:         cat.IntakeDate,
+		Microchip:          cat.Microchip,
+		GoodWithKids:       cat.GoodWithKids,
+	}
+}
+
+// profile returns the model profile of the document
+func (d *catDocument) profile() model.CatProfile {
+	return model.CatProfile{
+		BirthDate:          d.BirthDate,
+		BirthDateEstimated: d.BirthDateEstimated,
+		Sex:                d.Sex,
+		Breed:              d.Breed,
+		CoatColor:          d.CoatColor,
+		Neutered:           d.Neutered,
+		IntakeDate:         d.IntakeDate,
+		Microchip:          d.Microchip,
+		GoodWithKids:       d.GoodWithKids,
+	}
+}
+
+// ApplyPatch applies merge patch or JSON patch document to the stored cat and saves the changed fields.
+// The patch is applied to the cat read inside the versioned change, so it never sees a stale copy
+func (s *CatService) ApplyPatch(ctx context.Context, id uuid.UUID, version int64, format string, document []byte) (*model.Cat, error) {
+	if format != MergePatch && format != JSONPatch {
+		return nil, fmt.Errorf("%w: unknown patch format %q", model.ErrInvalid, format)
+	}
+
+	var patched *model.Cat
+	err := s.withStored(ctx, id, version, func(stored *model.Cat) error {
+		original := newCatDocument(stored)
+		changed, err := applyDocument(format, original, document)
+		if err != nil {
+			return err
+		}
+		patched, err = s.patchStored(ctx, stored, diffDocuments(original, changed))
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("patch cat %s: %w", id, err)
+	}
+
+	return patched, nil
+}
+
+// applyDocument applies the patch document to the cat document and returns the result
+func applyDocument(format string, document *catDocument, patch []byte) (*catDocument, error) {
+	original, err := json.Marshal(document)
+	if err != nil {
+		return nil, err
+	}
+
+	var modified []byte
+	switch format {
+	case MergePatch:
+		modified, err = jsonpatch.MergePatch(original, patch)
+	default:
+		var operations jsonpatch.Patch
+		operations, err = jsonpatch.DecodePatch(patch)
+		if err == nil {
+			modified, err = operations.Apply(original)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", model.ErrInvalid, err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(modified))
+	decoder.DisallowUnknownFields()
+	var result catDocument
+	if err := decoder.Decode(&result); err != nil {
+		return nil, fmt.Errorf("%w: %s", model.ErrInvalid, err)
+	}
+	if result.Age == 0 && result.BirthDate == nil {
+		return nil, fmt.Errorf("%w: age is required without birth_date", model.ErrInvalid)
+	}
+
+	return &result, nil
+}
+
+// diffDocuments returns patch which contains only changed fields
+func diffDocuments(original, patched *catDocument) *model.CatPatch {
+	var patch model.CatPatch
+	if original.Name != patched.Name {
+		patch.Name = &patched.Name
+	}
+	if original.Age != patched.Age {
+		patch.Age = &patched.Age
+	}
+	if original.Vaccinated != patched.Vaccinated {
+		patch.Vaccinated = &patched.Vaccinated
+	}
+	if shelter := shelterOrNil(patched.ShelterID); shelter != shelterOrNil(original.ShelterID) {
+		patch.ShelterID = &shelter
+	}
+	originalProfile, patchedProfile := original.profile(), patched.profile()
+	if !patchedProfile.Equal(&originalProfile) {
+		patch.Profile = &patchedProfile
+	}
+
+	return &patch
+}
+
+// shelterOrNil returns the shelter ID, uuid.Nil for the cat without shelter
+func shelterOrNil(id *uuid.UUID) uuid.UUID {
+	if id == nil {
+		return uuid.Nil
+	}
+
+	return *id
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/catService/internal/model"
+	mocks "github.com/catService/internal/repository/repository_mock"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCatService_ApplyPatch(t *testing.T) {
+	id := uuid.New()
+	age := 3
+
+	// the document is applied to the row read for the change, so the retry sees the concurrent rename
+	rps := &mocks.SheltersCatRepository{}
+	rps.On("Get", context.Background(), id).Return(&model.Cat{ID: id, Name: "Cat 1", Age: 2, Version: 1}, nil).Once()
+	rps.On("Get", context.Background(), id).Return(&model.Cat{ID: id, Name: "Cat 2", Age: 2, Version: 2}, nil).Once()
+	rps.On("Patch", context.Background(), id, int64(1), &model.CatPatch{Age: &age}).Return(int64(0), model.ErrVersionMismatch)
+	rps.On("Patch", context.Background(), id, int64(2), &model.CatPatch{Age: &age}).Return(int64(3), nil)
+	events := &mocks.CatEventRepository{}
+	events.On("AddEvent", context.Background(), mock.Anything).Return(nil)
+
+	srv := NewService(rps, events, &mocks.ShelterRepository{}, &mocks.VaccinationRepository{}, &mocks.PhotoRepository{}, &mocks.BlobStore{}, &mocks.RedisRepository{})
+	cat, err := srv.ApplyPatch(context.Background(), id, model.AnyVersion, JSONPatch,
+		[]byte(`[{"op":"test","path":"/age","value":2},{"op":"replace","path":"/age","value":3}]`))
+	require.NoError(t, err)
+	require.Equal(t, "Cat 2", cat.Name)
+	require.Equal(t, 3, cat.Age)
+	require.Equal(t, int64(3), cat.Version)
+	rps.AssertExpectations(t)
+}
+
+func TestCatService_ApplyPatchInvalid(t *testing.T) {
+	stored := &model.Cat{ID: uuid.New(), Name: "Cat 1", Age: 2, Version: 1}
+
+	tests := []struct {
+		name     string
+		format   string
+		document string
+	}{
+		{name: "unknown format", format: "xml-patch", document: `{}`},
+		{name: "removed required field", format: MergePatch, document: `{"name":null}`},
+		{name: "removed age", format: MergePatch, document: `{"age":null}`},
+		{name: "unknown field", format: MergePatch, document: `{"color":"black"}`},
+		{name: "failed test operation", format: JSONPatch, document: `[{"op":"test","path":"/age","value":5}]`},
+		{name: "broken document", format: JSONPatch, document: `{"op":"add"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rps := &mocks.SheltersCatRepository{}
+			rps.On("Get", context.Background(), stored.ID).Return(stored, nil)
+
+			srv := NewService(rps, &mocks.CatEventRepository{}, &mocks.ShelterRepository{}, &mocks.VaccinationRepository{}, &mocks.PhotoRepository{}, &mocks.BlobStore{}, &mocks.RedisRepository{})
+			_, err := srv.ApplyPatch(context.Background(), stored.ID, model.AnyVersion, tt.format, []byte(tt.document))
+			require.ErrorIs(t, err, model.ErrInvalid)
+			rps.AssertNotCalled(t, "Patch", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestDiffDocumentsShelter(t *testing.T) {
+	shelterID := uuid.New()
+	original := &catDocument{Name: "Cat 1", Age: 2, ShelterID: &shelterID}
+
+	patched, err := applyDocument(MergePatch, original, []byte(`{"shelter_id":null}`))
+	require.NoError(t, err)
+	patch := diffDocuments(original, patched)
+	require.Equal(t, uuid.Nil, *patch.ShelterID)
+	require.Nil(t, patch.Shelter())
+
+	otherID := uuid.New()
+	patched, err = applyDocument(JSONPatch, original, []byte(`[{"op":"replace","path":"/shelter_id","value":"`+otherID.String()+`"}]`))
+	require.NoError(t, err)
+	require.Equal(t, otherID, *diffDocuments(original, patched).Shelter())
+
+	sameID := shelterID
+	require.True(t, diffDocuments(original, &catDocument{Name: "Cat 1", Age: 2, ShelterID: &sameID}).Empty())
+}
+
+func TestDiffDocumentsProfile(t *testing.T) {
+	original := &catDocument{Name: "Cat 1", Age: 2, Sex: model.CatFemale}
+
+	patched, err := applyDocument(MergePatch, original, []byte(`{"breed":"siamese","neutered":true}`))
+	require.NoError(t, err)
+	patch := diffDocuments(original, patched)
+	require.NotNil(t, patch.Profile)
+	require.Equal(t, model.CatFemale, patch.Profile.Sex)
+	require.Equal(t, "siamese", patch.Profile.Breed)
+	require.True(t, *patch.Profile.Neutered)
+	require.Nil(t, patch.Name)
+
+	patched, err = applyDocument(MergePatch, original, []byte(`{"name":"Cat 2"}`))
+	require.NoError(t, err)
+	require.Nil(t, diffDocuments(original, patched).Profile)
+}
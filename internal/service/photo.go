@@ -0,0 +1,250 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"io"
+	"net/http"
+	"time"
+
+	// decoders of the accepted photo formats
+	_ "image/png"
+
+	"github.com/catService/internal/model"
+	"github.com/catService/internal/repository"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// PhotosService contains business logic for cat photos
+//go:generate mockery --dir . --name PhotosService --output ./service_mock
+type PhotosService interface {
+	Photos(ctx context.Context, catID uuid.UUID) ([]*model.Photo, error)
+	AddPhoto(ctx context.Context, catID uuid.UUID, data []byte) (*model.Photo, error)
+	DeletePhoto(ctx context.Context, catID, id uuid.UUID) error
+	Image(ctx context.Context, catID, id uuid.UUID, size string) (io.ReadCloser, string, error)
+}
+
+// Photo upload limits
+const (
+	MaxPhotoSize = 10 << 20
+	MaxPhotos    = 20
+	// maxPhotoPixels protects from small files which decode into huge images
+	maxPhotoPixels = 50_000_000
+)
+
+// Sides of the square the generated sizes fit into
+const (
+	mediumSide    = 800
+	thumbnailSide = 200
+)
+
+// PhotoService contains links to the photo storage, the blob store and the cat service
+type PhotoService struct {
+	rps   repository.PhotoRepository
+	blobs repository.BlobStore
+	cats  SheltersCatService
+}
+
+// NewPhotoService create new instance
+func NewPhotoService(rps repository.PhotoRepository, blobs repository.BlobStore, cats SheltersCatService) *PhotoService {
+	return &PhotoService{
+		rps:   rps,
+		blobs: blobs,
+		cats:  cats,
+	}
+}
+
+// PhotoContentType returns the content type of the image, empty for formats which can't be uploaded
+func PhotoContentType(data []byte) string {
+	switch contentType := http.DetectContentType(data); contentType {
+	case "image/jpeg", "image/png":
+		return contentType
+	default:
+		return ""
+	}
+}
+
+// Photos returns photos of the cat in the order they were uploaded
+func (s *PhotoService) Photos(ctx context.Context, catID uuid.UUID) ([]*model.Photo, error) {
+	if _, err := s.cats.Get(ctx, catID); err != nil {
+		return nil, fmt.Errorf("cat %s photos: %w", catID, err)
+	}
+	photos, err := s.rps.ListPhotos(ctx, catID)
+	if err != nil {
+		return nil, fmt.Errorf("cat %s photos: %w", catID, err)
+	}
+	for _, photo := range photos {
+		fillPhotoURLs(catID, photo)
+	}
+
+	return photos, nil
+}
+
+// AddPhoto checks the image, stores it with the generated sizes and returns its description
+func (s *PhotoService) AddPhoto(ctx context.Context, catID uuid.UUID, data []byte) (*model.Photo, error) {
+	if len(data) > MaxPhotoSize {
+		return nil, fmt.Errorf("%w: photo is larger than %d bytes", model.ErrInvalid, MaxPhotoSize)
+	}
+	contentType := PhotoContentType(data)
+	if contentType == "" {
+		return nil, fmt.Errorf("%w: photo must be jpeg or png", model.ErrInvalid)
+	}
+	if _, err := s.cats.Get(ctx, catID); err != nil {
+		return nil, fmt.Errorf("add cat %s photo: %w", catID, err)
+	}
+	// the limit is checked before the images are generated, the repository enforces it for concurrent uploads
+	photos, err := s.rps.ListPhotos(ctx, catID)
+	if err != nil {
+		return nil, fmt.Errorf("add cat %s photo: %w", catID, err)
+	}
+	if len(photos) >= MaxPhotos {
+		return nil, fmt.Errorf("%w: cat already has %d photos", model.ErrConflict, MaxPhotos)
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: photo can't be decoded: %s", model.ErrInvalid, err)
+	}
+	if config.Width*config.Height > maxPhotoPixels {
+		return nil, fmt.Errorf("%w: photo is larger than %d pixels", model.ErrInvalid, maxPhotoPixels)
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: photo can't be decoded: %s", model.ErrInvalid, err)
+	}
+
+	photo := &model.Photo{
+		ID:          uuid.New(),
+		ContentType: contentType,
+		Size:        int64(len(data)),
+		Width:       config.Width,
+		Height:      config.Height,
+		// both storages keep milliseconds
+		CreatedAt: time.Now().UTC().Truncate(time.Millisecond),
+	}
+	if err := s.putImages(ctx, catID, photo.ID, data, img); err != nil {
+		return nil, fmt.Errorf("add cat %s photo: %w", catID, err)
+	}
+	if err := s.rps.AddPhoto(ctx, catID, photo, MaxPhotos); err != nil {
+		s.deleteImages(ctx, catID, photo.ID)
+		return nil, fmt.Errorf("add cat %s photo: %w", catID, err)
+	}
+	s.touch(ctx, catID)
+	fillPhotoURLs(catID, photo)
+
+	return photo, nil
+}
+
+// DeletePhoto removes the photo of not deleted cat with all its sizes
+func (s *PhotoService) DeletePhoto(ctx context.Context, catID, id uuid.UUID) error {
+	if _, err := s.cats.Get(ctx, catID); err != nil {
+		return fmt.Errorf("delete cat %s photo %s: %w", catID, id, err)
+	}
+	if err := s.rps.DeletePhoto(ctx, catID, id); err != nil {
+		return fmt.Errorf("delete cat %s photo %s: %w", catID, id, err)
+	}
+	s.deleteImages(ctx, catID, id)
+	s.touch(ctx, catID)
+
+	return nil
+}
+
+// Image opens the image of the photo size of not deleted cat and returns its content type, the caller closes it
+func (s *PhotoService) Image(ctx context.Context, catID, id uuid.UUID, size string) (io.ReadCloser, string, error) {
+	contentType := "image/jpeg"
+	switch size {
+	case model.PhotoOriginal:
+		contentType = ""
+	case model.PhotoMedium, model.PhotoThumbnail:
+	default:
+		return nil, "", fmt.Errorf("%w: unknown photo size %q", model.ErrInvalid, size)
+	}
+	if _, err := s.cats.Get(ctx, catID); err != nil {
+		return nil, "", fmt.Errorf("cat %s photo %s: %w", catID, id, err)
+	}
+
+	photos, err := s.rps.ListPhotos(ctx, catID)
+	if err != nil {
+		return nil, "", fmt.Errorf("cat %s photo %s: %w", catID, id, err)
+	}
+	var photo *model.Photo
+	for _, p := range photos {
+		if p.ID == id {
+			photo = p
+		}
+	}
+	if photo == nil {
+		return nil, "", fmt.Errorf("cat %s photo %s: %w", catID, id, model.ErrPhotoNotFound)
+	}
+	if contentType == "" {
+		contentType = photo.ContentType
+	}
+
+	blob, err := s.blobs.Get(ctx, photoKey(catID, id, size))
+	if err != nil {
+		return nil, "", fmt.Errorf("cat %s photo %s: %w", catID, id, err)
+	}
+
+	return blob, contentType, nil
+}
+
+// putImages stores the original and the generated sizes, nothing is left on error
+func (s *PhotoService) putImages(ctx context.Context, catID, id uuid.UUID, data []byte, img image.Image) error {
+	if err := s.blobs.Put(ctx, photoKey(catID, id, model.PhotoOriginal), bytes.NewReader(data)); err != nil {
+		return err
+	}
+	for size, side := range map[string]int{model.PhotoMedium: mediumSide, model.PhotoThumbnail: thumbnailSide} {
+		var buf bytes.Buffer
+		if err := jpeg.Encode(&buf, resizeImage(img, side), &jpeg.Options{Quality: 85}); err != nil {
+			s.deleteImages(ctx, catID, id)
+			return err
+		}
+		if err := s.blobs.Put(ctx, photoKey(catID, id, size), &buf); err != nil {
+			s.deleteImages(ctx, catID, id)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deleteImages removes all sizes of the photo.
+// The photo is already gone for the client, so errors are only logged
+func (s *PhotoService) deleteImages(ctx context.Context, catID, id uuid.UUID) {
+	for _, size := range []string{model.PhotoOriginal, model.PhotoMedium, model.PhotoThumbnail} {
+		if err := s.blobs.Delete(ctx, photoKey(catID, id, size)); err != nil {
+			logrus.Errorf("delete %s of cat %s photo %s failed: %v", size, catID, id, err)
+		}
+	}
+}
+
+// touch changes the version of the cat after its photos changed.
+// The photos are already changed for the client, so errors are only logged
+func (s *PhotoService) touch(ctx context.Context, catID uuid.UUID) {
+	if err := s.cats.Touch(ctx, catID); err != nil {
+		logrus.Errorf("touch cat %s after photo change failed: %v", catID, err)
+	}
+}
+
+// photoKey returns the blob key of the photo size
+func photoKey(catID, id uuid.UUID, size string) string {
+	return fmt.Sprintf("cats/%s/photos/%s/%s", catID, id, size)
+}
+
+// catBlobsPrefix returns the prefix all blobs of the cat are kept under
+func catBlobsPrefix(catID uuid.UUID) string {
+	return fmt.Sprintf("cats/%s/", catID)
+}
+
+// fillPhotoURLs sets the API paths the photo sizes are served from
+func fillPhotoURLs(catID uuid.UUID, photo *model.Photo) {
+	photo.URLs = make(map[string]string, 3)
+	for _, size := range []string{model.PhotoOriginal, model.PhotoMedium, model.PhotoThumbnail} {
+		photo.URLs[size] = fmt.Sprintf("/v1/cat/%s/photos/%s/%s", catID, photo.ID, size)
+	}
+}
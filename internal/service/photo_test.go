@@ -0,0 +1,141 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"testing"
+
+	"github.com/catService/internal/model"
+	mocks "github.com/catService/internal/repository/repository_mock"
+	servicemock "github.com/catService/internal/service/service_mock"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// testPNG returns the png image filled with the color
+func testPNG(t *testing.T, width, height int, fill color.Color) []byte {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, fill)
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+
+	return buf.Bytes()
+}
+
+func TestPhotoService_AddPhoto(t *testing.T) {
+	catID := uuid.New()
+	data := testPNG(t, 1200, 600, color.NRGBA{R: 200, A: 255})
+
+	cats := &servicemock.SheltersCatService{}
+	cats.On("Get", context.Background(), catID).Return(&model.Cat{ID: catID}, nil)
+	rps := &mocks.PhotoRepository{}
+	rps.On("ListPhotos", context.Background(), catID).Return([]*model.Photo{}, nil)
+	rps.On("AddPhoto", context.Background(), catID, mock.Anything, MaxPhotos).Return(nil)
+	images := make(map[string][]byte)
+	blobs := &mocks.BlobStore{}
+	blobs.On("Put", context.Background(), mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		data, err := io.ReadAll(args.Get(2).(io.Reader))
+		require.NoError(t, err)
+		images[args.String(1)] = data
+	})
+
+	cats.On("Touch", context.Background(), catID).Return(nil).Once()
+
+	srv := NewPhotoService(rps, blobs, cats)
+	photo, err := srv.AddPhoto(context.Background(), catID, data)
+	require.NoError(t, err)
+	cats.AssertExpectations(t)
+	require.Equal(t, "image/png", photo.ContentType)
+	require.Equal(t, 1200, photo.Width)
+	require.Len(t, photo.URLs, 3)
+
+	require.Equal(t, data, images[photoKey(catID, photo.ID, model.PhotoOriginal)])
+	thumbnail, err := jpeg.Decode(bytes.NewReader(images[photoKey(catID, photo.ID, model.PhotoThumbnail)]))
+	require.NoError(t, err)
+	require.Equal(t, image.Rect(0, 0, thumbnailSide, thumbnailSide/2), thumbnail.Bounds())
+	medium, err := jpeg.Decode(bytes.NewReader(images[photoKey(catID, photo.ID, model.PhotoMedium)]))
+	require.NoError(t, err)
+	require.Equal(t, image.Rect(0, 0, mediumSide, mediumSide/2), medium.Bounds())
+}
+
+func TestPhotoService_AddPhotoInvalid(t *testing.T) {
+	srv := NewPhotoService(&mocks.PhotoRepository{}, &mocks.BlobStore{}, &servicemock.SheltersCatService{})
+	_, err := srv.AddPhoto(context.Background(), uuid.New(), []byte("GIF89a not a photo"))
+	require.ErrorIs(t, err, model.ErrInvalid)
+}
+
+func TestPhotoService_AddPhotoLimit(t *testing.T) {
+	catID := uuid.New()
+
+	cats := &servicemock.SheltersCatService{}
+	cats.On("Get", context.Background(), catID).Return(&model.Cat{ID: catID}, nil)
+	rps := &mocks.PhotoRepository{}
+	rps.On("ListPhotos", context.Background(), catID).Return(make([]*model.Photo, MaxPhotos), nil)
+
+	srv := NewPhotoService(rps, &mocks.BlobStore{}, cats)
+	_, err := srv.AddPhoto(context.Background(), catID, testPNG(t, 10, 10, color.White))
+	require.ErrorIs(t, err, model.ErrConflict)
+}
+
+func TestPhotoService_Image(t *testing.T) {
+	catID := uuid.New()
+	photo := &model.Photo{ID: uuid.New(), ContentType: "image/png"}
+
+	rps := &mocks.PhotoRepository{}
+	rps.On("ListPhotos", context.Background(), catID).Return([]*model.Photo{photo}, nil)
+	blobs := &mocks.BlobStore{}
+	blobs.On("Get", context.Background(), photoKey(catID, photo.ID, model.PhotoOriginal)).Return(io.NopCloser(bytes.NewReader(nil)), nil)
+	blobs.On("Get", context.Background(), photoKey(catID, photo.ID, model.PhotoThumbnail)).Return(io.NopCloser(bytes.NewReader(nil)), nil)
+
+	cats := &servicemock.SheltersCatService{}
+	cats.On("Get", context.Background(), catID).Return(&model.Cat{ID: catID}, nil)
+
+	srv := NewPhotoService(rps, blobs, cats)
+	_, contentType, err := srv.Image(context.Background(), catID, photo.ID, model.PhotoOriginal)
+	require.NoError(t, err)
+	require.Equal(t, "image/png", contentType)
+	_, contentType, err = srv.Image(context.Background(), catID, photo.ID, model.PhotoThumbnail)
+	require.NoError(t, err)
+	require.Equal(t, "image/jpeg", contentType)
+
+	_, _, err = srv.Image(context.Background(), catID, uuid.New(), model.PhotoMedium)
+	require.ErrorIs(t, err, model.ErrPhotoNotFound)
+	_, _, err = srv.Image(context.Background(), catID, photo.ID, "huge")
+	require.ErrorIs(t, err, model.ErrInvalid)
+}
+
+func TestPhotoService_DeletedCat(t *testing.T) {
+	catID, photoID := uuid.New(), uuid.New()
+	cats := &servicemock.SheltersCatService{}
+	cats.On("Get", context.Background(), catID).Return(nil, model.ErrCatNotFound)
+
+	srv := NewPhotoService(&mocks.PhotoRepository{}, &mocks.BlobStore{}, cats)
+	_, err := srv.Photos(context.Background(), catID)
+	require.ErrorIs(t, err, model.ErrCatNotFound)
+	_, _, err = srv.Image(context.Background(), catID, photoID, model.PhotoThumbnail)
+	require.ErrorIs(t, err, model.ErrCatNotFound)
+	require.ErrorIs(t, srv.DeletePhoto(context.Background(), catID, photoID), model.ErrCatNotFound)
+}
+
+func TestResizeImage(t *testing.T) {
+	img, err := png.Decode(bytes.NewReader(testPNG(t, 30, 90, color.NRGBA{})))
+	require.NoError(t, err)
+
+	resized := resizeImage(img, 10)
+	require.Equal(t, image.Rect(0, 0, 3, 10), resized.Bounds())
+	// transparent pixels become white
+	require.Equal(t, color.RGBA{R: 255, G: 255, B: 255, A: 255}, resized.At(1, 1))
+
+	require.Equal(t, img.Bounds(), resizeImage(img, 100).Bounds())
+}
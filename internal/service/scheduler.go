@@ -0,0 +1,222 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/catService/internal/model"
+	"github.com/catService/internal/repository"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// Scheduler settings
+const (
+	// jobHistorySize is the number of the latest runs kept for each job
+	jobHistorySize = 20
+	// jobLockTTL keeps the lock of one scheduled run long enough for the replicas with a late clock
+	jobLockTTL = time.Hour
+)
+
+// JobFunc does the work of the scheduled job and returns a short summary of the result
+type JobFunc func(ctx context.Context) (string, error)
+
+// JobsService shows the scheduled jobs
+//go:generate mockery --dir . --name JobsService --output ./service_mock
+type JobsService interface {
+	Jobs(ctx context.Context) ([]*model.JobStatus, error)
+}
+
+// scheduledJob is the job with its schedule and the state of this replica
+type scheduledJob struct {
+	name     string
+	spec     string
+	schedule *Schedule
+	run      JobFunc
+	next     time.Time
+	running  bool
+}
+
+// Scheduler runs jobs on cron schedules. Every replica runs the same scheduler,
+// the lock of the scheduled run in the job storage lets only one of them do the work
+type Scheduler struct {
+	rps   repository.JobRepository
+	owner string
+	mutex sync.Mutex
+	jobs  []*scheduledJob
+	wg    sync.WaitGroup
+}
+
+// NewScheduler create new instance, owner names the replica in the run history
+func NewScheduler(rps repository.JobRepository, owner string) *Scheduler {
+	return &Scheduler{
+		rps:   rps,
+		owner: owner,
+	}
+}
+
+// Add registers the job with the cron schedule, see ParseSchedule. Jobs must be added before Start
+func (s *Scheduler) Add(name, spec string, run JobFunc) error {
+	schedule, err := ParseSchedule(spec)
+	if err != nil {
+		return fmt.Errorf("add job %s: %w", name, err)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for _, job := range s.jobs {
+		if job.name == name {
+			return fmt.Errorf("add job %s: %w: job already exists", name, model.ErrConflict)
+		}
+	}
+	s.jobs = append(s.jobs, &scheduledJob{name: name, spec: spec, schedule: schedule, run: run})
+
+	return nil
+}
+
+// Start runs the jobs in the background until the context is done, the running jobs get the canceled context
+func (s *Scheduler) Start(ctx context.Context) {
+	s.mutex.Lock()
+	now := time.Now()
+	for _, job := range s.jobs {
+		job.next = job.schedule.Next(now)
+	}
+	s.mutex.Unlock()
+
+	go func() {
+		for {
+			next, ok := s.nextRun()
+			if !ok {
+				logrus.Infof("no scheduled jobs")
+				return
+			}
+
+			timer := time.NewTimer(time.Until(next))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+			s.runDue(ctx, time.Now())
+		}
+	}()
+}
+
+// Wait blocks until the running jobs finish
+func (s *Scheduler) Wait() {
+	s.wg.Wait()
+}
+
+// nextRun returns the earliest time one of the jobs must run at
+func (s *Scheduler) nextRun() (time.Time, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var next time.Time
+	for _, job := range s.jobs {
+		if !job.next.IsZero() && (next.IsZero() || job.next.Before(next)) {
+			next = job.next
+		}
+	}
+
+	return next, !next.IsZero()
+}
+
+// runDue starts the jobs which must have run by now and schedules their next runs
+func (s *Scheduler) runDue(ctx context.Context, now time.Time) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, job := range s.jobs {
+		if job.next.IsZero() || job.next.After(now) {
+			continue
+		}
+		scheduledAt := job.next
+		job.next = job.schedule.Next(now)
+		if job.running {
+			logrus.Warnf("job %s scheduled at %s skipped, the previous run isn't finished", job.name, scheduledAt)
+			continue
+		}
+
+		job.running = true
+		s.wg.Add(1)
+		go func(job *scheduledJob) {
+			defer s.wg.Done()
+			s.run(ctx, job, scheduledAt)
+			s.mutex.Lock()
+			job.running = false
+			s.mutex.Unlock()
+		}(job)
+	}
+}
+
+// run takes the lock of the scheduled run and runs the job if no other replica did it
+func (s *Scheduler) run(ctx context.Context, job *scheduledJob, scheduledAt time.Time) {
+	key := fmt.Sprintf("%s:%d", job.name, scheduledAt.Unix())
+	locked, err := s.rps.Lock(ctx, key, s.owner, jobLockTTL)
+	if err != nil {
+		logrus.Errorf("job %s lock error %s", job.name, err)
+		return
+	}
+	if !locked {
+		logrus.Debugf("job %s scheduled at %s runs on another replica", job.name, scheduledAt)
+		return
+	}
+
+	run := &model.JobRun{
+		ID:          uuid.New(),
+		Job:         job.name,
+		Owner:       s.owner,
+		ScheduledAt: scheduledAt,
+		StartedAt:   time.Now().UTC(),
+		Status:      model.JobSucceeded,
+	}
+	result, err := job.run(ctx)
+	run.FinishedAt = time.Now().UTC()
+	run.Result = result
+	if err != nil {
+		run.Status = model.JobFailed
+		run.Error = err.Error()
+		logrus.Errorf("job %s failed: %s", job.name, err)
+	} else {
+		logrus.Infof("job %s finished: %s", job.name, result)
+	}
+
+	// the run is saved even if the job was canceled on shutdown
+	if err := s.rps.AddRun(context.Background(), run, jobHistorySize); err != nil {
+		logrus.Errorf("job %s history error %s", job.name, err)
+	}
+}
+
+// Jobs returns the jobs in name order with their latest runs on all replicas
+func (s *Scheduler) Jobs(ctx context.Context) ([]*model.JobStatus, error) {
+	s.mutex.Lock()
+	statuses := make([]*model.JobStatus, 0, len(s.jobs))
+	for _, job := range s.jobs {
+		statuses = append(statuses, &model.JobStatus{
+			Name:     job.name,
+			Schedule: job.spec,
+			NextRun:  job.next,
+			Running:  job.running,
+		})
+	}
+	s.mutex.Unlock()
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Name < statuses[j].Name
+	})
+	for _, status := range statuses {
+		runs, err := s.rps.ListRuns(ctx, status.Name, jobHistorySize)
+		if err != nil {
+			return nil, fmt.Errorf("job %s runs: %w", status.Name, err)
+		}
+		status.Runs = runs
+	}
+
+	return statuses, nil
+}
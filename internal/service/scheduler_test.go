@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/catService/internal/model"
+	mocks "github.com/catService/internal/repository/repository_mock"
+	servicemock "github.com/catService/internal/service/service_mock"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduler_RunDue(t *testing.T) {
+	scheduledAt := time.Date(2022, time.March, 14, 3, 0, 0, 0, time.UTC)
+
+	rps := &mocks.JobRepository{}
+	rps.On("Lock", context.Background(), "purge:1647226800", "replica-1", jobLockTTL).Return(true, nil)
+	rps.On("Lock", context.Background(), "reminders:1647226800", "replica-1", jobLockTTL).Return(false, nil)
+	rps.On("AddRun", context.Background(), mock.MatchedBy(func(run *model.JobRun) bool {
+		return run.Job == "purge" && run.Status == model.JobFailed && run.Error == "db is down" &&
+			run.Owner == "replica-1" && run.ScheduledAt.Equal(scheduledAt)
+	}), jobHistorySize).Return(nil)
+
+	scheduler := NewScheduler(rps, "replica-1")
+	require.NoError(t, scheduler.Add("purge", "0 3 * * *", func(ctx context.Context) (string, error) {
+		return "", errors.New("db is down")
+	}))
+	require.NoError(t, scheduler.Add("reminders", "0 3 * * *", func(ctx context.Context) (string, error) {
+		t.Error("locked job must not run")
+		return "", nil
+	}))
+	require.ErrorIs(t, scheduler.Add("purge", "@daily", nil), model.ErrConflict)
+	require.ErrorIs(t, scheduler.Add("broken", "0 3 *", nil), model.ErrInvalid)
+
+	for _, job := range scheduler.jobs {
+		job.next = scheduledAt
+	}
+	scheduler.runDue(context.Background(), scheduledAt.Add(time.Second))
+	scheduler.wg.Wait()
+	rps.AssertExpectations(t)
+
+	next, ok := scheduler.nextRun()
+	require.True(t, ok)
+	require.Equal(t, scheduledAt.AddDate(0, 0, 1), next)
+}
+
+func TestScheduler_Jobs(t *testing.T) {
+	run := &model.JobRun{ID: uuid.New(), Job: VaccinationReminderJob, Status: model.JobSucceeded, Result: "2 vaccinations due, 0 overdue"}
+
+	rps := &mocks.JobRepository{}
+	rps.On("ListRuns", context.Background(), PurgeJob, jobHistorySize).Return([]*model.JobRun{}, nil)
+	rps.On("ListRuns", context.Background(), VaccinationReminderJob, jobHistorySize).Return([]*model.JobRun{run}, nil)
+
+	scheduler := NewScheduler(rps, "replica-1")
+	require.NoError(t, scheduler.Add(VaccinationReminderJob, "0 8 * * *", nil))
+	require.NoError(t, scheduler.Add(PurgeJob, "0 3 * * *", nil))
+
+	jobs, err := scheduler.Jobs(context.Background())
+	require.NoError(t, err)
+	require.Len(t, jobs, 2)
+	require.Equal(t, PurgeJob, jobs[0].Name)
+	require.Equal(t, "0 8 * * *", jobs[1].Schedule)
+	require.Equal(t, []*model.JobRun{run}, jobs[1].Runs)
+}
+
+func TestVaccinationReminderJob(t *testing.T) {
+	past := time.Now().AddDate(0, 0, -2)
+	soon := time.Now().AddDate(0, 0, 5)
+	due := []*model.VaccinationDue{
+		{CatID: uuid.New(), CatName: "Cat 1", Vaccination: model.Vaccination{Vaccine: model.VaccineRabies, ExpiresAt: &past}},
+		{CatID: uuid.New(), CatName: "Cat 2", Vaccination: model.Vaccination{Vaccine: model.VaccineFVRCP, ExpiresAt: &soon}},
+	}
+
+	cats := &servicemock.SheltersCatService{}
+	cats.On("DueVaccinations", context.Background(), 14*24*time.Hour).Return(due, nil)
+
+	result, err := NewVaccinationReminderJob(cats, 14*24*time.Hour)(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "2 vaccinations due, 1 overdue", result)
+}
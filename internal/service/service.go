@@ -0,0 +1,845 @@
+// Package service ...
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/catService/internal/model"
+	"github.com/catService/internal/repository"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// SheltersCatService contains business logic for cats
+//go:generate mockery --dir . --name SheltersCatService --output ./service_mock
+type SheltersCatService interface {
+	Get(context.Context, uuid.UUID) (*model.Cat, error)
+	GetByMicrochip(ctx context.Context, chip string) (*model.Cat, error)
+	WithPhotos(ctx context.Context, cat *model.Cat) (*model.Cat, error)
+	Create(context.Context, *model.Cat) error
+	Update(context.Context, *model.Cat) error
+	Delete(ctx context.Context, id uuid.UUID, version int64) error
+	Patch(ctx context.Context, id uuid.UUID, version int64, patch *model.CatPatch) (*model.Cat, error)
+	ApplyPatch(ctx context.Context, id uuid.UUID, version int64, format string, document []byte) (*model.Cat, error)
+	List(context.Context, *model.CatQuery) (*model.CatPage, error)
+	Restore(ctx context.Context, id uuid.UUID, version int64) (*model.Cat, error)
+	Purge(ctx context.Context, retention time.Duration) (int64, error)
+	History(ctx context.Context, id uuid.UUID, limit int, cursor string) (*model.CatEventPage, error)
+	ValidateImport(context.Context, []*model.Cat) error
+	Import(context.Context, []*model.Cat) (int, error)
+	Export(ctx context.Context, filter *model.CatFilter, fn func(*model.Cat) error) error
+	Stats(ctx context.Context, filter *model.StatsFilter) (*model.CatStats, error)
+	Transition(ctx context.Context, id uuid.UUID, version int64, transition string) (*model.Cat, error)
+	Vaccinations(ctx context.Context, id uuid.UUID) ([]*model.Vaccination, error)
+	DueVaccinations(ctx context.Context, within time.Duration) ([]*model.VaccinationDue, error)
+	AddVaccination(ctx context.Context, id uuid.UUID, vaccination *model.Vaccination) error
+	DeleteVaccination(ctx context.Context, id, vaccinationID uuid.UUID) error
+	SyncVaccinated(ctx context.Context) (int, error)
+	Touch(ctx context.Context, id uuid.UUID) error
+}
+
+// Page size limits of the cat list
+const (
+	DefaultListLimit = 20
+	MaxListLimit     = 100
+)
+
+// MaxImportRows limits the number of cats in one import
+const MaxImportRows = 10000
+
+// importBatchSize is the number of cats saved with one storage request
+const importBatchSize = 500
+
+// microchipFormats describes the accepted microchip numbers in validation errors
+const microchipFormats = "9-digit, 10-character hexadecimal or 15-digit ISO 11784 number"
+
+// changeAttempts limits retries of the change which lost the race to a concurrent change
+const changeAttempts = 3
+
+// CatService contains links to the storages and the cache
+type CatService struct {
+	rps          repository.SheltersCatRepository
+	events       repository.CatEventRepository
+	shelters     repository.ShelterRepository
+	vaccinations repository.VaccinationRepository
+	photos       repository.PhotoRepository
+	blobs        repository.BlobStore
+	cache        repository.RedisRepository
+}
+
+// NewService create new instance
+func NewService(rps repository.SheltersCatRepository, events repository.CatEventRepository,
+	shelters repository.ShelterRepository, vaccinations repository.VaccinationRepository,
+	photos repository.PhotoRepository, blobs repository.BlobStore, cache repository.RedisRepository) *CatService {
+	return &CatService{
+		rps:          rps,
+		events:       events,
+		shelters:     shelters,
+		vaccinations: vaccinations,
+		photos:       photos,
+		blobs:        blobs,
+		cache:        cache,
+	}
+}
+
+// Get returns cat from the cache or from the storage, the photos aren't filled, see WithPhotos
+func (s *CatService) Get(ctx context.Context, id uuid.UUID) (*model.Cat, error) {
+	cat, err := s.cache.Get(id)
+	if err == nil {
+		cached := *cat
+		cat = &cached
+	} else if cat, err = s.rps.Get(ctx, id); err != nil {
+		return nil, fmt.Errorf("get cat %s: %w", id, err)
+	}
+	cat.DeriveAge(time.Now())
+
+	return cat, nil
+}
+
+// GetByMicrochip returns cat with the microchip number from the cache or from the storage like Get.
+// The number is normalized, see model.NormalizeMicrochip
+func (s *CatService) GetByMicrochip(ctx context.Context, chip string) (*model.Cat, error) {
+	chip, ok := model.NormalizeMicrochip(chip)
+	if !ok {
+		return nil, fmt.Errorf("%w: microchip must be a %s", model.ErrInvalid, microchipFormats)
+	}
+
+	cat, err := s.cache.GetByMicrochip(chip)
+	if err == nil {
+		cached := *cat
+		cat = &cached
+	} else if cat, err = s.rps.GetByMicrochip(ctx, chip); err != nil {
+		return nil, fmt.Errorf("get cat by microchip %s: %w", chip, err)
+	}
+	cat.DeriveAge(time.Now())
+
+	return cat, nil
+}
+
+// WithPhotos fills the photos of the cat. They are read from the storage,
+// so only the endpoints which show the cat call it, not every Get
+func (s *CatService) WithPhotos(ctx context.Context, cat *model.Cat) (*model.Cat, error) {
+	var err error
+	cat.Photos, err = s.photos.ListPhotos(ctx, cat.ID)
+	if err != nil {
+		return nil, fmt.Errorf("get cat %s photos: %w", cat.ID, err)
+	}
+	for _, photo := range cat.Photos {
+		fillPhotoURLs(cat.ID, photo)
+	}
+
+	return cat, nil
+}
+
+// Create validates and saves new cat
+func (s *CatService) Create(ctx context.Context, cat *model.Cat) error {
+	prepareCat(cat, time.Now())
+	if err := validateCat(cat); err != nil {
+		return err
+	}
+	if err := s.checkShelter(ctx, cat.ShelterID); err != nil {
+		return fmt.Errorf("create cat: %w", err)
+	}
+
+	cat.ID = uuid.New()
+	cat.Status = model.CatAvailable
+	cat.Version = 1
+	if err := s.rps.Create(ctx, cat); err != nil {
+		return fmt.Errorf("create cat: %w", err)
+	}
+	s.record(ctx, cat.ID, model.CatEventCreate, nil, cat)
+
+	return nil
+}
+
+// ValidateImport prepares and checks the cats of an import without saving them.
+// Every invalid cat is reported in model.ImportError, other errors stop the validation
+func (s *CatService) ValidateImport(ctx context.Context, cats []*model.Cat) error {
+	invalid := make(map[int]error)
+	shelters := make(map[uuid.UUID]error)
+	microchips := make(map[string]bool)
+	now := time.Now()
+	for i, cat := range cats {
+		prepareCat(cat, now)
+		if err := validateCat(cat); err != nil {
+			invalid[i] = err
+			continue
+		}
+		if cat.ShelterID != nil {
+			err, checked := shelters[*cat.ShelterID]
+			if !checked {
+				err = s.checkShelter(ctx, cat.ShelterID)
+				if err != nil && !errors.Is(err, model.ErrInvalid) {
+					return fmt.Errorf("validate import: %w", err)
+				}
+				shelters[*cat.ShelterID] = err
+			}
+			if err != nil {
+				invalid[i] = err
+				continue
+			}
+		}
+		if cat.Microchip != nil {
+			if microchips[*cat.Microchip] {
+				invalid[i] = fmt.Errorf("%w: microchip repeats an earlier cat of the import", model.ErrInvalid)
+				continue
+			}
+			microchips[*cat.Microchip] = true
+			_, err := s.rps.GetByMicrochip(ctx, *cat.Microchip)
+			if err == nil {
+				invalid[i] = fmt.Errorf("%w: microchip is already registered", model.ErrInvalid)
+				continue
+			}
+			if !errors.Is(err, model.ErrCatNotFound) {
+				return fmt.Errorf("validate import: %w", err)
+			}
+		}
+	}
+	if len(invalid) > 0 {
+		return &model.ImportError{Cats: invalid}
+	}
+
+	return nil
+}
+
+// Import validates and saves new cats in batches, returns the number of saved cats.
+// Nothing is saved if any cat is invalid, cats of the batches saved before an error stay in the storage
+func (s *CatService) Import(ctx context.Context, cats []*model.Cat) (int, error) {
+	if len(cats) > MaxImportRows {
+		return 0, fmt.Errorf("%w: import is limited to %d cats", model.ErrInvalid, MaxImportRows)
+	}
+	if err := s.ValidateImport(ctx, cats); err != nil {
+		return 0, err
+	}
+	for _, cat := range cats {
+		cat.ID = uuid.New()
+		cat.Status = model.CatAvailable
+		cat.Version = 1
+	}
+
+	imported := 0
+	for start := 0; start < len(cats); start += importBatchSize {
+		end := start + importBatchSize
+		if end > len(cats) {
+			end = len(cats)
+		}
+		batch := cats[start:end]
+		if err := s.rps.CreateMany(ctx, batch); err != nil {
+			return imported, fmt.Errorf("import cats: %w", err)
+		}
+		imported += len(batch)
+
+		for _, cat := range batch {
+			s.record(ctx, cat.ID, model.CatEventCreate, nil, cat)
+		}
+	}
+
+	return imported, nil
+}
+
+// Update validates and saves cat states if cat.Version matches the stored one
+func (s *CatService) Update(ctx context.Context, cat *model.Cat) error {
+	prepareCat(cat, time.Now())
+	if err := validateCat(cat); err != nil {
+		return err
+	}
+	if err := s.checkShelter(ctx, cat.ShelterID); err != nil {
+		return fmt.Errorf("update cat %s: %w", cat.ID, err)
+	}
+
+	err := s.withStored(ctx, cat.ID, cat.Version, func(stored *model.Cat) error {
+		// the status is changed by adoption transitions only
+		cat.Status = stored.Status
+		cat.Version = stored.Version
+		vaccinated, err := s.vaccinated(ctx, cat.ID, cat.Vaccinated)
+		if err != nil {
+			return err
+		}
+		cat.Vaccinated = vaccinated
+		if err := s.rps.Update(ctx, cat); err != nil {
+			return err
+		}
+		s.record(ctx, cat.ID, model.CatEventUpdate, stored, cat)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("update cat %s: %w", cat.ID, err)
+	}
+
+	return nil
+}
+
+// Patch changes only the given fields of the stored cat and returns the result
+func (s *CatService) Patch(ctx context.Context, id uuid.UUID, version int64, patch *model.CatPatch) (*model.Cat, error) {
+	var patched *model.Cat
+	err := s.withStored(ctx, id, version, func(stored *model.Cat) error {
+		var err error
+		patched, err = s.patchStored(ctx, stored, patch)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("patch cat %s: %w", id, err)
+	}
+
+	return patched, nil
+}
+
+// patchStored validates and saves the patch of the stored cat, it's called inside withStored
+func (s *CatService) patchStored(ctx context.Context, stored *model.Cat, patch *model.CatPatch) (*model.Cat, error) {
+	if patch.Empty() {
+		return stored, nil
+	}
+
+	changed := *patch
+	if changed.Vaccinated != nil {
+		vaccinated, err := s.vaccinated(ctx, stored.ID, *changed.Vaccinated)
+		if err != nil {
+			return nil, err
+		}
+		changed.Vaccinated = &vaccinated
+	}
+	cat := *stored
+	changed.Apply(&cat)
+	prepareCat(&cat, time.Now())
+	if err := validateCat(&cat); err != nil {
+		return nil, err
+	}
+	// the age of the cat with birth date follows it
+	changed.Age = nil
+	if cat.Age != stored.Age {
+		changed.Age = &cat.Age
+	}
+	if changed.Profile != nil {
+		changed.Profile = &cat.CatProfile
+	}
+	if changed.ShelterID != nil {
+		if err := s.checkShelter(ctx, cat.ShelterID); err != nil {
+			return nil, err
+		}
+	}
+	newVersion, err := s.rps.Patch(ctx, stored.ID, stored.Version, &changed)
+	if err != nil {
+		return nil, err
+	}
+	cat.Version = newVersion
+	s.record(ctx, stored.ID, model.CatEventUpdate, stored, &cat)
+
+	return &cat, nil
+}
+
+// Transition moves the cat through the adoption lifecycle.
+// Transitions which are not allowed from the current status return model.ErrConflict
+func (s *CatService) Transition(ctx context.Context, id uuid.UUID, version int64, transition string) (*model.Cat, error) {
+	if !model.IsTransition(transition) {
+		return nil, fmt.Errorf("%w: unknown transition %q", model.ErrInvalid, transition)
+	}
+
+	var changed *model.Cat
+	err := s.withStored(ctx, id, version, func(stored *model.Cat) error {
+		status := model.NextStatus(stored.Status, transition)
+		if status == "" {
+			return fmt.Errorf("%w: can't %s %s cat", model.ErrConflict, transition, model.StatusOrDefault(stored.Status))
+		}
+
+		cat := *stored
+		cat.Status = status
+		newVersion, err := s.rps.Patch(ctx, id, stored.Version, &model.CatPatch{Status: &status})
+		if err != nil {
+			return err
+		}
+		cat.Version = newVersion
+		s.record(ctx, id, transition, stored, &cat)
+		changed = &cat
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s cat %s: %w", transition, id, err)
+	}
+
+	return changed, nil
+}
+
+// Delete marks cat as deleted if version matches the stored one
+func (s *CatService) Delete(ctx context.Context, id uuid.UUID, version int64) error {
+	err := s.withStored(ctx, id, version, func(stored *model.Cat) error {
+		if err := s.rps.Delete(ctx, id, stored.Version); err != nil {
+			return err
+		}
+		s.record(ctx, id, model.CatEventDelete, stored, nil)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("delete cat %s: %w", id, err)
+	}
+
+	return nil
+}
+
+// Restore returns deleted cat back
+func (s *CatService) Restore(ctx context.Context, id uuid.UUID, version int64) (*model.Cat, error) {
+	cat, err := s.rps.Restore(ctx, id, version)
+	if err != nil {
+		return nil, fmt.Errorf("restore cat %s: %w", id, err)
+	}
+	s.record(ctx, id, model.CatEventRestore, nil, cat)
+
+	return cat, nil
+}
+
+// Purge permanently removes cats deleted longer than retention ago with their photo images.
+// Deleted cats were already removed from the cache by Delete
+func (s *CatService) Purge(ctx context.Context, retention time.Duration) (int64, error) {
+	if retention <= 0 {
+		return 0, fmt.Errorf("%w: retention must be positive", model.ErrInvalid)
+	}
+
+	purged, err := s.rps.Purge(ctx, time.Now().Add(-retention))
+	// the photo records go with the cats, the images are left in the blob store
+	for _, id := range purged {
+		if err := s.blobs.DeletePrefix(ctx, catBlobsPrefix(id)); err != nil {
+			logrus.Errorf("delete images of purged cat %s: %v", id, err)
+		}
+	}
+	if err != nil {
+		return 0, fmt.Errorf("purge cats: %w", err)
+	}
+	logrus.Infof("%d deleted cats purged", len(purged))
+
+	return int64(len(purged)), nil
+}
+
+// Touch increments the version of the cat whose photos changed, so the cached copies are replaced
+// and the clients see the new ETag
+func (s *CatService) Touch(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.rps.Patch(ctx, id, model.AnyVersion, &model.CatPatch{}); err != nil {
+		return fmt.Errorf("touch cat %s: %w", id, err)
+	}
+
+	return nil
+}
+
+// List returns a page of cats matching the query
+func (s *CatService) List(ctx context.Context, query *model.CatQuery) (*model.CatPage, error) {
+	if err := validateQuery(query); err != nil {
+		return nil, err
+	}
+	after, err := decodeCursor(query)
+	if err != nil {
+		return nil, err
+	}
+
+	// one extra cat shows whether there is a next page
+	extended := *query
+	extended.Limit++
+	cats, err := s.rps.List(ctx, &extended, after)
+	if err != nil {
+		return nil, fmt.Errorf("list cats: %w", err)
+	}
+
+	page := &model.CatPage{Cats: cats}
+	if len(cats) > query.Limit {
+		page.Cats = cats[:query.Limit]
+		page.NextCursor, err = encodeCursor(query, page.Cats[query.Limit-1])
+		if err != nil {
+			return nil, fmt.Errorf("list cats: %w", err)
+		}
+	}
+	// the cursor keeps the stored age the cats are sorted by
+	now := time.Now()
+	for _, cat := range page.Cats {
+		cat.DeriveAge(now)
+	}
+
+	return page, nil
+}
+
+// Export passes all cats matching the filter to fn without loading them into memory
+func (s *CatService) Export(ctx context.Context, filter *model.CatFilter, fn func(*model.Cat) error) error {
+	if err := validateFilter(filter); err != nil {
+		return err
+	}
+	now := time.Now()
+	err := s.rps.Export(ctx, filter, func(cat *model.Cat) error {
+		cat.DeriveAge(now)
+		return fn(cat)
+	})
+	if err != nil {
+		return fmt.Errorf("export cats: %w", err)
+	}
+
+	return nil
+}
+
+// Stats counts cats and their finished stays. Shares and lengths are rounded,
+// so both storages return the same numbers despite the different time precision
+func (s *CatService) Stats(ctx context.Context, filter *model.StatsFilter) (*model.CatStats, error) {
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return nil, fmt.Errorf("%w: from must be before to", model.ErrInvalid)
+	}
+	if filter.ShelterID != nil {
+		if _, err := s.shelters.Get(ctx, *filter.ShelterID); err != nil {
+			return nil, fmt.Errorf("cat stats: %w", err)
+		}
+	}
+
+	stats, err := s.rps.Stats(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("cat stats: %w", err)
+	}
+	if stats.Total > 0 {
+		stats.VaccinatedRatio = roundTo(float64(stats.Vaccinated)/float64(stats.Total), 4)
+	}
+	stats.AverageStayDays = roundTo(stats.AverageStayDays, 2)
+	stats.MedianStayDays = roundTo(stats.MedianStayDays, 2)
+
+	return stats, nil
+}
+
+// roundTo rounds the value to the number of decimal places
+func roundTo(value float64, places int) float64 {
+	scale := math.Pow(10, float64(places))
+
+	return math.Round(value*scale) / scale
+}
+
+// History returns a page of the cat changes in chronological order
+func (s *CatService) History(ctx context.Context, id uuid.UUID, limit int, cursor string) (*model.CatEventPage, error) {
+	switch {
+	case limit == 0:
+		limit = DefaultListLimit
+	case limit < 0 || limit > MaxListLimit:
+		return nil, fmt.Errorf("%w: limit must be between 1 and %d", model.ErrInvalid, MaxListLimit)
+	}
+	after, err := decodeEventCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	// one extra event shows whether there is a next page
+	events, err := s.events.ListEvents(ctx, id, after, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("cat %s history: %w", id, err)
+	}
+
+	page := &model.CatEventPage{Events: events}
+	if len(events) > limit {
+		page.Events = events[:limit]
+		page.NextCursor, err = encodeEventCursor(page.Events[limit-1])
+		if err != nil {
+			return nil, fmt.Errorf("cat %s history: %w", id, err)
+		}
+	}
+
+	return page, nil
+}
+
+// Vaccinations returns vaccination records of the cat in the order they were administered
+func (s *CatService) Vaccinations(ctx context.Context, id uuid.UUID) ([]*model.Vaccination, error) {
+	if _, err := s.rps.Get(ctx, id); err != nil {
+		return nil, fmt.Errorf("cat %s vaccinations: %w", id, err)
+	}
+	vaccinations, err := s.vaccinations.ListVaccinations(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("cat %s vaccinations: %w", id, err)
+	}
+
+	return vaccinations, nil
+}
+
+// DueVaccinations returns the vaccinations of the cats in care which expire within the given time
+// or have already expired and weren't repeated
+func (s *CatService) DueVaccinations(ctx context.Context, within time.Duration) ([]*model.VaccinationDue, error) {
+	if within < 0 {
+		return nil, fmt.Errorf("%w: due window must not be negative", model.ErrInvalid)
+	}
+	due, err := s.vaccinations.DueVaccinations(ctx, time.Now().Add(within))
+	if err != nil {
+		return nil, fmt.Errorf("due vaccinations: %w", err)
+	}
+
+	return due, nil
+}
+
+// AddVaccination validates and saves new vaccination record of the cat, then updates the vaccinated flag
+func (s *CatService) AddVaccination(ctx context.Context, id uuid.UUID, vaccination *model.Vaccination) error {
+	if err := validateVaccination(vaccination); err != nil {
+		return err
+	}
+	if _, err := s.rps.Get(ctx, id); err != nil {
+		return fmt.Errorf("add cat %s vaccination: %w", id, err)
+	}
+
+	vaccination.ID = uuid.New()
+	if err := s.vaccinations.AddVaccination(ctx, id, vaccination); err != nil {
+		return fmt.Errorf("add cat %s vaccination: %w", id, err)
+	}
+	if err := s.syncVaccinated(ctx, id); err != nil {
+		return fmt.Errorf("add cat %s vaccination: %w", id, err)
+	}
+
+	return nil
+}
+
+// DeleteVaccination removes vaccination record of the cat, then updates the vaccinated flag
+func (s *CatService) DeleteVaccination(ctx context.Context, id, vaccinationID uuid.UUID) error {
+	if _, err := s.rps.Get(ctx, id); err != nil {
+		return fmt.Errorf("delete cat %s vaccination: %w", id, err)
+	}
+	if err := s.vaccinations.DeleteVaccination(ctx, id, vaccinationID); err != nil {
+		return fmt.Errorf("delete cat %s vaccination: %w", id, err)
+	}
+	if err := s.syncVaccinated(ctx, id); err != nil {
+		return fmt.Errorf("delete cat %s vaccination: %w", id, err)
+	}
+
+	return nil
+}
+
+// SyncVaccinated clears the vaccinated flag of the cats whose vaccinations have expired since
+// the flag was stored and returns their number. Cats without vaccination records keep the flag
+func (s *CatService) SyncVaccinated(ctx context.Context) (int, error) {
+	vaccinated := true
+	var ids []uuid.UUID
+	err := s.rps.Export(ctx, &model.CatFilter{Vaccinated: &vaccinated}, func(cat *model.Cat) error {
+		ids = append(ids, cat.ID)
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("sync vaccinated cats: %w", err)
+	}
+
+	synced := 0
+	for _, id := range ids {
+		upToDate, err := s.vaccinated(ctx, id, true)
+		if err != nil {
+			return synced, fmt.Errorf("sync cat %s vaccinated: %w", id, err)
+		}
+		if upToDate {
+			continue
+		}
+		err = s.syncVaccinated(ctx, id)
+		if errors.Is(err, model.ErrCatNotFound) {
+			continue
+		}
+		if err != nil {
+			return synced, fmt.Errorf("sync cat %s vaccinated: %w", id, err)
+		}
+		synced++
+	}
+
+	return synced, nil
+}
+
+// vaccinated returns the vaccinated flag derived from the cat vaccination records.
+// Cats without records keep the given flag, as it was set before the records existed
+func (s *CatService) vaccinated(ctx context.Context, id uuid.UUID, vaccinated bool) (bool, error) {
+	vaccinations, err := s.vaccinations.ListVaccinations(ctx, id)
+	if err != nil {
+		return false, err
+	}
+	if len(vaccinations) == 0 {
+		return vaccinated, nil
+	}
+
+	return model.UpToDate(vaccinations, time.Now()), nil
+}
+
+// syncVaccinated stores the vaccinated flag derived from the changed vaccination records.
+// The flag of the cat without records left is cleared
+func (s *CatService) syncVaccinated(ctx context.Context, id uuid.UUID) error {
+	return s.withStored(ctx, id, model.AnyVersion, func(stored *model.Cat) error {
+		vaccinated, err := s.vaccinated(ctx, id, false)
+		if err != nil || vaccinated == stored.Vaccinated {
+			return err
+		}
+
+		cat := *stored
+		cat.Vaccinated = vaccinated
+		newVersion, err := s.rps.Patch(ctx, id, stored.Version, &model.CatPatch{Vaccinated: &vaccinated})
+		if err != nil {
+			return err
+		}
+		cat.Version = newVersion
+		s.record(ctx, id, model.CatEventUpdate, stored, &cat)
+		return nil
+	})
+}
+
+// withStored runs the change against the actual state of the cat. When the caller doesn't expect
+// a particular version, the change is retried if it lost the race to a concurrent change
+func (s *CatService) withStored(ctx context.Context, id uuid.UUID, version int64, change func(stored *model.Cat) error) error {
+	for attempt := 1; ; attempt++ {
+		stored, err := s.rps.Get(ctx, id)
+		if err != nil {
+			return err
+		}
+		if version != model.AnyVersion && version != stored.Version {
+			return model.ErrVersionMismatch
+		}
+
+		err = change(stored)
+		if errors.Is(err, model.ErrVersionMismatch) && version == model.AnyVersion && attempt < changeAttempts {
+			continue
+		}
+		return err
+	}
+}
+
+// checkShelter verifies the cat is assigned to an existing shelter, nil ID means no shelter
+func (s *CatService) checkShelter(ctx context.Context, id *uuid.UUID) error {
+	if id == nil {
+		return nil
+	}
+	_, err := s.shelters.Get(ctx, *id)
+	if errors.Is(err, model.ErrShelterNotFound) {
+		return fmt.Errorf("%w: shelter %s not found", model.ErrInvalid, id)
+	}
+
+	return err
+}
+
+// record saves the change into the cat history.
+// The change is already stored, so the error is only logged
+func (s *CatService) record(ctx context.Context, id uuid.UUID, action string, before, after *model.Cat) {
+	event := &model.CatEvent{
+		ID:     uuid.New(),
+		CatID:  id,
+		Action: action,
+		Before: snapshot(before),
+		After:  snapshot(after),
+		Actor:  ActorFromContext(ctx),
+		// both storages keep milliseconds, so cursors stay exact
+		CreatedAt: time.Now().UTC().Truncate(time.Millisecond),
+	}
+	if err := s.events.AddEvent(ctx, event); err != nil {
+		logrus.Errorf("record %s of cat %s failed: %v", action, id, err)
+	}
+}
+
+// validateQuery checks the query and fills the defaults
+func validateQuery(query *model.CatQuery) error {
+	if query.SortBy == "" {
+		query.SortBy = model.CatSortID
+	}
+	if (&model.Cat{}).SortKey(query.SortBy) == nil {
+		return fmt.Errorf("%w: unknown sort field %q", model.ErrInvalid, query.SortBy)
+	}
+	switch {
+	case query.Limit == 0:
+		query.Limit = DefaultListLimit
+	case query.Limit < 0 || query.Limit > MaxListLimit:
+		return fmt.Errorf("%w: limit must be between 1 and %d", model.ErrInvalid, MaxListLimit)
+	}
+
+	return validateFilter(&query.CatFilter)
+}
+
+// validateFilter checks the filter of cats
+func validateFilter(filter *model.CatFilter) error {
+	switch filter.Deleted {
+	case model.DeletedExclude, model.DeletedInclude, model.DeletedOnly:
+	default:
+		return fmt.Errorf("%w: deleted must be include or only", model.ErrInvalid)
+	}
+	switch filter.Status {
+	case "", model.CatAvailable, model.CatReserved, model.CatAdopted, model.CatReturned:
+	default:
+		return fmt.Errorf("%w: unknown status %q", model.ErrInvalid, filter.Status)
+	}
+	if filter.MinAge != nil && filter.MaxAge != nil && *filter.MinAge > *filter.MaxAge {
+		return fmt.Errorf("%w: min_age is greater than max_age", model.ErrInvalid)
+	}
+
+	return nil
+}
+
+// snapshot returns a copy of the cat state
+func snapshot(cat *model.Cat) *model.Cat {
+	if cat == nil {
+		return nil
+	}
+	copied := *cat
+
+	return &copied
+}
+
+// prepareCat cleans the profile up and derives the age from the birth date
+func prepareCat(cat *model.Cat, now time.Time) {
+	profile := &cat.CatProfile
+	profile.BirthDate = truncateDate(profile.BirthDate)
+	profile.IntakeDate = truncateDate(profile.IntakeDate)
+	profile.Sex = strings.ToLower(strings.TrimSpace(profile.Sex))
+	profile.Breed = strings.TrimSpace(profile.Breed)
+	profile.CoatColor = strings.TrimSpace(profile.CoatColor)
+	if profile.Microchip != nil {
+		// invalid number is kept for validateCat to report
+		microchip, _ := model.NormalizeMicrochip(*profile.Microchip)
+		profile.Microchip = &microchip
+	}
+	cat.DeriveAge(now)
+}
+
+// truncateDate returns the UTC date without time
+func truncateDate(date *time.Time) *time.Time {
+	if date == nil {
+		return nil
+	}
+	year, month, day := date.UTC().Date()
+	truncated := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+
+	return &truncated
+}
+
+func validateCat(cat *model.Cat) error {
+	if strings.TrimSpace(cat.Name) == "" {
+		return fmt.Errorf("%w: name is required", model.ErrInvalid)
+	}
+	if cat.Age < 0 {
+		return fmt.Errorf("%w: age must not be negative", model.ErrInvalid)
+	}
+	switch cat.Sex {
+	case "", model.CatMale, model.CatFemale:
+	default:
+		return fmt.Errorf("%w: sex must be male or female", model.ErrInvalid)
+	}
+	now := time.Now()
+	if cat.BirthDate != nil && cat.BirthDate.After(now) {
+		return fmt.Errorf("%w: birth_date is in the future", model.ErrInvalid)
+	}
+	if cat.IntakeDate != nil && cat.IntakeDate.After(now) {
+		return fmt.Errorf("%w: intake_date is in the future", model.ErrInvalid)
+	}
+	if cat.BirthDate != nil && cat.IntakeDate != nil && cat.IntakeDate.Before(*cat.BirthDate) {
+		return fmt.Errorf("%w: intake_date is before birth_date", model.ErrInvalid)
+	}
+	if cat.Microchip != nil {
+		if _, ok := model.NormalizeMicrochip(*cat.Microchip); !ok {
+			return fmt.Errorf("%w: microchip must be a %s", model.ErrInvalid, microchipFormats)
+		}
+	}
+
+	return nil
+}
+
+func validateVaccination(vaccination *model.Vaccination) error {
+	if strings.TrimSpace(vaccination.Vaccine) == "" {
+		return fmt.Errorf("%w: vaccine is required", model.ErrInvalid)
+	}
+	if vaccination.AdministeredAt.IsZero() {
+		return fmt.Errorf("%w: administered_at is required", model.ErrInvalid)
+	}
+	if vaccination.AdministeredAt.After(time.Now()) {
+		return fmt.Errorf("%w: administered_at is in the future", model.ErrInvalid)
+	}
+	if vaccination.ExpiresAt != nil && !vaccination.ExpiresAt.After(vaccination.AdministeredAt) {
+		return fmt.Errorf("%w: expires_at must be after administered_at", model.ErrInvalid)
+	}
+
+	return nil
+}
@@ -0,0 +1,815 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/catService/internal/model"
+	mocks "github.com/catService/internal/repository/repository_mock"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCatService_GetFromCache(t *testing.T) {
+	cat := &model.Cat{ID: uuid.New(), Name: "Cat 1", Age: 2}
+	photo := &model.Photo{ID: uuid.New(), ContentType: "image/png"}
+
+	rps := &mocks.SheltersCatRepository{}
+	cache := &mocks.RedisRepository{}
+	cache.On("Get", cat.ID).Return(cat, nil)
+	photos := &mocks.PhotoRepository{}
+	photos.On("ListPhotos", context.Background(), cat.ID).Return([]*model.Photo{photo}, nil)
+
+	srv := NewService(rps, &mocks.CatEventRepository{}, &mocks.ShelterRepository{}, &mocks.VaccinationRepository{}, photos, &mocks.BlobStore{}, cache)
+	result, err := srv.Get(context.Background(), cat.ID)
+	require.NoError(t, err)
+	require.Equal(t, cat.Name, result.Name)
+	require.Nil(t, result.Photos)
+	photos.AssertNotCalled(t, "ListPhotos", mock.Anything, mock.Anything)
+
+	result, err = srv.WithPhotos(context.Background(), result)
+	require.NoError(t, err)
+	require.Len(t, result.Photos, 1)
+	require.Equal(t, "/v1/cat/"+cat.ID.String()+"/photos/"+photo.ID.String()+"/thumbnail", result.Photos[0].URLs[model.PhotoThumbnail])
+	require.Nil(t, cat.Photos)
+	rps.AssertNotCalled(t, "Get", mock.Anything, mock.Anything)
+}
+
+func TestCatService_GetNotFound(t *testing.T) {
+	id := uuid.New()
+
+	rps := &mocks.SheltersCatRepository{}
+	rps.On("Get", context.Background(), id).Return(nil, model.ErrCatNotFound)
+	cache := &mocks.RedisRepository{}
+	cache.On("Get", id).Return(nil, errors.New("cat don't exist"))
+
+	srv := NewService(rps, &mocks.CatEventRepository{}, &mocks.ShelterRepository{}, &mocks.VaccinationRepository{}, &mocks.PhotoRepository{}, &mocks.BlobStore{}, cache)
+	_, err := srv.Get(context.Background(), id)
+	require.ErrorIs(t, err, model.ErrCatNotFound)
+}
+
+func TestCatService_Create(t *testing.T) {
+	cat := &model.Cat{Name: "Cat 1", Age: 2}
+
+	rps := &mocks.SheltersCatRepository{}
+	rps.On("Create", context.Background(), cat).Return(nil)
+	cache := &mocks.RedisRepository{}
+	events := &mocks.CatEventRepository{}
+	events.On("AddEvent", context.Background(), mock.MatchedBy(func(event *model.CatEvent) bool {
+		return event.Action == model.CatEventCreate && event.Before == nil && event.After.Name == cat.Name
+	})).Return(nil)
+
+	srv := NewService(rps, events, &mocks.ShelterRepository{}, &mocks.VaccinationRepository{}, &mocks.PhotoRepository{}, &mocks.BlobStore{}, cache)
+	err := srv.Create(context.Background(), cat)
+	require.NoError(t, err)
+	require.NotEqual(t, uuid.Nil, cat.ID)
+	events.AssertExpectations(t)
+}
+
+func TestCatService_CreateInvalid(t *testing.T) {
+	srv := NewService(&mocks.SheltersCatRepository{}, &mocks.CatEventRepository{}, &mocks.ShelterRepository{}, &mocks.VaccinationRepository{}, &mocks.PhotoRepository{}, &mocks.BlobStore{}, &mocks.RedisRepository{})
+
+	err := srv.Create(context.Background(), &model.Cat{Name: " ", Age: 2})
+	require.ErrorIs(t, err, model.ErrInvalid)
+
+	err = srv.Create(context.Background(), &model.Cat{Name: "Cat 1", Age: -1})
+	require.ErrorIs(t, err, model.ErrInvalid)
+}
+
+func TestCatService_UpdateNotFound(t *testing.T) {
+	cat := &model.Cat{ID: uuid.New(), Name: "Cat 1", Age: 2}
+
+	rps := &mocks.SheltersCatRepository{}
+	rps.On("Get", context.Background(), cat.ID).Return(nil, model.ErrCatNotFound)
+	cache := &mocks.RedisRepository{}
+
+	srv := NewService(rps, &mocks.CatEventRepository{}, &mocks.ShelterRepository{}, &mocks.VaccinationRepository{}, &mocks.PhotoRepository{}, &mocks.BlobStore{}, cache)
+	err := srv.Update(context.Background(), cat)
+	require.ErrorIs(t, err, model.ErrCatNotFound)
+}
+
+func TestCatService_Delete(t *testing.T) {
+	id := uuid.New()
+
+	rps := &mocks.SheltersCatRepository{}
+	rps.On("Get", context.Background(), id).Return(&model.Cat{ID: id, Name: "Cat 1", Version: 2}, nil)
+	rps.On("Delete", context.Background(), id, int64(2)).Return(nil)
+	cache := &mocks.RedisRepository{}
+	events := &mocks.CatEventRepository{}
+	events.On("AddEvent", context.Background(), mock.MatchedBy(func(event *model.CatEvent) bool {
+		return event.Action == model.CatEventDelete && event.Before.Version == 2 && event.After == nil
+	})).Return(nil)
+
+	srv := NewService(rps, events, &mocks.ShelterRepository{}, &mocks.VaccinationRepository{}, &mocks.PhotoRepository{}, &mocks.BlobStore{}, cache)
+	err := srv.Delete(context.Background(), id, model.AnyVersion)
+	require.NoError(t, err)
+	events.AssertExpectations(t)
+}
+
+func TestCatService_ListPagination(t *testing.T) {
+	cats := []*model.Cat{
+		{ID: uuid.New(), Name: "Cat 1", Age: 1},
+		{ID: uuid.New(), Name: "Cat 2", Age: 2},
+		{ID: uuid.New(), Name: "Cat 3", Age: 3},
+	}
+
+	rps := &mocks.SheltersCatRepository{}
+	rps.On("List", context.Background(), mock.MatchedBy(func(q *model.CatQuery) bool { return q.Limit == 3 }), (*model.Cat)(nil)).
+		Return(cats, nil)
+	rps.On("List", context.Background(), mock.Anything, mock.MatchedBy(func(after *model.Cat) bool {
+		return after != nil && after.ID == cats[1].ID && after.Age == cats[1].Age
+	})).Return(cats[2:], nil)
+
+	srv := NewService(rps, &mocks.CatEventRepository{}, &mocks.ShelterRepository{}, &mocks.VaccinationRepository{}, &mocks.PhotoRepository{}, &mocks.BlobStore{}, &mocks.RedisRepository{})
+	query := &model.CatQuery{SortBy: model.CatSortAge, Limit: 2}
+	page, err := srv.List(context.Background(), query)
+	require.NoError(t, err)
+	require.Equal(t, cats[:2], page.Cats)
+	require.NotEmpty(t, page.NextCursor)
+
+	query.Cursor = page.NextCursor
+	page, err = srv.List(context.Background(), query)
+	require.NoError(t, err)
+	require.Equal(t, cats[2:], page.Cats)
+	require.Empty(t, page.NextCursor)
+}
+
+func TestCatService_ListInvalidQuery(t *testing.T) {
+	minAge, maxAge := 5, 1
+	srv := NewService(&mocks.SheltersCatRepository{}, &mocks.CatEventRepository{}, &mocks.ShelterRepository{}, &mocks.VaccinationRepository{}, &mocks.PhotoRepository{}, &mocks.BlobStore{}, &mocks.RedisRepository{})
+
+	tests := []*model.CatQuery{
+		{SortBy: "color"},
+		{Limit: MaxListLimit + 1},
+		{CatFilter: model.CatFilter{MinAge: &minAge, MaxAge: &maxAge}},
+		{Cursor: "not a cursor"},
+	}
+	for _, query := range tests {
+		_, err := srv.List(context.Background(), query)
+		require.ErrorIs(t, err, model.ErrInvalid)
+	}
+}
+
+func TestCatService_ListCursorSortMismatch(t *testing.T) {
+	query := &model.CatQuery{SortBy: model.CatSortName}
+	cursor, err := encodeCursor(query, &model.Cat{ID: uuid.New(), Name: "Cat 1"})
+	require.NoError(t, err)
+
+	srv := NewService(&mocks.SheltersCatRepository{}, &mocks.CatEventRepository{}, &mocks.ShelterRepository{}, &mocks.VaccinationRepository{}, &mocks.PhotoRepository{}, &mocks.BlobStore{}, &mocks.RedisRepository{})
+	_, err = srv.List(context.Background(), &model.CatQuery{SortBy: model.CatSortAge, Cursor: cursor})
+	require.ErrorIs(t, err, model.ErrInvalid)
+}
+
+func TestCatService_Patch(t *testing.T) {
+	stored := &model.Cat{ID: uuid.New(), Name: "Cat 1", Age: 2, Version: 3}
+	vaccinated := true
+	patch := &model.CatPatch{Vaccinated: &vaccinated}
+
+	rps := &mocks.SheltersCatRepository{}
+	rps.On("Get", context.Background(), stored.ID).Return(stored, nil)
+	rps.On("Patch", context.Background(), stored.ID, int64(3), patch).Return(int64(4), nil)
+	cache := &mocks.RedisRepository{}
+	events := &mocks.CatEventRepository{}
+	events.On("AddEvent", context.Background(), mock.MatchedBy(func(event *model.CatEvent) bool {
+		return event.Action == model.CatEventUpdate && !event.Before.Vaccinated && event.After.Vaccinated
+	})).Return(nil)
+
+	srv := NewService(rps, events, &mocks.ShelterRepository{}, noVaccinations(), &mocks.PhotoRepository{}, &mocks.BlobStore{}, cache)
+	cat, err := srv.Patch(context.Background(), stored.ID, 3, patch)
+	require.NoError(t, err)
+	require.True(t, cat.Vaccinated)
+	require.Equal(t, "Cat 1", cat.Name)
+	require.Equal(t, int64(4), cat.Version)
+	rps.AssertExpectations(t)
+}
+
+func TestCatService_PatchInvalid(t *testing.T) {
+	stored := &model.Cat{ID: uuid.New(), Name: "Cat 1", Age: 2}
+	name := ""
+
+	rps := &mocks.SheltersCatRepository{}
+	rps.On("Get", context.Background(), stored.ID).Return(stored, nil)
+
+	srv := NewService(rps, &mocks.CatEventRepository{}, &mocks.ShelterRepository{}, &mocks.VaccinationRepository{}, &mocks.PhotoRepository{}, &mocks.BlobStore{}, &mocks.RedisRepository{})
+	_, err := srv.Patch(context.Background(), stored.ID, model.AnyVersion, &model.CatPatch{Name: &name})
+	require.ErrorIs(t, err, model.ErrInvalid)
+	rps.AssertNotCalled(t, "Patch", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCatService_PatchVersionMismatch(t *testing.T) {
+	stored := &model.Cat{ID: uuid.New(), Name: "Cat 1", Age: 2, Version: 5}
+	vaccinated := true
+
+	rps := &mocks.SheltersCatRepository{}
+	rps.On("Get", context.Background(), stored.ID).Return(stored, nil)
+
+	srv := NewService(rps, &mocks.CatEventRepository{}, &mocks.ShelterRepository{}, &mocks.VaccinationRepository{}, &mocks.PhotoRepository{}, &mocks.BlobStore{}, &mocks.RedisRepository{})
+	_, err := srv.Patch(context.Background(), stored.ID, 4, &model.CatPatch{Vaccinated: &vaccinated})
+	require.ErrorIs(t, err, model.ErrVersionMismatch)
+}
+
+func TestCatService_PatchRetriesConcurrentChange(t *testing.T) {
+	id := uuid.New()
+	vaccinated := true
+	patch := &model.CatPatch{Vaccinated: &vaccinated}
+
+	rps := &mocks.SheltersCatRepository{}
+	rps.On("Get", context.Background(), id).Return(&model.Cat{ID: id, Name: "Cat 1", Age: 2, Version: 1}, nil).Once()
+	rps.On("Get", context.Background(), id).Return(&model.Cat{ID: id, Name: "Cat 2", Age: 2, Version: 2}, nil).Once()
+	rps.On("Patch", context.Background(), id, int64(1), patch).Return(int64(0), model.ErrVersionMismatch)
+	rps.On("Patch", context.Background(), id, int64(2), patch).Return(int64(3), nil)
+	cache := &mocks.RedisRepository{}
+	events := &mocks.CatEventRepository{}
+	events.On("AddEvent", context.Background(), mock.Anything).Return(nil).Once()
+
+	srv := NewService(rps, events, &mocks.ShelterRepository{}, noVaccinations(), &mocks.PhotoRepository{}, &mocks.BlobStore{}, cache)
+	cat, err := srv.Patch(context.Background(), id, model.AnyVersion, patch)
+	require.NoError(t, err)
+	require.Equal(t, "Cat 2", cat.Name)
+	require.Equal(t, int64(3), cat.Version)
+}
+
+func TestCatService_Restore(t *testing.T) {
+	restored := &model.Cat{ID: uuid.New(), Name: "Cat 1", Age: 2, Version: 3}
+
+	rps := &mocks.SheltersCatRepository{}
+	rps.On("Restore", context.Background(), restored.ID, model.AnyVersion).Return(restored, nil)
+	cache := &mocks.RedisRepository{}
+	events := &mocks.CatEventRepository{}
+	events.On("AddEvent", context.Background(), mock.Anything).Return(nil)
+
+	srv := NewService(rps, events, &mocks.ShelterRepository{}, &mocks.VaccinationRepository{}, &mocks.PhotoRepository{}, &mocks.BlobStore{}, cache)
+	cat, err := srv.Restore(context.Background(), restored.ID, model.AnyVersion)
+	require.NoError(t, err)
+	require.Equal(t, restored, cat)
+}
+
+func TestCatService_Purge(t *testing.T) {
+	first, second := uuid.New(), uuid.New()
+	rps := &mocks.SheltersCatRepository{}
+	rps.On("Purge", context.Background(), mock.MatchedBy(func(before time.Time) bool {
+		return time.Since(before) >= time.Hour
+	})).Return([]uuid.UUID{first, second}, nil)
+	blobs := &mocks.BlobStore{}
+	blobs.On("DeletePrefix", context.Background(), "cats/"+first.String()+"/").Return(errors.New("disk is gone")).Once()
+	blobs.On("DeletePrefix", context.Background(), "cats/"+second.String()+"/").Return(nil).Once()
+
+	srv := NewService(rps, &mocks.CatEventRepository{}, &mocks.ShelterRepository{}, &mocks.VaccinationRepository{}, &mocks.PhotoRepository{}, blobs, &mocks.RedisRepository{})
+	purged, err := srv.Purge(context.Background(), time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), purged)
+	blobs.AssertExpectations(t)
+
+	_, err = srv.Purge(context.Background(), 0)
+	require.ErrorIs(t, err, model.ErrInvalid)
+}
+
+func TestCatService_Touch(t *testing.T) {
+	id := uuid.New()
+	rps := &mocks.SheltersCatRepository{}
+	rps.On("Patch", context.Background(), id, model.AnyVersion, &model.CatPatch{}).Return(int64(3), nil).Once()
+
+	srv := NewService(rps, &mocks.CatEventRepository{}, &mocks.ShelterRepository{}, &mocks.VaccinationRepository{}, &mocks.PhotoRepository{}, &mocks.BlobStore{}, &mocks.RedisRepository{})
+	require.NoError(t, srv.Touch(context.Background(), id))
+	rps.AssertExpectations(t)
+}
+
+func TestCatService_UpdateRecordsActor(t *testing.T) {
+	stored := &model.Cat{ID: uuid.New(), Name: "Cat 1", Age: 2, Version: 1}
+	cat := &model.Cat{ID: stored.ID, Name: "Cat 2", Age: 3}
+	ctx := WithActor(context.Background(), "volunteer")
+
+	rps := &mocks.SheltersCatRepository{}
+	rps.On("Get", ctx, stored.ID).Return(stored, nil)
+	rps.On("Update", ctx, cat).Return(nil)
+	cache := &mocks.RedisRepository{}
+	events := &mocks.CatEventRepository{}
+	events.On("AddEvent", ctx, mock.MatchedBy(func(event *model.CatEvent) bool {
+		return event.Actor == "volunteer" && event.Before.Name == "Cat 1" && event.After.Name == "Cat 2"
+	})).Return(nil)
+
+	srv := NewService(rps, events, &mocks.ShelterRepository{}, noVaccinations(), &mocks.PhotoRepository{}, &mocks.BlobStore{}, cache)
+	require.NoError(t, srv.Update(ctx, cat))
+	events.AssertExpectations(t)
+}
+
+func TestCatService_UpdateVersionMismatch(t *testing.T) {
+	stored := &model.Cat{ID: uuid.New(), Name: "Cat 1", Age: 2, Version: 3}
+
+	rps := &mocks.SheltersCatRepository{}
+	rps.On("Get", context.Background(), stored.ID).Return(stored, nil)
+
+	srv := NewService(rps, &mocks.CatEventRepository{}, &mocks.ShelterRepository{}, &mocks.VaccinationRepository{}, &mocks.PhotoRepository{}, &mocks.BlobStore{}, &mocks.RedisRepository{})
+	err := srv.Update(context.Background(), &model.Cat{ID: stored.ID, Name: "Cat 2", Age: 2, Version: 2})
+	require.ErrorIs(t, err, model.ErrVersionMismatch)
+}
+
+func TestCatService_HistoryPagination(t *testing.T) {
+	id := uuid.New()
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	history := []*model.CatEvent{
+		{ID: uuid.New(), CatID: id, Action: model.CatEventCreate, CreatedAt: now},
+		{ID: uuid.New(), CatID: id, Action: model.CatEventUpdate, CreatedAt: now.Add(time.Second)},
+		{ID: uuid.New(), CatID: id, Action: model.CatEventDelete, CreatedAt: now.Add(2 * time.Second)},
+	}
+
+	events := &mocks.CatEventRepository{}
+	events.On("ListEvents", context.Background(), id, (*model.CatEvent)(nil), 3).Return(history, nil)
+	events.On("ListEvents", context.Background(), id, mock.MatchedBy(func(after *model.CatEvent) bool {
+		return after != nil && after.ID == history[1].ID && after.CreatedAt.Equal(history[1].CreatedAt)
+	}), 3).Return(history[2:], nil)
+
+	srv := NewService(&mocks.SheltersCatRepository{}, events, &mocks.ShelterRepository{}, &mocks.VaccinationRepository{}, &mocks.PhotoRepository{}, &mocks.BlobStore{}, &mocks.RedisRepository{})
+	page, err := srv.History(context.Background(), id, 2, "")
+	require.NoError(t, err)
+	require.Equal(t, history[:2], page.Events)
+	require.NotEmpty(t, page.NextCursor)
+
+	page, err = srv.History(context.Background(), id, 2, page.NextCursor)
+	require.NoError(t, err)
+	require.Equal(t, history[2:], page.Events)
+	require.Empty(t, page.NextCursor)
+
+	_, err = srv.History(context.Background(), id, MaxListLimit+1, "")
+	require.ErrorIs(t, err, model.ErrInvalid)
+	_, err = srv.History(context.Background(), id, 2, "not a cursor")
+	require.ErrorIs(t, err, model.ErrInvalid)
+}
+
+func TestCatService_ImportBatches(t *testing.T) {
+	cats := make([]*model.Cat, importBatchSize+1)
+	for i := range cats {
+		cats[i] = &model.Cat{Name: "Cat", Age: i}
+	}
+
+	rps := &mocks.SheltersCatRepository{}
+	rps.On("CreateMany", context.Background(), cats[:importBatchSize]).Return(nil).Once()
+	rps.On("CreateMany", context.Background(), cats[importBatchSize:]).Return(nil).Once()
+	cache := &mocks.RedisRepository{}
+	events := &mocks.CatEventRepository{}
+	events.On("AddEvent", context.Background(), mock.Anything).Return(nil).Times(len(cats))
+
+	srv := NewService(rps, events, &mocks.ShelterRepository{}, &mocks.VaccinationRepository{}, &mocks.PhotoRepository{}, &mocks.BlobStore{}, cache)
+	imported, err := srv.Import(context.Background(), cats)
+	require.NoError(t, err)
+	require.Equal(t, len(cats), imported)
+	require.NotEqual(t, uuid.Nil, cats[0].ID)
+	rps.AssertExpectations(t)
+}
+
+func TestCatService_ImportInvalid(t *testing.T) {
+	srv := NewService(&mocks.SheltersCatRepository{}, &mocks.CatEventRepository{}, &mocks.ShelterRepository{}, &mocks.VaccinationRepository{}, &mocks.PhotoRepository{}, &mocks.BlobStore{}, &mocks.RedisRepository{})
+	_, err := srv.Import(context.Background(), []*model.Cat{{Name: "Cat 1"}, {Name: " "}})
+	require.ErrorIs(t, err, model.ErrInvalid)
+}
+
+func TestCatService_ValidateImport(t *testing.T) {
+	shelterID := uuid.New()
+	registered, repeated, fresh := "985112000123456", "985112000123457", "985112000123458"
+	cats := []*model.Cat{
+		{Name: "Cat 1", CatProfile: model.CatProfile{Sex: "unknown"}},
+		{Name: "Cat 2", ShelterID: &shelterID},
+		{Name: "Cat 3", CatProfile: model.CatProfile{Microchip: &registered}},
+		{Name: "Cat 4", CatProfile: model.CatProfile{Microchip: &repeated}},
+		{Name: "Cat 5", CatProfile: model.CatProfile{Microchip: &repeated}},
+		{Name: "Cat 6", CatProfile: model.CatProfile{Microchip: &fresh}},
+	}
+
+	rps := &mocks.SheltersCatRepository{}
+	rps.On("GetByMicrochip", context.Background(), registered).Return(&model.Cat{ID: uuid.New()}, nil)
+	rps.On("GetByMicrochip", context.Background(), repeated).Return(nil, model.ErrCatNotFound).Twice()
+	rps.On("GetByMicrochip", context.Background(), fresh).Return(nil, model.ErrCatNotFound)
+	shelters := &mocks.ShelterRepository{}
+	shelters.On("Get", context.Background(), shelterID).Return(nil, model.ErrShelterNotFound)
+
+	srv := NewService(rps, &mocks.CatEventRepository{}, shelters, &mocks.VaccinationRepository{}, &mocks.PhotoRepository{}, &mocks.BlobStore{}, &mocks.RedisRepository{})
+	err := srv.ValidateImport(context.Background(), cats)
+	require.ErrorIs(t, err, model.ErrInvalid)
+	var invalid *model.ImportError
+	require.ErrorAs(t, err, &invalid)
+	require.Len(t, invalid.Cats, 4)
+	for _, i := range []int{0, 1, 2, 4} {
+		require.Error(t, invalid.Cats[i], "cat %d", i)
+	}
+
+	_, err = srv.Import(context.Background(), cats)
+	require.ErrorAs(t, err, &invalid)
+	rps.AssertNotCalled(t, "CreateMany", mock.Anything, mock.Anything)
+}
+
+func TestCatService_ImportStoppedByError(t *testing.T) {
+	cats := make([]*model.Cat, importBatchSize+1)
+	for i := range cats {
+		cats[i] = &model.Cat{Name: "Cat", Age: i}
+	}
+
+	rps := &mocks.SheltersCatRepository{}
+	rps.On("CreateMany", context.Background(), cats[:importBatchSize]).Return(nil).Once()
+	rps.On("CreateMany", context.Background(), cats[importBatchSize:]).Return(errors.New("connection lost")).Once()
+	cache := &mocks.RedisRepository{}
+	events := &mocks.CatEventRepository{}
+	events.On("AddEvent", context.Background(), mock.Anything).Return(nil)
+
+	srv := NewService(rps, events, &mocks.ShelterRepository{}, &mocks.VaccinationRepository{}, &mocks.PhotoRepository{}, &mocks.BlobStore{}, cache)
+	imported, err := srv.Import(context.Background(), cats)
+	require.Error(t, err)
+	require.Equal(t, importBatchSize, imported)
+}
+
+func TestCatService_Export(t *testing.T) {
+	vaccinated := true
+	filter := &model.CatFilter{Vaccinated: &vaccinated}
+
+	rps := &mocks.SheltersCatRepository{}
+	rps.On("Export", context.Background(), filter, mock.Anything).Return(nil)
+
+	srv := NewService(rps, &mocks.CatEventRepository{}, &mocks.ShelterRepository{}, &mocks.VaccinationRepository{}, &mocks.PhotoRepository{}, &mocks.BlobStore{}, &mocks.RedisRepository{})
+	require.NoError(t, srv.Export(context.Background(), filter, func(*model.Cat) error { return nil }))
+	rps.AssertExpectations(t)
+
+	minAge, maxAge := 5, 1
+	err := srv.Export(context.Background(), &model.CatFilter{MinAge: &minAge, MaxAge: &maxAge}, func(*model.Cat) error { return nil })
+	require.ErrorIs(t, err, model.ErrInvalid)
+}
+
+func TestCatService_CreateUnknownShelter(t *testing.T) {
+	shelterID := uuid.New()
+
+	shelters := &mocks.ShelterRepository{}
+	shelters.On("Get", context.Background(), shelterID).Return(nil, model.ErrShelterNotFound)
+
+	srv := NewService(&mocks.SheltersCatRepository{}, &mocks.CatEventRepository{}, shelters, &mocks.VaccinationRepository{}, &mocks.PhotoRepository{}, &mocks.BlobStore{}, &mocks.RedisRepository{})
+	err := srv.Create(context.Background(), &model.Cat{Name: "Cat 1", Age: 2, ShelterID: &shelterID})
+	require.ErrorIs(t, err, model.ErrInvalid)
+}
+
+func TestCatService_Transition(t *testing.T) {
+	stored := &model.Cat{ID: uuid.New(), Name: "Cat 1", Age: 2, Status: model.CatAvailable, Version: 2}
+	reserved := model.CatReserved
+
+	rps := &mocks.SheltersCatRepository{}
+	rps.On("Get", context.Background(), stored.ID).Return(stored, nil)
+	rps.On("Patch", context.Background(), stored.ID, int64(2), &model.CatPatch{Status: &reserved}).Return(int64(3), nil)
+	cache := &mocks.RedisRepository{}
+	events := &mocks.CatEventRepository{}
+	events.On("AddEvent", context.Background(), mock.MatchedBy(func(event *model.CatEvent) bool {
+		return event.Action == model.CatReserve && event.Before.Status == model.CatAvailable && event.After.Status == model.CatReserved
+	})).Return(nil)
+
+	srv := NewService(rps, events, &mocks.ShelterRepository{}, &mocks.VaccinationRepository{}, &mocks.PhotoRepository{}, &mocks.BlobStore{}, cache)
+	cat, err := srv.Transition(context.Background(), stored.ID, 2, model.CatReserve)
+	require.NoError(t, err)
+	require.Equal(t, model.CatReserved, cat.Status)
+	require.Equal(t, int64(3), cat.Version)
+	events.AssertExpectations(t)
+}
+
+func TestCatService_TransitionNotAllowed(t *testing.T) {
+	tests := []struct {
+		status     string
+		transition string
+	}{
+		{status: model.CatAvailable, transition: model.CatReturn},
+		{status: "", transition: model.CatCancelReservation},
+		{status: model.CatReserved, transition: model.CatReserve},
+		{status: model.CatAdopted, transition: model.CatAdopt},
+		{status: model.CatAdopted, transition: model.CatReserve},
+		{status: model.CatReturned, transition: model.CatReturn},
+	}
+	for _, tt := range tests {
+		t.Run(tt.status+" "+tt.transition, func(t *testing.T) {
+			stored := &model.Cat{ID: uuid.New(), Name: "Cat 1", Age: 2, Status: tt.status, Version: 1}
+
+			rps := &mocks.SheltersCatRepository{}
+			rps.On("Get", context.Background(), stored.ID).Return(stored, nil)
+
+			srv := NewService(rps, &mocks.CatEventRepository{}, &mocks.ShelterRepository{}, &mocks.VaccinationRepository{}, &mocks.PhotoRepository{}, &mocks.BlobStore{}, &mocks.RedisRepository{})
+			_, err := srv.Transition(context.Background(), stored.ID, model.AnyVersion, tt.transition)
+			require.ErrorIs(t, err, model.ErrConflict)
+		})
+	}
+
+	srv := NewService(&mocks.SheltersCatRepository{}, &mocks.CatEventRepository{}, &mocks.ShelterRepository{}, &mocks.VaccinationRepository{}, &mocks.PhotoRepository{}, &mocks.BlobStore{}, &mocks.RedisRepository{})
+	_, err := srv.Transition(context.Background(), uuid.New(), model.AnyVersion, "sell")
+	require.ErrorIs(t, err, model.ErrInvalid)
+}
+
+func TestCatService_UpdateKeepsStatus(t *testing.T) {
+	stored := &model.Cat{ID: uuid.New(), Name: "Cat 1", Age: 2, Status: model.CatAdopted, Version: 1}
+	cat := &model.Cat{ID: stored.ID, Name: "Cat 2", Age: 2, Status: model.CatAvailable}
+
+	rps := &mocks.SheltersCatRepository{}
+	rps.On("Get", context.Background(), stored.ID).Return(stored, nil)
+	rps.On("Update", context.Background(), cat).Return(nil)
+	cache := &mocks.RedisRepository{}
+	events := &mocks.CatEventRepository{}
+	events.On("AddEvent", context.Background(), mock.Anything).Return(nil)
+
+	srv := NewService(rps, events, &mocks.ShelterRepository{}, noVaccinations(), &mocks.PhotoRepository{}, &mocks.BlobStore{}, cache)
+	require.NoError(t, srv.Update(context.Background(), cat))
+	require.Equal(t, model.CatAdopted, cat.Status)
+}
+
+// noVaccinations returns the storage of cats without vaccination records
+func noVaccinations() *mocks.VaccinationRepository {
+	vaccinations := &mocks.VaccinationRepository{}
+	vaccinations.On("ListVaccinations", mock.Anything, mock.Anything).Return([]*model.Vaccination{}, nil)
+
+	return vaccinations
+}
+
+func TestCatService_AddVaccination(t *testing.T) {
+	stored := &model.Cat{ID: uuid.New(), Name: "Cat 1", Age: 2, Version: 1}
+	administered := time.Now().AddDate(0, -1, 0)
+	expires := administered.AddDate(1, 0, 0)
+	rabies := &model.Vaccination{Vaccine: model.VaccineRabies, AdministeredAt: administered, ExpiresAt: &expires}
+	fvrcp := &model.Vaccination{ID: uuid.New(), Vaccine: "fvrcp", AdministeredAt: administered}
+	vaccinated := true
+
+	rps := &mocks.SheltersCatRepository{}
+	rps.On("Get", context.Background(), stored.ID).Return(stored, nil)
+	rps.On("Patch", context.Background(), stored.ID, int64(1), &model.CatPatch{Vaccinated: &vaccinated}).Return(int64(2), nil)
+	vaccinations := &mocks.VaccinationRepository{}
+	vaccinations.On("AddVaccination", context.Background(), stored.ID, rabies).Return(nil)
+	vaccinations.On("ListVaccinations", context.Background(), stored.ID).Return([]*model.Vaccination{fvrcp, rabies}, nil)
+	cache := &mocks.RedisRepository{}
+	events := &mocks.CatEventRepository{}
+	events.On("AddEvent", context.Background(), mock.MatchedBy(func(event *model.CatEvent) bool {
+		return !event.Before.Vaccinated && event.After.Vaccinated
+	})).Return(nil)
+
+	srv := NewService(rps, events, &mocks.ShelterRepository{}, vaccinations, &mocks.PhotoRepository{}, &mocks.BlobStore{}, cache)
+	require.NoError(t, srv.AddVaccination(context.Background(), stored.ID, rabies))
+	require.NotEqual(t, uuid.Nil, rabies.ID)
+	rps.AssertExpectations(t)
+}
+
+func TestCatService_SyncVaccinated(t *testing.T) {
+	expired := &model.Cat{ID: uuid.New(), Name: "Cat 1", Age: 2, Vaccinated: true, Version: 1}
+	current := &model.Cat{ID: uuid.New(), Name: "Cat 2", Age: 3, Vaccinated: true, Version: 1}
+	unrecorded := &model.Cat{ID: uuid.New(), Name: "Cat 3", Age: 4, Vaccinated: true, Version: 1}
+	administered := time.Now().AddDate(-1, -1, 0)
+	lapsed := administered.AddDate(1, 0, 0)
+	valid := administered.AddDate(3, 0, 0)
+	vaccinated, notVaccinated := true, false
+
+	rps := &mocks.SheltersCatRepository{}
+	rps.On("Export", context.Background(), &model.CatFilter{Vaccinated: &vaccinated}, mock.Anything).
+		Return(nil).Run(func(args mock.Arguments) {
+		fn := args.Get(2).(func(*model.Cat) error)
+		for _, cat := range []*model.Cat{expired, current, unrecorded} {
+			require.NoError(t, fn(cat))
+		}
+	})
+	rps.On("Get", context.Background(), expired.ID).Return(expired, nil)
+	rps.On("Patch", context.Background(), expired.ID, int64(1), &model.CatPatch{Vaccinated: &notVaccinated}).Return(int64(2), nil).Once()
+	vaccinations := &mocks.VaccinationRepository{}
+	vaccinations.On("ListVaccinations", context.Background(), expired.ID).Return([]*model.Vaccination{
+		{ID: uuid.New(), Vaccine: model.VaccineFVRCP, AdministeredAt: administered, ExpiresAt: &valid},
+		{ID: uuid.New(), Vaccine: model.VaccineRabies, AdministeredAt: administered, ExpiresAt: &lapsed},
+	}, nil)
+	vaccinations.On("ListVaccinations", context.Background(), current.ID).Return([]*model.Vaccination{
+		{ID: uuid.New(), Vaccine: model.VaccineFVRCP, AdministeredAt: administered, ExpiresAt: &valid},
+		{ID: uuid.New(), Vaccine: model.VaccineRabies, AdministeredAt: administered, ExpiresAt: &valid},
+	}, nil)
+	vaccinations.On("ListVaccinations", context.Background(), unrecorded.ID).Return([]*model.Vaccination{}, nil)
+	events := &mocks.CatEventRepository{}
+	events.On("AddEvent", context.Background(), mock.MatchedBy(func(event *model.CatEvent) bool {
+		return event.CatID == expired.ID && event.Before.Vaccinated && !event.After.Vaccinated
+	})).Return(nil).Once()
+
+	srv := NewService(rps, events, &mocks.ShelterRepository{}, vaccinations, &mocks.PhotoRepository{}, &mocks.BlobStore{}, &mocks.RedisRepository{})
+	synced, err := srv.SyncVaccinated(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, synced)
+	rps.AssertExpectations(t)
+	events.AssertExpectations(t)
+}
+
+func TestCatService_AddVaccinationInvalid(t *testing.T) {
+	administered := time.Now().AddDate(0, -1, 0)
+	expired := administered.AddDate(0, 0, -1)
+	tests := map[string]*model.Vaccination{
+		"no vaccine":      {AdministeredAt: administered},
+		"no date":         {Vaccine: model.VaccineFeLV},
+		"future":          {Vaccine: model.VaccineFeLV, AdministeredAt: time.Now().AddDate(0, 0, 1)},
+		"expires earlier": {Vaccine: model.VaccineFeLV, AdministeredAt: administered, ExpiresAt: &expired},
+	}
+	for name, vaccination := range tests {
+		t.Run(name, func(t *testing.T) {
+			srv := NewService(&mocks.SheltersCatRepository{}, &mocks.CatEventRepository{}, &mocks.ShelterRepository{},
+				&mocks.VaccinationRepository{}, &mocks.PhotoRepository{}, &mocks.BlobStore{}, &mocks.RedisRepository{})
+			err := srv.AddVaccination(context.Background(), uuid.New(), vaccination)
+			require.ErrorIs(t, err, model.ErrInvalid)
+		})
+	}
+}
+
+func TestCatService_DeleteLastVaccination(t *testing.T) {
+	stored := &model.Cat{ID: uuid.New(), Name: "Cat 1", Age: 2, Vaccinated: true, Version: 4}
+	vaccinationID := uuid.New()
+	vaccinated := false
+
+	rps := &mocks.SheltersCatRepository{}
+	rps.On("Get", context.Background(), stored.ID).Return(stored, nil)
+	rps.On("Patch", context.Background(), stored.ID, int64(4), &model.CatPatch{Vaccinated: &vaccinated}).Return(int64(5), nil)
+	vaccinations := noVaccinations()
+	vaccinations.On("DeleteVaccination", context.Background(), stored.ID, vaccinationID).Return(nil)
+	cache := &mocks.RedisRepository{}
+	events := &mocks.CatEventRepository{}
+	events.On("AddEvent", context.Background(), mock.Anything).Return(nil)
+
+	srv := NewService(rps, events, &mocks.ShelterRepository{}, vaccinations, &mocks.PhotoRepository{}, &mocks.BlobStore{}, cache)
+	require.NoError(t, srv.DeleteVaccination(context.Background(), stored.ID, vaccinationID))
+	rps.AssertExpectations(t)
+}
+
+func TestCatService_UpdateDerivesVaccinated(t *testing.T) {
+	stored := &model.Cat{ID: uuid.New(), Name: "Cat 1", Age: 2, Version: 1}
+	cat := &model.Cat{ID: stored.ID, Name: "Cat 1", Age: 2, Vaccinated: true}
+	expired := time.Now().AddDate(0, -1, 0)
+	records := []*model.Vaccination{
+		{Vaccine: model.VaccineFVRCP, AdministeredAt: expired.AddDate(-1, 0, 0)},
+		{Vaccine: model.VaccineRabies, AdministeredAt: expired.AddDate(-1, 0, 0), ExpiresAt: &expired},
+	}
+
+	rps := &mocks.SheltersCatRepository{}
+	rps.On("Get", context.Background(), stored.ID).Return(stored, nil)
+	rps.On("Update", context.Background(), cat).Return(nil)
+	vaccinations := &mocks.VaccinationRepository{}
+	vaccinations.On("ListVaccinations", context.Background(), stored.ID).Return(records, nil)
+	cache := &mocks.RedisRepository{}
+	events := &mocks.CatEventRepository{}
+	events.On("AddEvent", context.Background(), mock.Anything).Return(nil)
+
+	srv := NewService(rps, events, &mocks.ShelterRepository{}, vaccinations, &mocks.PhotoRepository{}, &mocks.BlobStore{}, cache)
+	require.NoError(t, srv.Update(context.Background(), cat))
+	require.False(t, cat.Vaccinated)
+}
+
+func TestCatService_CreateProfile(t *testing.T) {
+	birthDate := time.Now().AddDate(-3, 0, -1)
+	microchip := " 985112000123456 "
+	cat := &model.Cat{Name: "Cat 1", CatProfile: model.CatProfile{BirthDate: &birthDate, Sex: " Female ", Breed: " siamese ", Microchip: &microchip}}
+
+	rps := &mocks.SheltersCatRepository{}
+	rps.On("Create", context.Background(), cat).Return(nil)
+	cache := &mocks.RedisRepository{}
+	events := &mocks.CatEventRepository{}
+	events.On("AddEvent", context.Background(), mock.Anything).Return(nil)
+
+	srv := NewService(rps, events, &mocks.ShelterRepository{}, &mocks.VaccinationRepository{}, &mocks.PhotoRepository{}, &mocks.BlobStore{}, cache)
+	require.NoError(t, srv.Create(context.Background(), cat))
+	require.Equal(t, 3, cat.Age)
+	require.Equal(t, model.CatFemale, cat.Sex)
+	require.Equal(t, "siamese", cat.Breed)
+	require.Equal(t, "985112000123456", *cat.Microchip)
+	require.Zero(t, cat.BirthDate.Hour())
+}
+
+func TestCatService_CreateInvalidProfile(t *testing.T) {
+	srv := NewService(&mocks.SheltersCatRepository{}, &mocks.CatEventRepository{}, &mocks.ShelterRepository{}, &mocks.VaccinationRepository{}, &mocks.PhotoRepository{}, &mocks.BlobStore{}, &mocks.RedisRepository{})
+	future := time.Now().AddDate(0, 1, 0)
+	birthDate := time.Now().AddDate(-2, 0, 0)
+	intakeDate := birthDate.AddDate(0, -1, 0)
+	empty := " "
+
+	tests := []model.CatProfile{
+		{Sex: "unknown"},
+		{BirthDate: &future},
+		{IntakeDate: &future},
+		{BirthDate: &birthDate, IntakeDate: &intakeDate},
+		{Microchip: &empty},
+	}
+	for _, profile := range tests {
+		err := srv.Create(context.Background(), &model.Cat{Name: "Cat 1", Age: 2, CatProfile: profile})
+		require.ErrorIs(t, err, model.ErrInvalid)
+	}
+}
+
+func TestCatService_PatchBirthDateDerivesAge(t *testing.T) {
+	stored := &model.Cat{ID: uuid.New(), Name: "Cat 1", Age: 2, Version: 3}
+	birthDate := time.Now().AddDate(-5, -1, 0)
+	patch := &model.CatPatch{Profile: &model.CatProfile{BirthDate: &birthDate}}
+
+	rps := &mocks.SheltersCatRepository{}
+	rps.On("Get", context.Background(), stored.ID).Return(stored, nil)
+	rps.On("Patch", context.Background(), stored.ID, int64(3), mock.MatchedBy(func(patch *model.CatPatch) bool {
+		return patch.Age != nil && *patch.Age == 5 && patch.Profile.BirthDate != nil
+	})).Return(int64(4), nil)
+	cache := &mocks.RedisRepository{}
+	events := &mocks.CatEventRepository{}
+	events.On("AddEvent", context.Background(), mock.Anything).Return(nil)
+
+	srv := NewService(rps, events, &mocks.ShelterRepository{}, noVaccinations(), &mocks.PhotoRepository{}, &mocks.BlobStore{}, cache)
+	cat, err := srv.Patch(context.Background(), stored.ID, 3, patch)
+	require.NoError(t, err)
+	require.Equal(t, 5, cat.Age)
+	rps.AssertExpectations(t)
+}
+
+func TestLatestBirthDate(t *testing.T) {
+	now := time.Date(2024, time.February, 29, 15, 0, 0, 0, time.UTC)
+	require.Equal(t, time.Date(2021, time.February, 28, 0, 0, 0, 0, time.UTC), model.LatestBirthDate(3, now))
+	require.Equal(t, time.Date(2020, time.February, 29, 0, 0, 0, 0, time.UTC), model.LatestBirthDate(4, now))
+
+	cat := &model.Cat{CatProfile: model.CatProfile{BirthDate: &now}}
+	cat.DeriveAge(time.Date(2027, time.February, 28, 0, 0, 0, 0, time.UTC))
+	require.Equal(t, 2, cat.Age)
+	cat.DeriveAge(time.Date(2027, time.March, 1, 0, 0, 0, 0, time.UTC))
+	require.Equal(t, 3, cat.Age)
+}
+
+func TestNormalizeMicrochip(t *testing.T) {
+	tests := []struct {
+		chip       string
+		normalized string
+		valid      bool
+	}{
+		{"985 112 000 123 456", "985112000123456", true},
+		{"985-112-000-123-456", "985112000123456", true},
+		{"AVID*012*345*678", "", false},
+		{"012*345*678", "012345678", true},
+		{"0a1b2c3d4e", "0A1B2C3D4E", true},
+		{"0a1b2c3d4g", "", false},
+		{"000112000123456", "", false},
+		{"999112000123456", "", false},
+		{"985999999999999", "", false},
+		{"98511200012345", "", false},
+	}
+	for _, test := range tests {
+		normalized, valid := model.NormalizeMicrochip(test.chip)
+		require.Equal(t, test.valid, valid, test.chip)
+		if valid {
+			require.Equal(t, test.normalized, normalized)
+		}
+	}
+}
+
+func TestCatService_GetByMicrochip(t *testing.T) {
+	microchip := "985112000123456"
+	cached := &model.Cat{ID: uuid.New(), Name: "Cat 1", CatProfile: model.CatProfile{Microchip: &microchip}}
+	stored := &model.Cat{ID: uuid.New(), Name: "Cat 2"}
+
+	rps := &mocks.SheltersCatRepository{}
+	rps.On("GetByMicrochip", context.Background(), "0A1B2C3D4E").Return(stored, nil)
+	rps.On("GetByMicrochip", context.Background(), "012345678").Return(nil, model.ErrCatNotFound)
+	cache := &mocks.RedisRepository{}
+	cache.On("GetByMicrochip", microchip).Return(cached, nil)
+	cache.On("GetByMicrochip", mock.Anything).Return(nil, errors.New("cat don't exist"))
+	photos := &mocks.PhotoRepository{}
+	photos.On("ListPhotos", context.Background(), mock.Anything).Return(nil, nil)
+
+	srv := NewService(rps, &mocks.CatEventRepository{}, &mocks.ShelterRepository{}, &mocks.VaccinationRepository{}, photos, &mocks.BlobStore{}, cache)
+	cat, err := srv.GetByMicrochip(context.Background(), "985 112 000 123 456")
+	require.NoError(t, err)
+	require.Equal(t, cached.ID, cat.ID)
+	rps.AssertNotCalled(t, "GetByMicrochip", mock.Anything, microchip)
+
+	cat, err = srv.GetByMicrochip(context.Background(), "0a1b2c3d4e")
+	require.NoError(t, err)
+	require.Equal(t, stored.ID, cat.ID)
+
+	_, err = srv.GetByMicrochip(context.Background(), "012-345-678")
+	require.ErrorIs(t, err, model.ErrCatNotFound)
+
+	_, err = srv.GetByMicrochip(context.Background(), "12345")
+	require.ErrorIs(t, err, model.ErrInvalid)
+}
+
+func TestCatService_Stats(t *testing.T) {
+	shelterID := uuid.New()
+	stats := model.NewCatStats()
+	stats.Add("", true, 0, 2)
+	stats.Add(model.CatAdopted, false, 4, 1)
+	stats.AverageStayDays = 10.456
+	stats.MedianStayDays = model.Median([]float64{1, 2.5, 4, 30})
+
+	rps := &mocks.SheltersCatRepository{}
+	rps.On("Stats", context.Background(), mock.Anything).Return(stats, nil)
+	shelters := &mocks.ShelterRepository{}
+	shelters.On("Get", context.Background(), shelterID).Return(&model.Shelter{ID: shelterID}, nil)
+	shelters.On("Get", context.Background(), mock.Anything).Return(nil, model.ErrShelterNotFound)
+
+	srv := NewService(rps, &mocks.CatEventRepository{}, shelters, &mocks.VaccinationRepository{}, &mocks.PhotoRepository{}, &mocks.BlobStore{}, &mocks.RedisRepository{})
+	result, err := srv.Stats(context.Background(), &model.StatsFilter{ShelterID: &shelterID})
+	require.NoError(t, err)
+	require.Equal(t, int64(3), result.Total)
+	require.Equal(t, int64(2), result.ByStatus[model.CatAvailable])
+	require.Equal(t, 0.6667, result.VaccinatedRatio)
+	require.Equal(t, int64(1), result.Ages[4].Count)
+	require.Equal(t, 10.46, result.AverageStayDays)
+	require.Equal(t, 3.25, result.MedianStayDays)
+
+	otherID := uuid.New()
+	_, err = srv.Stats(context.Background(), &model.StatsFilter{ShelterID: &otherID})
+	require.ErrorIs(t, err, model.ErrShelterNotFound)
+
+	from := time.Now()
+	_, err = srv.Stats(context.Background(), &model.StatsFilter{From: &from, To: &from})
+	require.ErrorIs(t, err, model.ErrInvalid)
+}
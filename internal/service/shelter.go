@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/catService/internal/model"
+	"github.com/catService/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+// SheltersService contains business logic for shelters
+//go:generate mockery --dir . --name SheltersService --output ./service_mock
+type SheltersService interface {
+	Get(context.Context, uuid.UUID) (*model.Shelter, error)
+	Create(context.Context, *model.Shelter) error
+	Update(context.Context, *model.Shelter) error
+	Delete(context.Context, uuid.UUID) error
+	List(ctx context.Context, limit int, cursor string) (*model.ShelterPage, error)
+	Cats(ctx context.Context, id uuid.UUID, query *model.CatQuery) (*model.CatPage, error)
+}
+
+// ShelterService contains links to the shelter storage and the cat service
+type ShelterService struct {
+	rps  repository.ShelterRepository
+	cats SheltersCatService
+}
+
+// NewShelterService create new instance
+func NewShelterService(rps repository.ShelterRepository, cats SheltersCatService) *ShelterService {
+	return &ShelterService{
+		rps:  rps,
+		cats: cats,
+	}
+}
+
+// Get returns shelter
+func (s *ShelterService) Get(ctx context.Context, id uuid.UUID) (*model.Shelter, error) {
+	shelter, err := s.rps.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("get shelter %s: %w", id, err)
+	}
+
+	return shelter, nil
+}
+
+// Create validates and saves new shelter
+func (s *ShelterService) Create(ctx context.Context, shelter *model.Shelter) error {
+	if err := validateShelter(shelter); err != nil {
+		return err
+	}
+
+	shelter.ID = uuid.New()
+	if err := s.rps.Create(ctx, shelter); err != nil {
+		return fmt.Errorf("create shelter: %w", err)
+	}
+
+	return nil
+}
+
+// Update validates and saves shelter states
+func (s *ShelterService) Update(ctx context.Context, shelter *model.Shelter) error {
+	if err := validateShelter(shelter); err != nil {
+		return err
+	}
+	if err := s.rps.Update(ctx, shelter); err != nil {
+		return fmt.Errorf("update shelter %s: %w", shelter.ID, err)
+	}
+
+	return nil
+}
+
+// Delete removes shelter which has no cats. Deleted cats count until they are purged
+func (s *ShelterService) Delete(ctx context.Context, id uuid.UUID) error {
+	page, err := s.cats.List(ctx, &model.CatQuery{
+		CatFilter: model.CatFilter{ShelterID: &id, Deleted: model.DeletedInclude},
+		Limit:     1,
+	})
+	if err != nil {
+		return fmt.Errorf("delete shelter %s: %w", id, err)
+	}
+	if len(page.Cats) > 0 {
+		return fmt.Errorf("delete shelter %s: %w: shelter has cats", id, model.ErrConflict)
+	}
+
+	if err := s.rps.Delete(ctx, id); err != nil {
+		return fmt.Errorf("delete shelter %s: %w", id, err)
+	}
+
+	return nil
+}
+
+// List returns a page of shelters
+func (s *ShelterService) List(ctx context.Context, limit int, cursor string) (*model.ShelterPage, error) {
+	switch {
+	case limit == 0:
+		limit = DefaultListLimit
+	case limit < 0 || limit > MaxListLimit:
+		return nil, fmt.Errorf("%w: limit must be between 1 and %d", model.ErrInvalid, MaxListLimit)
+	}
+	after, err := decodeIDCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	// one extra shelter shows whether there is a next page
+	shelters, err := s.rps.List(ctx, after, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("list shelters: %w", err)
+	}
+
+	page := &model.ShelterPage{Shelters: shelters}
+	if len(shelters) > limit {
+		page.Shelters = shelters[:limit]
+		page.NextCursor = encodeIDCursor(page.Shelters[limit-1].ID)
+	}
+
+	return page, nil
+}
+
+// Cats returns a page of cats of the shelter
+func (s *ShelterService) Cats(ctx context.Context, id uuid.UUID, query *model.CatQuery) (*model.CatPage, error) {
+	if _, err := s.rps.Get(ctx, id); err != nil {
+		return nil, fmt.Errorf("shelter %s cats: %w", id, err)
+	}
+
+	query.ShelterID = &id
+	return s.cats.List(ctx, query)
+}
+
+func validateShelter(shelter *model.Shelter) error {
+	if strings.TrimSpace(shelter.Name) == "" {
+		return fmt.Errorf("%w: name is required", model.ErrInvalid)
+	}
+	if shelter.Capacity < 0 {
+		return fmt.Errorf("%w: capacity must not be negative", model.ErrInvalid)
+	}
+
+	return nil
+}
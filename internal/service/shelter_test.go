@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/catService/internal/model"
+	mocks "github.com/catService/internal/repository/repository_mock"
+	servicemock "github.com/catService/internal/service/service_mock"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShelterService_Create(t *testing.T) {
+	shelter := &model.Shelter{Name: "Shelter 1", Capacity: 10}
+
+	rps := &mocks.ShelterRepository{}
+	rps.On("Create", context.Background(), shelter).Return(nil)
+
+	srv := NewShelterService(rps, &servicemock.SheltersCatService{})
+	require.NoError(t, srv.Create(context.Background(), shelter))
+	require.NotEqual(t, uuid.Nil, shelter.ID)
+
+	err := srv.Create(context.Background(), &model.Shelter{Name: "Shelter 2", Capacity: -1})
+	require.ErrorIs(t, err, model.ErrInvalid)
+}
+
+func TestShelterService_DeleteWithCats(t *testing.T) {
+	id := uuid.New()
+
+	cats := &servicemock.SheltersCatService{}
+	cats.On("List", context.Background(), mock.MatchedBy(func(query *model.CatQuery) bool {
+		return *query.ShelterID == id && query.Deleted == model.DeletedInclude
+	})).Return(&model.CatPage{Cats: []*model.Cat{{ID: uuid.New(), ShelterID: &id}}}, nil)
+
+	srv := NewShelterService(&mocks.ShelterRepository{}, cats)
+	err := srv.Delete(context.Background(), id)
+	require.ErrorIs(t, err, model.ErrConflict)
+}
+
+func TestShelterService_Delete(t *testing.T) {
+	id := uuid.New()
+
+	rps := &mocks.ShelterRepository{}
+	rps.On("Delete", context.Background(), id).Return(nil)
+	cats := &servicemock.SheltersCatService{}
+	cats.On("List", context.Background(), mock.Anything).Return(&model.CatPage{}, nil)
+
+	srv := NewShelterService(rps, cats)
+	require.NoError(t, srv.Delete(context.Background(), id))
+	rps.AssertExpectations(t)
+}
+
+func TestShelterService_ListPagination(t *testing.T) {
+	shelters := []*model.Shelter{{ID: uuid.New(), Name: "Shelter 1"}, {ID: uuid.New(), Name: "Shelter 2"}}
+
+	rps := &mocks.ShelterRepository{}
+	rps.On("List", context.Background(), (*uuid.UUID)(nil), 2).Return(shelters, nil)
+	rps.On("List", context.Background(), &shelters[0].ID, 2).Return(shelters[1:], nil)
+
+	srv := NewShelterService(rps, &servicemock.SheltersCatService{})
+	page, err := srv.List(context.Background(), 1, "")
+	require.NoError(t, err)
+	require.Equal(t, shelters[:1], page.Shelters)
+	require.NotEmpty(t, page.NextCursor)
+
+	page, err = srv.List(context.Background(), 1, page.NextCursor)
+	require.NoError(t, err)
+	require.Equal(t, shelters[1:], page.Shelters)
+	require.Empty(t, page.NextCursor)
+
+	_, err = srv.List(context.Background(), 1, "not a cursor")
+	require.ErrorIs(t, err, model.ErrInvalid)
+}
+
+func TestShelterService_Cats(t *testing.T) {
+	id := uuid.New()
+	page := &model.CatPage{Cats: []*model.Cat{{ID: uuid.New(), ShelterID: &id}}}
+
+	rps := &mocks.ShelterRepository{}
+	rps.On("Get", context.Background(), id).Return(&model.Shelter{ID: id, Name: "Shelter 1"}, nil)
+	missing := uuid.New()
+	rps.On("Get", context.Background(), missing).Return(nil, model.ErrShelterNotFound)
+	cats := &servicemock.SheltersCatService{}
+	cats.On("List", context.Background(), mock.MatchedBy(func(query *model.CatQuery) bool {
+		return *query.ShelterID == id
+	})).Return(page, nil)
+
+	srv := NewShelterService(rps, cats)
+	result, err := srv.Cats(context.Background(), id, &model.CatQuery{})
+	require.NoError(t, err)
+	require.Equal(t, page, result)
+
+	_, err = srv.Cats(context.Background(), missing, &model.CatQuery{})
+	require.ErrorIs(t, err, model.ErrShelterNotFound)
+}
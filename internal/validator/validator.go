@@ -3,6 +3,8 @@ package validator
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/go-playground/validator"
 )
@@ -18,11 +20,21 @@ func NewValidator() *Validator {
 }
 
 // Validate implements the echo framework validator interface.
+// The error names the fields which failed the validation
 func (val *Validator) Validate(i interface{}) error {
 	err := val.validator.Struct(i)
 	if err == nil {
 		return nil
 	}
-	err = errors.New("some validation error")
-	return err
+	var fieldErrors validator.ValidationErrors
+	if !errors.As(err, &fieldErrors) {
+		return errors.New("some validation error")
+	}
+
+	failed := make([]string, 0, len(fieldErrors))
+	for _, fieldError := range fieldErrors {
+		failed = append(failed, fmt.Sprintf("%s failed on %s", fieldError.Field(), fieldError.Tag()))
+	}
+
+	return fmt.Errorf("validation error: %s", strings.Join(failed, ", "))
 }
@@ -3,17 +3,24 @@ package main
 import (
 	"context"
 	"errors"
+	"flag"
+	"fmt"
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
+	"syscall"
+	"text/tabwriter"
 	"time"
 
 	_ "github.com/catService/docs"
 	"github.com/catService/internal/config"
 	"github.com/catService/internal/handlers"
+	"github.com/catService/internal/model"
 	"github.com/catService/internal/repository"
 	"github.com/catService/internal/service"
 	"github.com/catService/internal/validator"
+	"github.com/catService/migrations"
 
 	"github.com/go-redis/redis/v8"
 	"github.com/jackc/pgx/v4/pgxpool"
@@ -26,15 +33,21 @@ import (
 
 // @title        Cats API
 // @version      1.0
-// @description  API server for shelters cats
+// @description  API server for shelters cats. Changes are recorded in the cat history with the actor claimed in X-Actor header,
+// @description  the header isn't verified, requests with the admin token are recorded as admin
 
 // @host      localhost:9090
 // @BasePath  /v1/
 // @schemes   http
 
+// @securityDefinitions.apikey  AdminToken
+// @in                          header
+// @name                        Authorization
+
 func main() {
 	const timeout = 20 * time.Second
-	ctx, _ := signal.NotifyContext(context.Background(), os.Interrupt)
+	// docker stop and systemd send SIGTERM, the memory snapshot must be saved on it too
+	ctx, _ := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 
 	// config
 	cfg, err := config.New()
@@ -42,34 +55,112 @@ func main() {
 		logrus.Fatalf("Can't initialized config: %+v", err)
 	}
 
-	var rps repository.SheltersCatRepository
-	client := NewRedis(cfg.RedisURL)
-	redisRepository := repository.NewLocalCache(ctx, client)
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		runMigrate(ctx, cfg, os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "copy" {
+		runCopy(ctx, cfg, os.Args[2:])
+		return
+	}
 
-	switch cfg.DBType {
-	case "postgres":
-		db := NewPostgresDB(cfg.PostgresURL)
-		rps = repository.NewPostgresRepository(db)
-	case "mongo":
-		db := NewMongoDB(cfg.MongoURL)
-		rps = repository.NewMongoRepository(db)
+	var redisRepository repository.RedisRepository
+	var jobs repository.JobRepository
+	if cfg.DBType == "memory" || cfg.RedisURL == "" {
+		// the memory db serves one replica, it has nothing to share through redis
+		logrus.Info("Redis isn't used, the cache and the job locks are kept in the process")
+		redisRepository = repository.NewProcessCacheRepository()
+		jobs = repository.NewJobMemoryRepository()
+	} else {
+		client := NewRedis(cfg.RedisURL)
+		redisRepository = repository.NewLocalCache(ctx, client)
+		jobs = repository.NewJobRedisRepository(client)
+	}
+	storage, closeStorage := NewStorage(ctx, cfg, cfg.DBType)
+
+	var blobs repository.BlobStore
+	switch cfg.BlobStore {
+	case "local":
+		blobs = repository.NewLocalBlobStore(cfg.BlobDir)
 	default:
-		logrus.Fatalf("Unknown db type %v", cfg.DBType)
+		logrus.Fatalf("Unknown blob store %v", cfg.BlobStore)
 	}
 
-	srv := service.NewService(rps, redisRepository)
+	srv := service.NewService(storage.Cats, storage.Events, storage.Shelters, storage.Vaccinations, storage.Photos, blobs, redisRepository)
 	catHandler := handlers.NewCat(srv)
+	shelterHandler := handlers.NewShelter(service.NewShelterService(storage.Shelters, srv))
+	photoHandler := handlers.NewPhoto(service.NewPhotoService(storage.Photos, blobs, srv))
+	intakeHandler := handlers.NewIntake(service.NewIntakeService(storage.Intakes, srv))
+	fosterHandler := handlers.NewFoster(service.NewFosterService(storage.Fosters, srv))
+	adopterHandler := handlers.NewAdopter(service.NewAdopterService(storage.Adopters, srv))
+	scheduler := service.NewScheduler(jobs, replicaName())
+	addJob(scheduler, service.PurgeJob, cfg.PurgeSchedule, service.NewPurgeJob(srv, cfg.PurgeRetention))
+	addJob(scheduler, service.VaccinationReminderJob, cfg.VaccinationReminderSchedule,
+		service.NewVaccinationReminderJob(srv, cfg.VaccinationReminderWindow))
+	addJob(scheduler, service.VaccinationSyncJob, cfg.VaccinationSyncSchedule, service.NewVaccinationSyncJob(srv))
+	scheduler.Start(ctx)
+	relay := service.NewOutboxRelay(storage.Outbox, redisRepository, jobs, replicaName(), cfg.OutboxPollInterval)
+	relay.Start(ctx)
+	adminHandler := handlers.NewAdmin(srv, scheduler, cfg.PurgeRetention)
 
 	e := echo.New()
 	e.Validator = validator.NewValidator()
 
-	v1 := e.Group("/v1")
+	v1 := e.Group("/v1", handlers.Actor(cfg.AdminToken))
 	v1.GET("/swagger/*", echoSwagger.WrapHandler)
 	catRouters := v1.Group("/cat")
 	catRouters.POST("/", catHandler.Create)
+	catRouters.GET("/", catHandler.List)
+	catRouters.POST("/import", catHandler.Import)
+	catRouters.GET("/export", catHandler.Export)
+	catRouters.GET("/by-microchip/:chip", catHandler.GetByMicrochip)
 	catRouters.GET("/:id", catHandler.Get)
 	catRouters.DELETE("/:id", catHandler.Delete)
 	catRouters.PUT("/:id", catHandler.Update)
+	catRouters.PATCH("/:id", catHandler.Patch)
+	catRouters.POST("/:id/restore", catHandler.Restore)
+	catRouters.GET("/:id/history", catHandler.History)
+	catRouters.POST("/:id/reserve", catHandler.Reserve)
+	catRouters.POST("/:id/cancel-reservation", catHandler.CancelReservation)
+	catRouters.POST("/:id/adopt", catHandler.Adopt)
+	catRouters.POST("/:id/return", catHandler.Return)
+	catRouters.GET("/:id/vaccinations", catHandler.Vaccinations)
+	catRouters.POST("/:id/vaccinations", catHandler.AddVaccination)
+	catRouters.DELETE("/:id/vaccinations/:vaccination_id", catHandler.DeleteVaccination)
+	catRouters.POST("/:id/photos", photoHandler.Upload, handlers.PhotoUploadLimit())
+	catRouters.GET("/:id/photos", photoHandler.List)
+	catRouters.DELETE("/:id/photos/:photo_id", photoHandler.Delete)
+	catRouters.GET("/:id/photos/:photo_id/:size", photoHandler.Image)
+	catRouters.GET("/:id/intakes", intakeHandler.Intakes)
+	catRouters.POST("/:id/intakes", intakeHandler.AddIntake)
+	catRouters.POST("/:id/outcomes", intakeHandler.AddOutcome)
+	catRouters.GET("/:id/placements", fosterHandler.Placements)
+	catRouters.POST("/:id/placements", fosterHandler.Place)
+	catRouters.POST("/:id/placements/end", fosterHandler.BringBack)
+	v1.GET("/stats", catHandler.Stats)
+	shelterRouters := v1.Group("/shelter")
+	shelterRouters.POST("/", shelterHandler.Create)
+	shelterRouters.GET("/", shelterHandler.List)
+	shelterRouters.GET("/:id", shelterHandler.Get)
+	shelterRouters.PUT("/:id", shelterHandler.Update)
+	shelterRouters.DELETE("/:id", shelterHandler.Delete)
+	shelterRouters.GET("/:id/cats", shelterHandler.Cats)
+	fosterRouters := v1.Group("/foster")
+	fosterRouters.POST("/", fosterHandler.Create)
+	fosterRouters.GET("/", fosterHandler.List)
+	fosterRouters.GET("/:id", fosterHandler.Get)
+	fosterRouters.PUT("/:id", fosterHandler.Update)
+	fosterRouters.DELETE("/:id", fosterHandler.Delete)
+	adopterRouters := v1.Group("/adopter")
+	adopterRouters.POST("/", adopterHandler.Create)
+	adopterRouters.GET("/", adopterHandler.List)
+	adopterRouters.GET("/:id", adopterHandler.Get)
+	adopterRouters.PUT("/:id", adopterHandler.Update)
+	adopterRouters.DELETE("/:id", adopterHandler.Delete)
+	adopterRouters.GET("/:id/matches", adopterHandler.Matches)
+	adminRouters := v1.Group("/admin", handlers.AdminOnly(cfg.AdminToken))
+	adminRouters.POST("/cat/purge", adminHandler.Purge)
+	adminRouters.GET("/jobs", adminHandler.Jobs)
 
 	go func() {
 		err = e.Start(cfg.ServerPort)
@@ -86,6 +177,176 @@ func main() {
 	if err := e.Shutdown(ctxWithTimeout); err != nil {
 		logrus.Fatalf("Can't shutdown server gracefully: %v", err)
 	}
+	scheduler.Wait()
+	relay.Wait()
+	closeStorage()
+}
+
+// NewStorage creates the repositories of the db type, the returned function saves and closes the storage
+func NewStorage(ctx context.Context, cfg *config.Config, dbType string) (*service.Storage, func()) {
+	switch dbType {
+	case "postgres":
+		db := NewPostgresDB(cfg.PostgresURL)
+		if cfg.MigrateOnStart {
+			migrateUp(ctx, NewPostgresMigrator(db))
+		}
+		return &service.Storage{
+			Cats:         repository.NewPostgresRepository(db),
+			Events:       repository.NewEventPostgresRepository(db),
+			Shelters:     repository.NewShelterPostgresRepository(db),
+			Vaccinations: repository.NewVaccinationPostgresRepository(db),
+			Photos:       repository.NewPhotoPostgresRepository(db),
+			Intakes:      repository.NewIntakePostgresRepository(db),
+			Fosters:      repository.NewFosterPostgresRepository(db),
+			Adopters:     repository.NewAdopterPostgresRepository(db),
+			Outbox:       repository.NewOutboxPostgresRepository(db),
+		}, db.Close
+	case "mongo":
+		db := NewMongoDB(cfg.MongoURL, cfg.MongoDatabase)
+		if err := repository.BootstrapMongo(ctx, db); err != nil {
+			logrus.Fatalf("Can't bootstrap mongo: %v", err)
+		}
+		return &service.Storage{
+			Cats:         repository.NewMongoRepository(db),
+			Events:       repository.NewEventMongoRepository(db),
+			Shelters:     repository.NewShelterMongoRepository(db),
+			Vaccinations: repository.NewVaccinationMongoRepository(db),
+			Photos:       repository.NewPhotoMongoRepository(db),
+			Intakes:      repository.NewIntakeMongoRepository(db),
+			Fosters:      repository.NewFosterMongoRepository(db),
+			Adopters:     repository.NewAdopterMongoRepository(db),
+			Outbox:       repository.NewOutboxMongoRepository(db),
+		}, func() {
+			if err := db.Client().Disconnect(context.Background()); err != nil {
+				logrus.Errorf("Can't disconnect from mongo: %v", err)
+			}
+		}
+	case "memory":
+		memory := NewMemoryDB(cfg.MemorySnapshot)
+		return &service.Storage{
+			Cats:         repository.NewMemoryRepository(memory),
+			Events:       repository.NewEventMemoryRepository(memory),
+			Shelters:     repository.NewShelterMemoryRepository(memory),
+			Vaccinations: repository.NewVaccinationMemoryRepository(memory),
+			Photos:       repository.NewPhotoMemoryRepository(memory),
+			Intakes:      repository.NewIntakeMemoryRepository(memory),
+			Fosters:      repository.NewFosterMemoryRepository(memory),
+			Adopters:     repository.NewAdopterMemoryRepository(memory),
+			Outbox:       repository.NewOutboxMemoryRepository(memory),
+		}, func() {
+			if cfg.MemorySnapshot == "" {
+				return
+			}
+			if err := memory.Save(cfg.MemorySnapshot); err != nil {
+				logrus.Fatalf("Can't save memory snapshot: %v", err)
+			}
+			logrus.Infof("Memory snapshot was saved to %s", cfg.MemorySnapshot)
+		}
+	case "sqlite":
+		sqlite := NewSQLiteDB(cfg.SQLitePath)
+		return &service.Storage{
+			Cats:         repository.NewSQLiteRepository(sqlite),
+			Events:       repository.NewEventSQLiteRepository(sqlite),
+			Shelters:     repository.NewShelterSQLiteRepository(sqlite),
+			Vaccinations: repository.NewVaccinationSQLiteRepository(sqlite),
+			Photos:       repository.NewPhotoSQLiteRepository(sqlite),
+			Intakes:      repository.NewIntakeSQLiteRepository(sqlite),
+			Fosters:      repository.NewFosterSQLiteRepository(sqlite),
+			Adopters:     repository.NewAdopterSQLiteRepository(sqlite),
+			Outbox:       repository.NewOutboxSQLiteRepository(sqlite),
+		}, func() {
+			if err := sqlite.Close(); err != nil {
+				logrus.Fatalf("Can't close sqlite database: %v", err)
+			}
+		}
+	default:
+		logrus.Fatalf("Unknown db type %v", dbType)
+		return nil, nil
+	}
+}
+
+// runCopy runs the copy subcommand: it copies the cats with their records from one db type to another,
+// or compares the records of both with -verify
+func runCopy(ctx context.Context, cfg *config.Config, args []string) {
+	flags := flag.NewFlagSet("copy", flag.ExitOnError)
+	from := flags.String("from", "", "db type to copy from: postgres, mongo, memory or sqlite")
+	to := flags.String("to", "", "db type to copy to: postgres, mongo, memory or sqlite")
+	batch := flags.Int("batch", service.MaxListLimit, "number of records read and written at once")
+	checkpoint := flags.String("checkpoint", "copy-checkpoint.json", "file the progress is kept in to resume the copy")
+	verify := flags.Bool("verify", false, "compare the counts and checksums of the records instead of copying")
+	dryRun := flags.Bool("dry-run", false, "read the source and count the records without writing them")
+	_ = flags.Parse(args)
+	if *from == "" || *to == "" || *from == *to {
+		logrus.Fatalf("Copy needs different -from and -to db types")
+	}
+	if *batch < 1 || *batch > service.MaxListLimit {
+		logrus.Fatalf("Batch must be from 1 to %d", service.MaxListLimit)
+	}
+
+	source, closeSource := NewStorage(ctx, cfg, *from)
+	target, closeTarget := NewStorage(ctx, cfg, *to)
+	srv := service.NewCopyService(*from, source, *to, target, repository.NewCheckpointFileRepository(*checkpoint), *batch)
+	var err error
+	if *verify {
+		err = verifyCopy(ctx, srv)
+	} else {
+		var report *model.CopyReport
+		report, err = srv.Copy(ctx, *dryRun)
+		if report != nil {
+			logrus.Infof("Copied shelters %d, fosters %d, adopters %d, cats %d, records %d, dry run %v",
+				report.Shelters, report.Fosters, report.Adopters, report.Cats, report.Records, *dryRun)
+		}
+	}
+	// the storages are closed before the exit, the memory one saves its snapshot then
+	closeTarget()
+	closeSource()
+	if err != nil {
+		logrus.Fatalf("Can't copy %s to %s: %v", *from, *to, err)
+	}
+}
+
+// verifyCopy logs the differences of the storages, the error is returned when they aren't equal
+func verifyCopy(ctx context.Context, srv *service.CopyService) error {
+	results, err := srv.Verify(ctx)
+	if err != nil {
+		return err
+	}
+	differ := 0
+	for _, result := range results {
+		logrus.Infof("%s: source %d, target %d, missing %d %v, extra %d %v, changed %d %v", result.Kind,
+			result.Source, result.Target, result.MissingCount, result.Missing, result.ExtraCount, result.Extra,
+			result.ChangedCount, result.Changed)
+		if !result.Equal() {
+			differ++
+		}
+	}
+	if differ > 0 {
+		return fmt.Errorf("records of %d kinds differ", differ)
+	}
+	logrus.Info("Storages are equal")
+
+	return nil
+}
+
+// addJob schedules the job, empty schedule disables it
+func addJob(scheduler *service.Scheduler, name, spec string, run service.JobFunc) {
+	if spec == "" {
+		logrus.Infof("Job %s is disabled", name)
+		return
+	}
+	if err := scheduler.Add(name, spec, run); err != nil {
+		logrus.Fatalf("Can't schedule job: %v", err)
+	}
+}
+
+// replicaName names this replica in the job history
+func replicaName() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+
+	return fmt.Sprintf("%s-%d", host, os.Getpid())
 }
 
 // NewPostgresDB create connection to db
@@ -102,8 +363,79 @@ func NewPostgresDB(dbURL string) *pgxpool.Pool {
 	return pool
 }
 
+// NewPostgresMigrator creates the migrator of the migrations embedded into the binary
+func NewPostgresMigrator(pool *pgxpool.Pool) *repository.PostgresMigrator {
+	scripts, err := repository.LoadMigrations(migrations.FS)
+	if err != nil {
+		logrus.Fatalf("Can't load migrations: %v", err)
+	}
+
+	return repository.NewPostgresMigrator(pool, scripts)
+}
+
+// runMigrate runs the migrate subcommand: up, down [steps] or status of the Postgres schema, up by default
+func runMigrate(ctx context.Context, cfg *config.Config, args []string) {
+	migrator := NewPostgresMigrator(NewPostgresDB(cfg.PostgresURL))
+	command := "up"
+	if len(args) > 0 {
+		command = args[0]
+	}
+
+	switch command {
+	case "up":
+		migrateUp(ctx, migrator)
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			var err error
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps < 1 {
+				logrus.Fatalf("Wrong number of steps %v", args[1])
+			}
+		}
+		reverted, err := migrator.Down(ctx, steps)
+		for _, migration := range reverted {
+			logrus.Infof("Migration %s was reverted", migration.Script)
+		}
+		if err != nil {
+			logrus.Fatalf("Can't revert migrations: %v", err)
+		}
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			logrus.Fatalf("Can't get migrations status: %v", err)
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tDESCRIPTION\tSTATE\tINSTALLED ON")
+		for _, status := range statuses {
+			installed := ""
+			if status.InstalledOn != nil {
+				installed = status.InstalledOn.Format(time.RFC3339)
+			}
+			fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", status.Version, status.Description, status.State, installed)
+		}
+		w.Flush()
+	default:
+		logrus.Fatalf("Unknown migrate command %v, use up, down [steps] or status", command)
+	}
+}
+
+// migrateUp applies the pending migrations
+func migrateUp(ctx context.Context, migrator *repository.PostgresMigrator) {
+	applied, err := migrator.Up(ctx)
+	for _, migration := range applied {
+		logrus.Infof("Migration %s was applied", migration.Script)
+	}
+	if err != nil {
+		logrus.Fatalf("Can't apply migrations: %v", err)
+	}
+	if len(applied) == 0 {
+		logrus.Info("Schema is up to date")
+	}
+}
+
 // NewMongoDB create connection to db
-func NewMongoDB(dbURL string) *mongo.Database {
+func NewMongoDB(dbURL, database string) *mongo.Database {
 	const timeout = 10 * time.Minute
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
@@ -117,7 +449,36 @@ func NewMongoDB(dbURL string) *mongo.Database {
 		logrus.Fatalf("connection was not established: %v", err)
 	}
 
-	return client.Database("cats")
+	return client.Database(database)
+}
+
+// NewMemoryDB creates the memory store and loads the snapshot into it, empty path starts with empty store
+func NewMemoryDB(snapshot string) *repository.MemoryStore {
+	store := repository.NewMemoryStore()
+	if snapshot == "" {
+		logrus.Info("Memory db was started without snapshot...")
+		return store
+	}
+	if err := store.Load(snapshot); err != nil {
+		logrus.Fatalf("Unable to load memory snapshot: %v", err)
+	}
+	logrus.Infof("Memory db was started from snapshot %s...", snapshot)
+
+	return store
+}
+
+// NewSQLiteDB opens the sqlite database file and brings its schema up to date
+func NewSQLiteDB(path string) *repository.SQLiteDB {
+	const timeout = time.Minute
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	db, err := repository.OpenSQLite(ctx, path)
+	if err != nil {
+		logrus.Fatalf("Unable to open sqlite database: %v", err)
+	}
+	logrus.Infof("SQLite db was started from %s...", path)
+
+	return db
 }
 
 // NewRedis create connection
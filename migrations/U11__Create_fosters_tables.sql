@@ -0,0 +1,3 @@
+DROP TABLE cat_placements;
+
+DROP TABLE fosters;
@@ -0,0 +1,4 @@
+DROP TABLE adopters;
+
+ALTER TABLE CATS
+    DROP COLUMN good_with_kids;
@@ -0,0 +1,4 @@
+DROP INDEX outbox_pending_idx;
+CREATE INDEX outbox_pending_idx ON outbox (created_at, id) WHERE sent_at IS NULL;
+
+ALTER TABLE outbox DROP COLUMN dead_at;
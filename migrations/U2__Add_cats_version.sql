@@ -0,0 +1,2 @@
+ALTER TABLE CATS
+    DROP COLUMN version;
@@ -0,0 +1,4 @@
+DROP INDEX cats_deleted_at_idx;
+
+ALTER TABLE CATS
+    DROP COLUMN deleted_at;
@@ -0,0 +1 @@
+DROP TABLE CAT_EVENTS;
@@ -0,0 +1,4 @@
+ALTER TABLE CATS
+    DROP COLUMN shelter_id;
+
+DROP TABLE shelters;
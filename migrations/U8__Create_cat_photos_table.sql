@@ -0,0 +1 @@
+DROP TABLE cat_photos;
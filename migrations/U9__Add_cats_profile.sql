@@ -0,0 +1,9 @@
+ALTER TABLE CATS
+    DROP COLUMN birth_date,
+    DROP COLUMN birth_date_estimated,
+    DROP COLUMN sex,
+    DROP COLUMN breed,
+    DROP COLUMN coat_color,
+    DROP COLUMN neutered,
+    DROP COLUMN intake_date,
+    DROP COLUMN microchip;
@@ -0,0 +1,18 @@
+CREATE TABLE cat_intakes
+(
+    id               uuid         NOT NULL PRIMARY KEY,
+    cat_id           uuid         NOT NULL REFERENCES CATS (id) ON DELETE CASCADE,
+    type             varchar(32)  NOT NULL,
+    date             timestamptz  NOT NULL,
+    location         varchar(255) NOT NULL DEFAULT '',
+    notes            text         NOT NULL DEFAULT '',
+    outcome_type     varchar(32),
+    outcome_date     timestamptz,
+    outcome_location varchar(255),
+    outcome_notes    text
+);
+
+CREATE INDEX cat_intakes_cat_id_idx ON cat_intakes (cat_id, date, id);
+
+-- a cat has one open intake at most
+CREATE UNIQUE INDEX cat_intakes_open_idx ON cat_intakes (cat_id) WHERE outcome_type IS NULL;
@@ -0,0 +1,26 @@
+CREATE TABLE fosters
+(
+    id      uuid         NOT NULL PRIMARY KEY,
+    name    varchar(255) NOT NULL,
+    address text         NOT NULL DEFAULT '',
+    contact varchar(255) NOT NULL DEFAULT '',
+    notes   text         NOT NULL DEFAULT ''
+);
+
+-- fosters with placements can't be removed
+CREATE TABLE cat_placements
+(
+    id         uuid        NOT NULL PRIMARY KEY,
+    cat_id     uuid        NOT NULL REFERENCES CATS (id) ON DELETE CASCADE,
+    foster_id  uuid        NOT NULL REFERENCES fosters (id) ON DELETE RESTRICT,
+    started_at timestamptz NOT NULL,
+    ended_at   timestamptz,
+    notes      text        NOT NULL DEFAULT '',
+    CHECK (ended_at IS NULL OR ended_at >= started_at)
+);
+
+CREATE INDEX cat_placements_cat_id_idx ON cat_placements (cat_id, started_at, id);
+CREATE INDEX cat_placements_foster_id_idx ON cat_placements (foster_id);
+
+-- a cat has one active placement at most
+CREATE UNIQUE INDEX cat_placements_active_idx ON cat_placements (cat_id) WHERE ended_at IS NULL;
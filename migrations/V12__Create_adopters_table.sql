@@ -0,0 +1,21 @@
+ALTER TABLE CATS
+    ADD COLUMN good_with_kids boolean;
+
+CREATE TABLE adopters
+(
+    id                          uuid         NOT NULL PRIMARY KEY,
+    name                        varchar(255) NOT NULL,
+    email                       varchar(255) NOT NULL DEFAULT '',
+    phone                       varchar(64)  NOT NULL DEFAULT '',
+    address                     text         NOT NULL DEFAULT '',
+    household_adults            integer      NOT NULL DEFAULT 1,
+    household_children          integer      NOT NULL DEFAULT 0,
+    household_other_pets        boolean      NOT NULL DEFAULT false,
+    household_home_type         varchar(32)  NOT NULL DEFAULT '',
+    preferred_min_age           integer,
+    preferred_max_age           integer,
+    preferred_sex               varchar(16)  NOT NULL DEFAULT '',
+    preferred_vaccinated_only   boolean      NOT NULL DEFAULT false,
+    preferred_good_with_kids    boolean      NOT NULL DEFAULT false,
+    CHECK (preferred_min_age IS NULL OR preferred_max_age IS NULL OR preferred_min_age <= preferred_max_age)
+);
@@ -0,0 +1,14 @@
+CREATE TABLE outbox
+(
+    id         uuid        NOT NULL PRIMARY KEY,
+    action     varchar(64) NOT NULL,
+    payload    text        NOT NULL,
+    -- the clock time of the insert orders the changes of one cat, they wait for the lock of its row
+    created_at timestamptz NOT NULL DEFAULT clock_timestamp(),
+    attempts   integer     NOT NULL DEFAULT 0,
+    last_error text        NOT NULL DEFAULT '',
+    sent_at    timestamptz
+);
+
+CREATE INDEX outbox_pending_idx ON outbox (created_at, id) WHERE sent_at IS NULL;
+CREATE INDEX outbox_sent_at_idx ON outbox (sent_at) WHERE sent_at IS NOT NULL;
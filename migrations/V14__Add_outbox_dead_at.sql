@@ -0,0 +1,5 @@
+-- the relay gives up the message which failed too many times, so it doesn't block the messages after it
+ALTER TABLE outbox ADD COLUMN dead_at timestamptz;
+
+DROP INDEX outbox_pending_idx;
+CREATE INDEX outbox_pending_idx ON outbox (created_at, id) WHERE sent_at IS NULL AND dead_at IS NULL;
@@ -0,0 +1,2 @@
+ALTER TABLE CATS
+    ADD COLUMN version bigint NOT NULL DEFAULT 1;
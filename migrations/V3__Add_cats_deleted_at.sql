@@ -0,0 +1,4 @@
+ALTER TABLE CATS
+    ADD COLUMN deleted_at timestamptz;
+
+CREATE INDEX cats_deleted_at_idx ON CATS (deleted_at) WHERE deleted_at IS NOT NULL;
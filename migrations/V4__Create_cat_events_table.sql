@@ -0,0 +1,12 @@
+CREATE TABLE CAT_EVENTS
+(
+    id         uuid         NOT NULL PRIMARY KEY,
+    cat_id     uuid         NOT NULL,
+    action     varchar(32)  NOT NULL,
+    before     jsonb,
+    after      jsonb,
+    actor      varchar(255) NOT NULL,
+    created_at timestamptz  NOT NULL
+);
+
+CREATE INDEX cat_events_cat_id_idx ON CAT_EVENTS (cat_id, created_at, id);
@@ -0,0 +1,14 @@
+CREATE TABLE shelters
+(
+    id       uuid         NOT NULL PRIMARY KEY,
+    name     varchar(255) NOT NULL,
+    address  text         NOT NULL DEFAULT '',
+    capacity int          NOT NULL DEFAULT 0,
+    contact  varchar(255) NOT NULL DEFAULT ''
+);
+
+-- shelters with cats, even deleted but not purged ones, can't be removed
+ALTER TABLE CATS
+    ADD COLUMN shelter_id uuid REFERENCES shelters (id) ON DELETE RESTRICT;
+
+CREATE INDEX cats_shelter_id_idx ON CATS (shelter_id);
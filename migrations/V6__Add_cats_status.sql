@@ -0,0 +1,5 @@
+ALTER TABLE CATS
+    ADD COLUMN status varchar(32) NOT NULL DEFAULT 'available'
+        CHECK (status IN ('available', 'reserved', 'adopted', 'returned'));
+
+CREATE INDEX cats_status_idx ON CATS (status);
@@ -0,0 +1,12 @@
+CREATE TABLE cat_vaccinations
+(
+    id              uuid         NOT NULL PRIMARY KEY,
+    cat_id          uuid         NOT NULL REFERENCES CATS (id) ON DELETE CASCADE,
+    vaccine         varchar(64)  NOT NULL,
+    administered_at timestamptz  NOT NULL,
+    lot             varchar(64)  NOT NULL DEFAULT '',
+    vet             varchar(255) NOT NULL DEFAULT '',
+    expires_at      timestamptz
+);
+
+CREATE INDEX cat_vaccinations_cat_id_idx ON cat_vaccinations (cat_id, administered_at, id);
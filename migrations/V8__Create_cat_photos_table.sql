@@ -0,0 +1,12 @@
+CREATE TABLE cat_photos
+(
+    id           uuid        NOT NULL PRIMARY KEY,
+    cat_id       uuid        NOT NULL REFERENCES CATS (id) ON DELETE CASCADE,
+    content_type varchar(64) NOT NULL,
+    size         bigint      NOT NULL,
+    width        int         NOT NULL,
+    height       int         NOT NULL,
+    created_at   timestamptz NOT NULL
+);
+
+CREATE INDEX cat_photos_cat_id_idx ON cat_photos (cat_id, created_at, id);
@@ -0,0 +1,9 @@
+ALTER TABLE CATS
+    ADD COLUMN birth_date           date,
+    ADD COLUMN birth_date_estimated boolean      NOT NULL DEFAULT false,
+    ADD COLUMN sex                  varchar(16)  NOT NULL DEFAULT '',
+    ADD COLUMN breed                varchar(255) NOT NULL DEFAULT '',
+    ADD COLUMN coat_color           varchar(255) NOT NULL DEFAULT '',
+    ADD COLUMN neutered             boolean,
+    ADD COLUMN intake_date          date,
+    ADD COLUMN microchip            varchar(32) UNIQUE;
@@ -0,0 +1,9 @@
+// Package migrations contains the Postgres schema migrations in the flyway naming
+package migrations
+
+import "embed"
+
+// FS holds the versioned V<version>__<description>.sql migrations and their undo U<version>__<description>.sql scripts
+//
+//go:embed *.sql
+var FS embed.FS